@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hxnx/tunebot/config"
+	"github.com/hxnx/tunebot/internal/database"
+	"github.com/hxnx/tunebot/internal/redis"
+)
+
+func fail(message string, err error) int {
+	fmt.Fprintf(os.Stderr, "Error: %s: %v\n", message, err)
+	return 1
+}
+
+func databaseConfig(cfg *config.Config) *database.Config {
+	return &database.Config{
+		Host:     cfg.DBHost,
+		Port:     cfg.DBPort,
+		User:     cfg.DBUser,
+		Password: cfg.DBPassword,
+		DBName:   cfg.DBName,
+		SSLMode:  cfg.DBSSLMode,
+	}
+}
+
+func redisConfig(cfg *config.Config) redis.Config {
+	return redis.Config{
+		Host:     cfg.RedisHost,
+		Port:     cfg.RedisPort,
+		Password: cfg.RedisPassword,
+		DB:       cfg.RedisDB,
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hxnx/tunebot/config"
+	"github.com/hxnx/tunebot/internal/database"
+	"github.com/hxnx/tunebot/internal/redis"
+)
+
+type doctorCheck struct {
+	name string
+	run  func(ctx context.Context) (string, error)
+}
+
+func runDoctor(args []string) int {
+	if len(args) > 0 {
+		fmt.Println("Usage: tunebot doctor")
+		return 2
+	}
+
+	cfg, loadErr := config.LoadUnvalidated()
+
+	checks := []doctorCheck{
+		{"ffmpeg", binaryVersion("ffmpeg", "-version")},
+		{"yt-dlp", binaryVersion("yt-dlp", "--version")},
+		{"configuration", func(ctx context.Context) (string, error) {
+			if loadErr != nil {
+				return "", loadErr
+			}
+			if err := cfg.Validate(); err != nil {
+				return "", err
+			}
+			detail := "required settings present"
+			if len(cfg.BotOwnerIDs) == 0 {
+				detail += ", no BOT_OWNER_IDS"
+			}
+			if cfg.SpotifyClientID == "" || cfg.SpotifyClientSecret == "" {
+				detail += ", Spotify not configured"
+			}
+			return detail, nil
+		}},
+		{"postgres", func(ctx context.Context) (string, error) {
+			if loadErr != nil {
+				return "", errors.New("skipped: configuration could not be loaded")
+			}
+			if err := database.Connect(databaseConfig(cfg)); err != nil {
+				return "", err
+			}
+			states, err := database.MigrationStatus(ctx)
+			if err != nil {
+				return "", err
+			}
+			pending := 0
+			for _, state := range states {
+				if !state.Applied {
+					pending++
+				}
+			}
+			return fmt.Sprintf("%s:%d/%s, %d pending migration(s)", cfg.DBHost, cfg.DBPort, cfg.DBName, pending), nil
+		}},
+		{"redis", func(ctx context.Context) (string, error) {
+			if loadErr != nil {
+				return "", errors.New("skipped: configuration could not be loaded")
+			}
+			if cfg.RedisHost == "" {
+				return "", errors.New("REDIS_HOST is not configured")
+			}
+			if _, err := redis.Init(redisConfig(cfg)); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%s:%d/%d", cfg.RedisHost, cfg.RedisPort, cfg.RedisDB), redis.Ping(ctx)
+		}},
+	}
+	defer database.Close()
+	defer redis.Close()
+
+	failed := 0
+	for _, check := range checks {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		detail, err := check.run(ctx)
+		cancel()
+
+		if err != nil {
+			failed++
+			fmt.Printf("[FAIL] %-14s %s\n", check.name, strings.ReplaceAll(err.Error(), "\n", "\n                      "))
+			continue
+		}
+		fmt.Printf("[ OK ] %-14s %s\n", check.name, detail)
+	}
+
+	if failed > 0 {
+		fmt.Printf("\n%d of %d check(s) failed\n", failed, len(checks))
+		return 1
+	}
+	fmt.Printf("\nAll %d checks passed\n", len(checks))
+	return 0
+}
+
+func binaryVersion(name string, flag string) func(ctx context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		path, err := exec.LookPath(name)
+		if err != nil {
+			return "", err
+		}
+		out, err := exec.CommandContext(ctx, path, flag).Output()
+		if err != nil {
+			return "", fmt.Errorf("%s %s: %w", path, flag, err)
+		}
+		scanner := bufio.NewScanner(strings.NewReader(string(out)))
+		if scanner.Scan() {
+			return strings.TrimSpace(scanner.Text()), nil
+		}
+		return path, nil
+	}
+}
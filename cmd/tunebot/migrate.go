@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/hxnx/tunebot/config"
+	"github.com/hxnx/tunebot/internal/database"
+)
+
+const migrateUsage = `Usage: tunebot migrate <command>
+
+Commands:
+  status       Show applied and pending migrations
+  up           Apply all pending migrations
+  down [N]     Roll back the last N migrations (default: 1)`
+
+func runMigrate(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	steps := 1
+	switch args[0] {
+	case "status", "up":
+		if len(args) > 1 {
+			fmt.Fprintln(os.Stderr, migrateUsage)
+			return 2
+		}
+	case "down":
+		if len(args) > 2 {
+			fmt.Fprintln(os.Stderr, migrateUsage)
+			return 2
+		}
+		if len(args) == 2 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				fmt.Fprintf(os.Stderr, "Error: invalid step count %q\n", args[1])
+				return 2
+			}
+			steps = n
+		}
+	case "help", "-h", "--help":
+		fmt.Println(migrateUsage)
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown migrate command %q\n\n%s\n", args[0], migrateUsage)
+		return 2
+	}
+
+	cfg, err := config.LoadUnvalidated()
+	if err != nil {
+		return fail("Failed to load configuration", err)
+	}
+
+	if err := database.Connect(databaseConfig(cfg)); err != nil {
+		return fail("Failed to connect to database", err)
+	}
+	defer database.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	switch args[0] {
+	case "status":
+		states, err := database.MigrationStatus(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		pending := 0
+		for _, state := range states {
+			if state.Applied {
+				fmt.Printf("  %04d  %-28s applied %s\n", state.Version, state.Name, state.AppliedAt.Local().Format(time.RFC3339))
+			} else {
+				pending++
+				fmt.Printf("  %04d  %-28s pending\n", state.Version, state.Name)
+			}
+		}
+		fmt.Printf("%d migration(s), %d pending\n", len(states), pending)
+	case "up":
+		applied, err := database.MigrateUp(ctx)
+		for _, m := range applied {
+			fmt.Printf("Applied %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		if len(applied) == 0 {
+			fmt.Println("Schema is up to date")
+		}
+	case "down":
+		reverted, err := database.MigrateDown(ctx, steps)
+		for _, m := range reverted {
+			fmt.Printf("Rolled back %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		if len(reverted) == 0 {
+			fmt.Println("No applied migrations to roll back")
+		}
+	}
+	return 0
+}
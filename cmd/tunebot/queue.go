@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hxnx/tunebot/config"
+	"github.com/hxnx/tunebot/internal/music"
+	"github.com/hxnx/tunebot/internal/redis"
+)
+
+const queueUsage = `Usage: tunebot queue <command> <guild>
+
+Commands:
+  dump <guild>    Print the queue and settings of a guild as JSON
+  clear <guild>   Remove every queued track of a guild`
+
+type queueDump struct {
+	GuildID  string              `json:"guild_id"`
+	Settings music.QueueSettings `json:"settings"`
+	Items    []music.QueueItem   `json:"items"`
+}
+
+func runQueue(args []string) int {
+	if len(args) != 2 || (args[0] != "dump" && args[0] != "clear") {
+		fmt.Fprintln(os.Stderr, queueUsage)
+		return 2
+	}
+	action, guildID := args[0], args[1]
+
+	cfg, err := config.LoadUnvalidated()
+	if err != nil {
+		return fail("Failed to load configuration", err)
+	}
+	if cfg.RedisHost == "" {
+		return fail("Failed to connect to redis", errors.New("REDIS_HOST is not configured"))
+	}
+
+	client, err := redis.Init(redisConfig(cfg))
+	if err != nil {
+		return fail("Failed to connect to redis", err)
+	}
+	defer redis.Close()
+
+	store := music.NewQueueStore(client)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	switch action {
+	case "dump":
+		items, err := store.List(ctx, guildID, 0)
+		if err != nil {
+			return fail("Failed to read queue", err)
+		}
+		settings, err := store.GetSettings(ctx, guildID)
+		if err != nil {
+			return fail("Failed to read queue settings", err)
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(queueDump{GuildID: guildID, Settings: settings, Items: items}); err != nil {
+			return fail("Failed to encode queue", err)
+		}
+	case "clear":
+		size, err := store.QueueSize(ctx, guildID)
+		if err != nil {
+			return fail("Failed to read queue", err)
+		}
+		if err := store.Clear(ctx, guildID); err != nil {
+			return fail("Failed to clear queue", err)
+		}
+		fmt.Printf("Cleared %d track(s) from guild %s\n", size, guildID)
+	}
+	return 0
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/config"
+	commands "github.com/hxnx/tunebot/internal/features"
+)
+
+func runRegisterCommands(args []string) int {
+	flags := flag.NewFlagSet("register-commands", flag.ContinueOnError)
+	guildID := flags.String("guild", "", "register to this guild instead of DISCORD_GUILD_ID")
+	global := flags.Bool("global", false, "register globally even if DISCORD_GUILD_ID is set")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: tunebot register-commands [--global | --guild ID]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() > 0 || (*global && *guildID != "") {
+		flags.Usage()
+		return 2
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fail("Failed to load configuration", err)
+	}
+
+	target := cfg.GuildID
+	if *guildID != "" {
+		target = *guildID
+	}
+	if *global {
+		target = ""
+	}
+
+	s, err := discordgo.New("Bot " + cfg.DiscordToken)
+	if err != nil {
+		return fail("Failed to create Discord session", err)
+	}
+
+	registered, err := commands.RegisterCommands(s, cfg.ApplicationID, target)
+	if err != nil {
+		return fail("Failed to register commands", err)
+	}
+
+	scope := "globally"
+	if target != "" {
+		scope = "to guild " + target
+	}
+	fmt.Fprintf(os.Stdout, "Registered %d command(s) %s\n", len(registered), scope)
+	for _, cmd := range registered {
+		fmt.Printf("  /%s (%s)\n", cmd.Name, cmd.ID)
+	}
+	return 0
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hxnx/tunebot/config"
+	"github.com/hxnx/tunebot/internal/music"
+)
+
+func runResolve(args []string) int {
+	flags := flag.NewFlagSet("resolve", flag.ContinueOnError)
+	source := flags.String("source", "", "provider hint (youtube, soundcloud, spotify, http, ...)")
+	skipStream := flags.Bool("no-stream", false, "do not resolve the playable stream URL")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: tunebot resolve [--source NAME] [--no-stream] <url|query>")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	input := strings.TrimSpace(strings.Join(flags.Args(), " "))
+	if input == "" {
+		flags.Usage()
+		return 2
+	}
+
+	cfg, err := config.LoadUnvalidated()
+	if err != nil {
+		return fail("Failed to load configuration", err)
+	}
+
+	var spotify *music.SpotifyClient
+	if cfg.SpotifyClientID != "" && cfg.SpotifyClientSecret != "" {
+		spotify = music.NewSpotifyClient(cfg.SpotifyClientID, cfg.SpotifyClientSecret)
+	}
+	service := music.NewService(nil, music.NewDefaultProviderRegistry(spotify))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	hint := music.TrackSource(strings.ToLower(*source))
+	if *source == "" {
+		hint = music.TrackSourceUnknown
+	}
+
+	started := time.Now()
+	track, err := service.ResolveInput(ctx, input, hint, "")
+	if err != nil {
+		return fail("Failed to resolve input", err)
+	}
+
+	fmt.Printf("Source:    %s\n", track.Source)
+	fmt.Printf("Title:     %s\n", track.Title)
+	fmt.Printf("URL:       %s\n", track.URL)
+	if track.IsLive {
+		fmt.Printf("Duration:  live\n")
+	} else {
+		fmt.Printf("Duration:  %s\n", track.Duration)
+	}
+	if track.ISRC != "" {
+		fmt.Printf("ISRC:      %s\n", track.ISRC)
+	}
+	if track.Thumbnail != "" {
+		fmt.Printf("Thumbnail: %s\n", track.Thumbnail)
+	}
+	fmt.Printf("Resolved in %s\n", time.Since(started).Round(time.Millisecond))
+
+	if *skipStream {
+		return 0
+	}
+
+	provider, err := service.Providers().Lookup(track.URL, track.Source)
+	if err != nil {
+		return fail("Failed to find playback provider", err)
+	}
+
+	started = time.Now()
+	streamURL, err := provider.StreamURL(ctx, track)
+	if err != nil {
+		return fail("Failed to resolve stream URL", err)
+	}
+	fmt.Printf("Stream:    %s\n", streamURL)
+	fmt.Printf("Stream resolved in %s\n", time.Since(started).Round(time.Millisecond))
+	return 0
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/hxnx/tunebot/config"
+	"github.com/hxnx/tunebot/internal/bot"
+	"github.com/hxnx/tunebot/internal/logging"
+)
+
+const runUsage = `Please ensure you have set the following environment variables:
+  DISCORD_TOKEN          - Your Discord bot token (required)
+  DISCORD_APPLICATION_ID - Your Discord application ID (required)
+
+Optional environment variables:
+  DISCORD_GUILD_ID       - Guild ID for development (registers commands to specific guild)
+  BOT_OWNER_IDS          - Comma-separated user IDs allowed to use owner commands
+  SHARD_COUNT            - Number of shards (0 = auto-detect)
+  SHARD_IDS              - Shards run by this process, e.g. 0-3,8 (default: all)
+  INSTANCE_ID            - Unique name of this process (default: hostname)
+  HTTP_ADDR              - Health endpoint listen address (default: :8080, off = disabled)
+  METRICS_ADDR           - Prometheus /metrics listen address (default: :9090, off = disabled)
+  LOG_LEVEL              - Log level (debug, info, warn, error)
+  LOG_FORMAT             - Log format (text, json, default: text)
+  CONFIG_FILE            - Optional YAML/TOML config file (environment variables take precedence)
+  FEATURE_ANNOUNCEMENTS  - Enable now-playing announcements (default: true)
+  FEATURE_PLAY_HISTORY   - Record play history (default: true)
+  FEATURE_WEB_API        - Enable the web control REST API (default: false)
+  FEATURE_WEBHOOKS       - Enable outgoing playback webhooks (default: false)
+  WEBHOOK_ALLOW_PRIVATE  - Allow webhooks to private/loopback addresses (default: false)
+  FEATURE_SCROBBLING     - Enable Last.fm/ListenBrainz scrobbling for linked users (default: true)
+  PUBLIC_URL             - External base URL of the HTTP server (optional)
+  DEFAULT_VOLUME         - Default volume level (0-200, default: 100)
+  MAX_QUEUE_SIZE         - Maximum queue size per guild (default: 500)
+  AUTO_LEAVE_TIMEOUT     - Auto-leave timeout in seconds (0 = disabled, default: 300)
+
+Database configuration:
+  DB_HOST, DB_PORT, DB_USER, DB_PASSWORD, DB_NAME, DB_SSLMODE
+
+Redis configuration:
+  REDIS_HOST, REDIS_PORT, REDIS_PASSWORD, REDIS_DB
+
+Spotify configuration:
+  SPOTIFY_CLIENT_ID, SPOTIFY_CLIENT_SECRET
+
+Last.fm configuration (scrobbling):
+  LASTFM_API_KEY, LASTFM_API_SECRET
+
+Local library configuration:
+  LOCAL_LIBRARY_DIR      - Directory of audio files to index (optional)`
+
+func runBot(args []string) int {
+	if len(args) > 0 {
+		fmt.Fprintf(os.Stderr, "Error: run takes no arguments\n")
+		return 2
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to load configuration: %v\n\n", err)
+		fmt.Fprintln(os.Stderr, runUsage)
+		return 1
+	}
+
+	if err := logging.Setup(cfg.LogLevel, cfg.LogFormat); err != nil {
+		return fail("Failed to configure logging", err)
+	}
+
+	logConfig(cfg)
+
+	b, err := bot.New(cfg)
+	if err != nil {
+		slog.Error("failed to create bot", "error", err)
+		return 1
+	}
+
+	slog.Info("starting bot")
+	if err := b.Start(); err != nil {
+		slog.Error("failed to start bot", "error", err)
+		return 1
+	}
+
+	slog.Info("bot is running; press CTRL+C to exit")
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	for sig := range sigCh {
+		if sig != syscall.SIGHUP {
+			break
+		}
+		if _, err := b.ReloadConfig(); err != nil {
+			slog.Error("failed to reload configuration", "error", err)
+		}
+	}
+
+	slog.Info("shutting down")
+	if err := b.Stop(); err != nil {
+		slog.Error("failed to stop bot", "error", err)
+		return 1
+	}
+	return 0
+}
+
+func logConfig(cfg *config.Config) {
+	mode := "production"
+	if cfg.IsDevelopment() {
+		mode = "development"
+	}
+	slog.Info("configuration loaded",
+		"mode", mode,
+		"guild_id", cfg.GuildID,
+		"log_level", cfg.LogLevel,
+		"log_format", cfg.LogFormat,
+		"config_file", cfg.ConfigFile,
+		"bot_owners", len(cfg.BotOwnerIDs),
+	)
+	if len(cfg.BotOwnerIDs) == 0 {
+		slog.Warn("BOT_OWNER_IDS is empty; !sync and owner commands are disabled for everyone")
+	}
+
+	slog.Info("bot settings",
+		"default_volume", cfg.DefaultVolume,
+		"max_queue_size", cfg.MaxQueueSize,
+		"auto_leave_timeout_seconds", cfg.AutoLeaveTimeout,
+	)
+	slog.Info("sharding",
+		"shard_count", cfg.ShardCount,
+		"shard_ids", cfg.ShardIDs,
+		"instance_id", cfg.InstanceID,
+	)
+	slog.Info("http server", "addr", cfg.HTTPAddr, "metrics_addr", cfg.MetricsAddr, "web_api", cfg.FeatureWebAPI)
+	slog.Info("database",
+		"host", cfg.DBHost,
+		"port", cfg.DBPort,
+		"name", cfg.DBName,
+		"user", cfg.DBUser,
+		"ssl_mode", cfg.DBSSLMode,
+	)
+	slog.Info("redis",
+		"host", cfg.RedisHost,
+		"port", cfg.RedisPort,
+		"db", cfg.RedisDB,
+	)
+	slog.Info("spotify", "configured", cfg.SpotifyClientID != "" && cfg.SpotifyClientSecret != "")
+	slog.Info("scrobbling",
+		"enabled", cfg.FeatureScrobbling,
+		"lastfm", cfg.FeatureScrobbling && cfg.LastFMAPIKey != "" && cfg.LastFMAPISecret != "",
+	)
+	slog.Info("local library", "dir", cfg.LocalLibraryDir)
+}
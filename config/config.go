@@ -1,26 +1,47 @@
 package config
 
 import (
-	"errors"
+	"fmt"
 	"os"
+	"reflect"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
+	ConfigFile string
+
 	DiscordToken  string
 	ApplicationID string
 
-	GuildID string
+	GuildID     string
+	BotOwnerIDs []string
 
 	ShardCount int
+	ShardIDs   []int
+	InstanceID string
+
+	HTTPAddr    string
+	MetricsAddr string
+	PublicURL   string
 
 	LogLevel         string
+	LogFormat        string
 	AutoLeaveTimeout int
 	DefaultVolume    int
 	MaxQueueSize     int
 
+	FeatureAnnouncements bool
+	FeaturePlayHistory   bool
+	FeatureWebAPI        bool
+	FeatureWebhooks      bool
+	FeatureScrobbling    bool
+
+	WebhookAllowPrivate bool
+
 	DBHost     string
 	DBPort     int
 	DBUser     string
@@ -35,98 +56,361 @@ type Config struct {
 
 	SpotifyClientID     string
 	SpotifyClientSecret string
+
+	LastFMAPIKey    string
+	LastFMAPISecret string
+
+	LocalLibraryDir string
+}
+
+type setting struct {
+	key    string
+	reload bool
+	field  func(c *Config) any
+}
+
+var settings = []setting{
+	{"DISCORD_TOKEN", false, func(c *Config) any { return &c.DiscordToken }},
+	{"DISCORD_APPLICATION_ID", false, func(c *Config) any { return &c.ApplicationID }},
+	{"DISCORD_GUILD_ID", false, func(c *Config) any { return &c.GuildID }},
+	{"BOT_OWNER_IDS", true, func(c *Config) any { return &c.BotOwnerIDs }},
+	{"SHARD_COUNT", false, func(c *Config) any { return &c.ShardCount }},
+	{"SHARD_IDS", false, func(c *Config) any { return &c.ShardIDs }},
+	{"INSTANCE_ID", false, func(c *Config) any { return &c.InstanceID }},
+	{"HTTP_ADDR", false, func(c *Config) any { return &c.HTTPAddr }},
+	{"METRICS_ADDR", false, func(c *Config) any { return &c.MetricsAddr }},
+	{"PUBLIC_URL", true, func(c *Config) any { return &c.PublicURL }},
+	{"LOG_LEVEL", true, func(c *Config) any { return &c.LogLevel }},
+	{"LOG_FORMAT", true, func(c *Config) any { return &c.LogFormat }},
+	{"AUTO_LEAVE_TIMEOUT", true, func(c *Config) any { return &c.AutoLeaveTimeout }},
+	{"DEFAULT_VOLUME", true, func(c *Config) any { return &c.DefaultVolume }},
+	{"MAX_QUEUE_SIZE", true, func(c *Config) any { return &c.MaxQueueSize }},
+	{"FEATURE_ANNOUNCEMENTS", true, func(c *Config) any { return &c.FeatureAnnouncements }},
+	{"FEATURE_PLAY_HISTORY", true, func(c *Config) any { return &c.FeaturePlayHistory }},
+	{"FEATURE_WEB_API", false, func(c *Config) any { return &c.FeatureWebAPI }},
+	{"FEATURE_WEBHOOKS", true, func(c *Config) any { return &c.FeatureWebhooks }},
+	{"WEBHOOK_ALLOW_PRIVATE", true, func(c *Config) any { return &c.WebhookAllowPrivate }},
+	{"FEATURE_SCROBBLING", true, func(c *Config) any { return &c.FeatureScrobbling }},
+	{"DB_HOST", false, func(c *Config) any { return &c.DBHost }},
+	{"DB_PORT", false, func(c *Config) any { return &c.DBPort }},
+	{"DB_USER", false, func(c *Config) any { return &c.DBUser }},
+	{"DB_PASSWORD", false, func(c *Config) any { return &c.DBPassword }},
+	{"DB_NAME", false, func(c *Config) any { return &c.DBName }},
+	{"DB_SSLMODE", false, func(c *Config) any { return &c.DBSSLMode }},
+	{"REDIS_HOST", false, func(c *Config) any { return &c.RedisHost }},
+	{"REDIS_PORT", false, func(c *Config) any { return &c.RedisPort }},
+	{"REDIS_PASSWORD", false, func(c *Config) any { return &c.RedisPassword }},
+	{"REDIS_DB", false, func(c *Config) any { return &c.RedisDB }},
+	{"SPOTIFY_CLIENT_ID", false, func(c *Config) any { return &c.SpotifyClientID }},
+	{"SPOTIFY_CLIENT_SECRET", false, func(c *Config) any { return &c.SpotifyClientSecret }},
+	{"LASTFM_API_KEY", false, func(c *Config) any { return &c.LastFMAPIKey }},
+	{"LASTFM_API_SECRET", false, func(c *Config) any { return &c.LastFMAPISecret }},
+	{"LOCAL_LIBRARY_DIR", false, func(c *Config) any { return &c.LocalLibraryDir }},
+}
+
+func defaults() *Config {
+	return &Config{
+		HTTPAddr:             ":8080",
+		MetricsAddr:          ":9090",
+		LogLevel:             "info",
+		LogFormat:            "text",
+		AutoLeaveTimeout:     300,
+		DefaultVolume:        100,
+		MaxQueueSize:         500,
+		FeatureAnnouncements: true,
+		FeaturePlayHistory:   true,
+		FeatureScrobbling:    true,
+	}
 }
 
 func Load() (*Config, error) {
-	_ = godotenv.Load()
+	cfg, problems, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	problems = append(problems, cfg.problems()...)
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
+
+	return cfg, nil
+}
+
+func LoadUnvalidated() (*Config, error) {
+	cfg, problems, err := load()
+	if err != nil {
+		return nil, err
+	}
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
+	return cfg, nil
+}
 
-	cfg := &Config{
-		DiscordToken:  os.Getenv("DISCORD_TOKEN"),
-		ApplicationID: os.Getenv("DISCORD_APPLICATION_ID"),
+func load() (*Config, []string, error) {
+	_ = godotenv.Load()
 
-		GuildID: os.Getenv("DISCORD_GUILD_ID"),
+	cfg := defaults()
+	cfg.ConfigFile = strings.TrimSpace(os.Getenv("CONFIG_FILE"))
 
-		ShardCount: getEnvAsIntWithDefault("SHARD_COUNT", 0),
+	problems := []string{}
 
-		LogLevel:         getEnvWithDefault("LOG_LEVEL", "info"),
-		AutoLeaveTimeout: getEnvAsIntWithDefault("AUTO_LEAVE_TIMEOUT", 300),
-		DefaultVolume:    getEnvAsIntWithDefault("DEFAULT_VOLUME", 100),
-		MaxQueueSize:     getEnvAsIntWithDefault("MAX_QUEUE_SIZE", 500),
+	fileValues := map[string]string{}
+	if cfg.ConfigFile != "" {
+		values, err := readFile(cfg.ConfigFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		fileValues = values
+	}
 
-		DBHost:     os.Getenv("DB_HOST"),
-		DBPort:     getEnvAsInt("DB_PORT"),
-		DBUser:     os.Getenv("DB_USER"),
-		DBPassword: os.Getenv("DB_PASSWORD"),
-		DBName:     os.Getenv("DB_NAME"),
-		DBSSLMode:  os.Getenv("DB_SSLMODE"),
+	known := make(map[string]bool, len(settings))
+	for _, s := range settings {
+		known[s.key] = true
 
-		RedisHost:     os.Getenv("REDIS_HOST"),
-		RedisPort:     getEnvAsInt("REDIS_PORT"),
-		RedisPassword: os.Getenv("REDIS_PASSWORD"),
-		RedisDB:       getEnvAsIntWithDefault("REDIS_DB", 0),
+		raw, ok := os.LookupEnv(s.key)
+		if !ok || raw == "" {
+			raw, ok = fileValues[s.key]
+		}
+		if !ok {
+			continue
+		}
+		if err := s.set(cfg, raw); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
 
-		SpotifyClientID:     os.Getenv("SPOTIFY_CLIENT_ID"),
-		SpotifyClientSecret: os.Getenv("SPOTIFY_CLIENT_SECRET"),
+	unknown := []string{}
+	for key := range fileValues {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		problems = append(problems, fmt.Sprintf("%s: unknown key in %s", key, cfg.ConfigFile))
 	}
 
-	if err := cfg.Validate(); err != nil {
-		return nil, err
+	if strings.EqualFold(cfg.HTTPAddr, "off") {
+		cfg.HTTPAddr = ""
+	}
+	if strings.EqualFold(cfg.MetricsAddr, "off") {
+		cfg.MetricsAddr = ""
+	}
+	if cfg.InstanceID == "" {
+		cfg.InstanceID = defaultInstanceID()
 	}
 
-	return cfg, nil
+	return cfg, problems, nil
 }
 
 func (c *Config) Validate() error {
+	if problems := c.problems(); len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+func (c *Config) problems() []string {
+	problems := []string{}
+
 	if c.DiscordToken == "" {
-		return errors.New("DISCORD_TOKEN is required")
+		problems = append(problems, "DISCORD_TOKEN is required")
 	}
 
 	if c.ApplicationID == "" {
-		return errors.New("DISCORD_APPLICATION_ID is required")
+		problems = append(problems, "DISCORD_APPLICATION_ID is required")
+	}
+
+	for _, id := range c.BotOwnerIDs {
+		if _, err := strconv.ParseUint(id, 10, 64); err != nil {
+			problems = append(problems, fmt.Sprintf("BOT_OWNER_IDS contains invalid user ID %q", id))
+		}
+	}
+
+	switch strings.ToLower(c.LogLevel) {
+	case "debug", "info", "warn", "warning", "error":
+	default:
+		problems = append(problems, fmt.Sprintf("LOG_LEVEL must be one of debug, info, warn, error (got %q)", c.LogLevel))
+	}
+
+	switch strings.ToLower(c.LogFormat) {
+	case "text", "json":
+	default:
+		problems = append(problems, fmt.Sprintf("LOG_FORMAT must be text or json (got %q)", c.LogFormat))
+	}
+
+	if c.FeatureWebAPI && c.HTTPAddr == "" {
+		problems = append(problems, "FEATURE_WEB_API requires HTTP_ADDR to be enabled")
+	}
+
+	if c.MetricsAddr != "" && c.MetricsAddr == c.HTTPAddr {
+		problems = append(problems, "METRICS_ADDR must differ from HTTP_ADDR")
+	}
+
+	if c.PublicURL != "" && !strings.HasPrefix(c.PublicURL, "http://") && !strings.HasPrefix(c.PublicURL, "https://") {
+		problems = append(problems, fmt.Sprintf("PUBLIC_URL must start with http:// or https:// (got %q)", c.PublicURL))
 	}
 
 	if c.DefaultVolume < 0 || c.DefaultVolume > 200 {
-		return errors.New("DEFAULT_VOLUME must be between 0 and 200")
+		problems = append(problems, "DEFAULT_VOLUME must be between 0 and 200")
 	}
 
 	if c.MaxQueueSize < 1 {
-		return errors.New("MAX_QUEUE_SIZE must be at least 1")
+		problems = append(problems, "MAX_QUEUE_SIZE must be at least 1")
 	}
 
-	return nil
+	if c.AutoLeaveTimeout < 0 {
+		problems = append(problems, "AUTO_LEAVE_TIMEOUT must not be negative")
+	}
+
+	if c.ShardCount < 0 {
+		problems = append(problems, "SHARD_COUNT must not be negative")
+	}
+
+	if c.ShardCount > 0 {
+		for _, id := range c.ShardIDs {
+			if id >= c.ShardCount {
+				problems = append(problems, fmt.Sprintf("SHARD_IDS contains shard %d but SHARD_COUNT is %d", id, c.ShardCount))
+			}
+		}
+	}
+
+	if c.DBPort < 0 || c.DBPort > 65535 {
+		problems = append(problems, "DB_PORT must be a valid TCP port")
+	}
+
+	if c.RedisPort < 0 || c.RedisPort > 65535 {
+		problems = append(problems, "REDIS_PORT must be a valid TCP port")
+	}
+
+	return problems
 }
 
 func (c *Config) IsDevelopment() bool {
 	return c.GuildID != ""
 }
 
-func getEnvAsInt(key string) int {
-	return getEnvAsIntWithDefault(key, 0)
-}
+func (c *Config) Reload(next *Config) (*Config, []string, []string) {
+	merged := *c
+	changed := []string{}
+	ignored := []string{}
 
-func getEnvAsIntWithDefault(key string, defaultValue int) int {
-	if value, ok := os.LookupEnv(key); ok {
-		if intValue, err := strconv.Atoi(value); err == nil {
-			return intValue
+	for _, s := range settings {
+		if s.equal(c, next) {
+			continue
+		}
+		if !s.reload {
+			ignored = append(ignored, s.key)
+			continue
 		}
+		s.copy(&merged, next)
+		changed = append(changed, s.key)
 	}
-	return defaultValue
+
+	return &merged, changed, ignored
 }
 
-func getEnvWithDefault(key, defaultValue string) string {
-	if value, ok := os.LookupEnv(key); ok {
-		return value
+func (s setting) set(c *Config, raw string) error {
+	raw = strings.TrimSpace(raw)
+
+	switch dst := s.field(c).(type) {
+	case *string:
+		*dst = raw
+	case *int:
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("%s: %q is not an integer", s.key, raw)
+		}
+		*dst = value
+	case *bool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%s: %q is not a boolean", s.key, raw)
+		}
+		*dst = value
+	case *[]string:
+		var values []string
+		for _, part := range strings.Split(raw, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				values = append(values, part)
+			}
+		}
+		*dst = values
+	case *[]int:
+		ids, err := ParseShardIDs(raw)
+		if err != nil {
+			return fmt.Errorf("%s: %w", s.key, err)
+		}
+		*dst = ids
+	default:
+		return fmt.Errorf("%s: unsupported setting type", s.key)
 	}
-	return defaultValue
+	return nil
 }
 
-func getEnvAsBool(key string) bool {
-	if value := os.Getenv(key); value != "" {
-		if boolValue, err := strconv.ParseBool(value); err == nil {
-			return boolValue
+func (s setting) equal(a, b *Config) bool {
+	return reflect.DeepEqual(s.field(a), s.field(b))
+}
+
+func (s setting) copy(dst, src *Config) {
+	reflect.ValueOf(s.field(dst)).Elem().Set(reflect.ValueOf(s.field(src)).Elem())
+}
+
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+func ParseShardIDs(value string) ([]int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	seen := make(map[int]bool)
+	ids := []int{}
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		start, end := part, part
+		if from, to, ok := strings.Cut(part, "-"); ok {
+			start, end = strings.TrimSpace(from), strings.TrimSpace(to)
+		}
+
+		first, err := strconv.Atoi(start)
+		if err != nil || first < 0 {
+			return nil, fmt.Errorf("invalid SHARD_IDS entry %q", part)
+		}
+		last, err := strconv.Atoi(end)
+		if err != nil || last < first {
+			return nil, fmt.Errorf("invalid SHARD_IDS entry %q", part)
 		}
+
+		for id := first; id <= last; id++ {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	sort.Ints(ids)
+	return ids, nil
+}
+
+func defaultInstanceID() string {
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		return hostname
 	}
-	return false
+	return "tunebot"
 }
 
 type DBConfig struct {
@@ -163,5 +447,6 @@ func (c *Config) GetRedisConfig() *RedisConfig {
 		Port:     c.RedisPort,
 		Password: c.RedisPassword,
 		DB:       c.RedisDB,
+		Enabled:  c.RedisHost != "",
 	}
 }
@@ -0,0 +1,44 @@
+package config
+
+import "testing"
+
+func TestParseTOML(t *testing.T) {
+	values, err := parseTOML([]byte(`
+log_level = "debug" # trailing comment
+public_url = "https://example.com/a,b"
+bot_owner_ids = [
+	"123",
+	"456",
+]
+db = { host = "db.internal", port = 6543 }
+
+[spotify]
+client_secret = "quote\"and\\backslash"
+`))
+	if err != nil {
+		t.Fatalf("parseTOML: %v", err)
+	}
+
+	want := map[string]string{
+		"LOG_LEVEL":             "debug",
+		"PUBLIC_URL":            "https://example.com/a,b",
+		"BOT_OWNER_IDS":         "123,456",
+		"DB_HOST":               "db.internal",
+		"DB_PORT":               "6543",
+		"SPOTIFY_CLIENT_SECRET": `quote"and\backslash`,
+	}
+	for key, value := range want {
+		if values[key] != value {
+			t.Errorf("%s = %q, want %q", key, values[key], value)
+		}
+	}
+	if len(values) != len(want) {
+		t.Errorf("got %d keys, want %d: %v", len(values), len(want), values)
+	}
+}
+
+func TestParseTOMLRejectsInvalidSyntax(t *testing.T) {
+	if _, err := parseTOML([]byte("log_level = \"unterminated\n")); err == nil {
+		t.Fatal("expected an error for an unterminated string")
+	}
+}
@@ -1,20 +1,44 @@
 package bot
 
 import (
-	"log"
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/hxnx/tunebot/config"
+	"github.com/hxnx/tunebot/internal/cluster"
 	"github.com/hxnx/tunebot/internal/database"
 	commands "github.com/hxnx/tunebot/internal/features"
+	"github.com/hxnx/tunebot/internal/features/announcements"
+	"github.com/hxnx/tunebot/internal/features/dashboard"
+	"github.com/hxnx/tunebot/internal/features/history"
+	"github.com/hxnx/tunebot/internal/features/owner"
+	"github.com/hxnx/tunebot/internal/features/scrobbling"
+	"github.com/hxnx/tunebot/internal/features/webcontrol"
+	"github.com/hxnx/tunebot/internal/features/webhooks"
+	"github.com/hxnx/tunebot/internal/httpserver"
+	"github.com/hxnx/tunebot/internal/logging"
+	"github.com/hxnx/tunebot/internal/metrics"
+	"github.com/hxnx/tunebot/internal/music"
 	"github.com/hxnx/tunebot/internal/redis"
+	"github.com/hxnx/tunebot/internal/scrobble"
+	"github.com/hxnx/tunebot/internal/shard"
+	"github.com/hxnx/tunebot/internal/webapi"
+	"github.com/hxnx/tunebot/internal/webhook"
 )
 
 type Bot struct {
+	configMu     sync.Mutex
 	config       *config.Config
 	sessions     []*discordgo.Session
 	started      bool
 	presenceStop chan struct{}
+	shards       *shard.Manager
+	servers      []*httpserver.Server
+	metricsOnce  sync.Once
 }
 
 func New(cfg *config.Config) (*Bot, error) {
@@ -28,8 +52,9 @@ func New(cfg *config.Config) (*Bot, error) {
 		SSLMode:  cfg.DBSSLMode,
 	}
 
-	if err := database.Initalize(dbConfig); err != nil {
-		log.Printf("Warning: Database initialization failed: %v", err)
+	databaseErr := database.Initalize(dbConfig)
+	if databaseErr != nil {
+		slog.Warn("database initialization failed", "error", databaseErr)
 	}
 
 	redisConfig := redis.Config{
@@ -39,31 +64,70 @@ func New(cfg *config.Config) (*Bot, error) {
 		DB:       cfg.RedisDB,
 	}
 
-	if _, err := redis.Init(redisConfig); err != nil {
-		log.Printf("Warning: Redis initialization failed: %v", err)
+	_, redisErr := redis.Init(redisConfig)
+	if redisErr != nil {
+		slog.Warn("redis initialization failed", "error", redisErr)
 	}
 
+	var spotify *music.SpotifyClient
+	if cfg.SpotifyClientID != "" && cfg.SpotifyClientSecret != "" {
+		spotify = music.NewSpotifyClient(cfg.SpotifyClientID, cfg.SpotifyClientSecret)
+	}
+	providers := music.NewDefaultProviderRegistry(spotify)
+
+	scrobble.ConfigureLastFM(cfg.LastFMAPIKey, cfg.LastFMAPISecret)
+
+	if cfg.LocalLibraryDir != "" {
+		library := music.NewLocalProvider(cfg.LocalLibraryDir)
+		providers.Register(library)
+		go func() {
+			result, err := library.Scan(context.Background())
+			if err != nil {
+				slog.Warn("local library scan failed", "error", err)
+				return
+			}
+			slog.Info("local library indexed", "files", result.Scanned, "updated", result.Updated, "removed", result.Removed)
+		}()
+	}
+	music.DefaultPlayerManager = music.NewPlayerManager(music.NewService(music.NewQueueStoreFromDefault(), providers))
+
 	shardCount := cfg.ShardCount
-	if shardCount < 1 {
-		s, err := discordgo.New("Bot " + cfg.DiscordToken)
-		if err != nil {
-			return nil, err
-		}
+	maxConcurrency := 1
 
-		if gw, err := s.GatewayBot(); err == nil && gw.Shards > 0 {
+	probe, err := discordgo.New("Bot " + cfg.DiscordToken)
+	if err != nil {
+		return nil, err
+	}
+	if gw, err := probe.GatewayBot(); err == nil {
+		if shardCount < 1 && gw.Shards > 0 {
 			shardCount = gw.Shards
-		} else {
-			log.Printf("Warning: failed to auto-detect shard count, defaulting to 1: %v", err)
-			shardCount = 1
 		}
+		if gw.SessionStartLimit.MaxConcurrency > 0 {
+			maxConcurrency = gw.SessionStartLimit.MaxConcurrency
+		}
+	} else if shardCount < 1 {
+		slog.Warn("failed to auto-detect shard count, defaulting to 1", "error", err)
 	}
 
 	if shardCount < 1 {
 		shardCount = 1
 	}
 
-	sessions := make([]*discordgo.Session, 0, shardCount)
-	for shard := 0; shard < shardCount; shard++ {
+	shardIDs := cfg.ShardIDs
+	if len(shardIDs) == 0 {
+		shardIDs = make([]int, 0, shardCount)
+		for id := 0; id < shardCount; id++ {
+			shardIDs = append(shardIDs, id)
+		}
+	}
+	for _, id := range shardIDs {
+		if id >= shardCount {
+			return nil, fmt.Errorf("shard %d is out of range for %d shard(s)", id, shardCount)
+		}
+	}
+
+	sessions := make([]*discordgo.Session, 0, len(shardIDs))
+	for _, id := range shardIDs {
 		s, err := discordgo.New("Bot " + cfg.DiscordToken)
 		if err != nil {
 			return nil, err
@@ -74,18 +138,61 @@ func New(cfg *config.Config) (*Bot, error) {
 			discordgo.IntentsGuildMessages |
 			discordgo.IntentsMessageContent
 
-		if shardCount > 1 {
-			s.Identify.Shard = &[2]int{shard, shardCount}
-			s.ShardCount = shardCount
-		}
+		s.ShardID = id
+		s.ShardCount = shardCount
 
 		sessions = append(sessions, s)
 	}
 
-	return &Bot{
+	shards := shard.NewManager(sessions, shardCount, maxConcurrency)
+	shard.SetDefault(shards)
+
+	cluster.DefaultCoordinator.Configure(cfg.InstanceID, shardCount, shardIDs)
+	cluster.RegisterMusicHandlers(cluster.DefaultCoordinator, music.DefaultPlayerManager)
+	music.DefaultPlayerManager.SetOwnershipCheck(cluster.DefaultCoordinator.Owns)
+
+	var server *httpserver.Server
+	if cfg.HTTPAddr != "" {
+		server = httpserver.New(cfg.HTTPAddr)
+		server.Handle("/health", httpserver.ShardHealthHandler(cfg.InstanceID, shards))
+		server.Handle("/healthz", httpserver.LivenessHandler(cfg.InstanceID))
+		checks := []httpserver.Check{httpserver.ShardCheck(shards)}
+		if redisErr == nil {
+			checks = append(checks, httpserver.Check{Name: "redis", Run: redis.Ping})
+		}
+		if databaseErr == nil {
+			checks = append(checks, httpserver.Check{Name: "postgres", Run: database.Ping})
+		}
+		checks = append(checks, httpserver.BinaryCheck("ffmpeg"), httpserver.BinaryCheck("yt-dlp"))
+		server.Handle("/readyz", httpserver.ReadinessHandler(cfg.InstanceID, checks...))
+		if cfg.FeatureWebAPI {
+			webapi.Register(server)
+		}
+	}
+	webcontrol.SetEnabled(cfg.FeatureWebAPI && server != nil)
+
+	var servers []*httpserver.Server
+	if server != nil {
+		servers = append(servers, server)
+	}
+	if cfg.MetricsAddr != "" {
+		metricsServer := httpserver.New(cfg.MetricsAddr)
+		metricsServer.Handle("/metrics", metrics.Handler())
+		servers = append(servers, metricsServer)
+	}
+
+	if err := applyRuntimeConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	b := &Bot{
 		config:   cfg,
 		sessions: sessions,
-	}, nil
+		shards:   shards,
+		servers:  servers,
+	}
+	owner.SetConfigReloader(b.ReloadConfig)
+	return b, nil
 }
 
 func (b *Bot) Start() error {
@@ -97,33 +204,50 @@ func (b *Bot) Start() error {
 		return nil
 	}
 
+	if err := cluster.DefaultCoordinator.Start(); err != nil {
+		slog.Warn("cluster coordination disabled", "error", err)
+	}
+
+	dashboard.Register(music.DefaultPlayerManager)
+	announcements.Register(music.DefaultPlayerManager)
+	history.Register(music.DefaultPlayerManager)
+	webhooks.Register(music.DefaultPlayerManager)
+	webhook.DefaultWorker.Start()
+	scrobbling.Register(music.DefaultPlayerManager)
+	scrobble.DefaultWorker.Start()
+	b.metricsOnce.Do(func() {
+		registerMetrics(music.DefaultPlayerManager)
+	})
+
 	for _, s := range b.sessions {
 		b.registerHandlers(s)
 		commands.AddHandlers(s)
 	}
 
 	if _, err := commands.RegisterCommands(b.sessions[0], b.config.ApplicationID, b.config.GuildID); err != nil {
-		log.Printf("Warning: failed to register slash commands: %v", err)
+		slog.Warn("failed to register slash commands", "error", err)
 	}
 
-	for _, s := range b.sessions {
-		if err := s.Open(); err != nil {
-			return err
-		}
+	for _, server := range b.servers {
+		server.Start()
+	}
+
+	if err := b.shards.Open(); err != nil {
+		return err
 	}
 
 	b.startPresenceUpdater()
 	b.started = true
-	log.Printf("Bot session opened (%d shard(s))", len(b.sessions))
+	slog.Info("bot session opened", "shards", len(b.sessions), "shard_count", b.shards.ShardCount(), "max_concurrency", b.shards.MaxConcurrency())
 	return nil
 }
 
 func (b *Bot) registerHandlers(s *discordgo.Session) {
 	s.AddHandler(func(s *discordgo.Session, r *discordgo.Ready) {
 		if s.State != nil && s.State.User != nil {
-			log.Printf("Bot ready as %s#%s", s.State.User.Username, s.State.User.Discriminator)
+			logging.ForGuild(s, "").Info("bot ready", "user", s.State.User.Username+"#"+s.State.User.Discriminator)
 		} else {
-			log.Printf("Bot ready")
+			logging.ForGuild(s, "").Info("bot ready")
 		}
 		b.updatePresence()
 	})
@@ -136,20 +260,28 @@ func (b *Bot) Stop() error {
 
 	b.started = false
 	b.stopPresenceUpdater()
-	for _, s := range b.sessions {
-		if err := s.Close(); err != nil {
-			return err
+	cluster.DefaultCoordinator.Stop()
+	for _, server := range b.servers {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := server.Shutdown(ctx); err != nil {
+			slog.Warn("failed to stop HTTP server", "addr", server.Addr(), "error", err)
 		}
+		cancel()
+	}
+	if err := b.shards.Close(); err != nil {
+		return err
 	}
+	webhook.DefaultWorker.Stop()
+	scrobble.DefaultWorker.Stop()
 
 	if err := database.Close(); err != nil {
-		log.Printf("Warning: failed to close database: %v", err)
+		slog.Warn("failed to close database", "error", err)
 	}
 
 	if err := redis.Close(); err != nil {
-		log.Printf("Warning: failed to close redis: %v", err)
+		slog.Warn("failed to close redis", "error", err)
 	}
 
-	log.Printf("Bot session closed (%d shard(s))", len(b.sessions))
+	slog.Info("bot session closed", "shards", len(b.sessions))
 	return nil
 }
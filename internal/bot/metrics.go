@@ -0,0 +1,35 @@
+package bot
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/hxnx/tunebot/internal/metrics"
+	"github.com/hxnx/tunebot/internal/music"
+)
+
+func registerMetrics(manager *music.PlayerManager) {
+	metrics.RegisterPlayerStats(manager.ActivePlayers, manager.VoiceConnections)
+
+	queue := music.NewQueueStoreFromDefault()
+	manager.Subscribe(func(event music.PlayerEvent) {
+		if event.Type != music.PlayerEventQueueChanged || event.GuildID == "" {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		size, err := queue.QueueSize(ctx, event.GuildID)
+		cancel()
+		if err != nil {
+			slog.Warn("failed to read queue length for metrics", "guild_id", event.GuildID, "error", err)
+			return
+		}
+
+		if size == 0 {
+			metrics.QueueLength.DeleteLabelValues(event.GuildID)
+			return
+		}
+		metrics.QueueLength.WithLabelValues(event.GuildID).Set(float64(size))
+	})
+}
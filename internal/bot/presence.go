@@ -2,8 +2,9 @@ package bot
 
 import (
 	"fmt"
-	"log"
 	"time"
+
+	"github.com/hxnx/tunebot/internal/logging"
 )
 
 const presenceUpdateInterval = 60 * time.Second
@@ -48,7 +49,7 @@ func (b *Bot) updatePresence() {
 
 		status := fmt.Sprintf("#%d샤드 / %d개 서버 참가중", shardNumber, guildCount)
 		if err := s.UpdateGameStatus(0, status); err != nil {
-			log.Printf("failed to update presence: %v", err)
+			logging.ForGuild(s, "").Warn("failed to update presence", "error", err)
 		}
 	}
 }
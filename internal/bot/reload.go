@@ -0,0 +1,66 @@
+package bot
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/hxnx/tunebot/config"
+	"github.com/hxnx/tunebot/internal/features/announcements"
+	"github.com/hxnx/tunebot/internal/features/history"
+	"github.com/hxnx/tunebot/internal/features/scrobbling"
+	shared "github.com/hxnx/tunebot/internal/features/shared"
+	"github.com/hxnx/tunebot/internal/features/webhooks"
+	"github.com/hxnx/tunebot/internal/logging"
+	"github.com/hxnx/tunebot/internal/music"
+	"github.com/hxnx/tunebot/internal/webapi"
+	"github.com/hxnx/tunebot/internal/webhook"
+)
+
+func applyRuntimeConfig(cfg *config.Config) error {
+	if err := logging.Setup(cfg.LogLevel, cfg.LogFormat); err != nil {
+		return err
+	}
+
+	music.DefaultPlayerManager.SetLimits(music.Limits{
+		DefaultVolume:    cfg.DefaultVolume,
+		MaxQueueSize:     cfg.MaxQueueSize,
+		AutoLeaveTimeout: time.Duration(cfg.AutoLeaveTimeout) * time.Second,
+	})
+
+	shared.SetOwnerIDs(cfg.BotOwnerIDs)
+	announcements.SetEnabled(cfg.FeatureAnnouncements)
+	history.SetEnabled(cfg.FeaturePlayHistory)
+	webhooks.SetEnabled(cfg.FeatureWebhooks)
+	webhook.SetAllowPrivate(cfg.WebhookAllowPrivate)
+	scrobbling.SetEnabled(cfg.FeatureScrobbling)
+	webapi.SetPublicURL(cfg.PublicURL)
+	return nil
+}
+
+func (b *Bot) ReloadConfig() ([]string, error) {
+	next, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	b.configMu.Lock()
+	defer b.configMu.Unlock()
+
+	merged, changed, ignored := b.config.Reload(next)
+	if len(ignored) > 0 {
+		slog.Warn("config reload ignored settings that require a restart", "settings", ignored)
+	}
+	if len(changed) == 0 {
+		slog.Info("config reloaded", "changed", []string{})
+		return nil, nil
+	}
+
+	if err := applyRuntimeConfig(merged); err != nil {
+		return nil, fmt.Errorf("failed to apply reloaded config: %w", err)
+	}
+	b.config = merged
+
+	slog.Info("config reloaded", "changed", changed)
+	return changed, nil
+}
@@ -0,0 +1,508 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	internalredis "github.com/hxnx/tunebot/internal/redis"
+	redislib "github.com/redis/go-redis/v9"
+)
+
+const (
+	controlChannelPrefix = "tunebot:control:shard:"
+	replyChannelPrefix   = "tunebot:control:reply:"
+	ownerKeyPrefix       = "tunebot:shard:owner:"
+	ownerTTL             = 30 * time.Second
+	ownerRefreshInterval = 10 * time.Second
+	dispatchTimeout      = 5 * time.Second
+	handlerTimeout       = 10 * time.Second
+)
+
+var (
+	ErrRedisUnavailable    = errors.New("redis is not available")
+	ErrShardUnavailable    = errors.New("no instance owns the target shard")
+	ErrUnknownAction       = errors.New("unknown control action")
+	ErrRemoteCommandFailed = errors.New("remote control command failed")
+	ErrDispatchTimeout     = errors.New("control command timed out")
+)
+
+var refreshOwnerScript = redislib.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+var releaseOwnerScript = redislib.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+type Command struct {
+	Action   string          `json:"action"`
+	GuildID  string          `json:"guild_id"`
+	Payload  json.RawMessage `json:"payload,omitempty"`
+	Origin   string          `json:"origin"`
+	ReplyTo  string          `json:"reply_to,omitempty"`
+	Deadline time.Time       `json:"deadline,omitempty"`
+}
+
+type reply struct {
+	Error  string          `json:"error,omitempty"`
+	Code   string          `json:"code,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+}
+
+var remoteErrors = struct {
+	mu     sync.RWMutex
+	byCode map[string]error
+}{
+	byCode: make(map[string]error),
+}
+
+func RegisterError(code string, err error) {
+	remoteErrors.mu.Lock()
+	remoteErrors.byCode[code] = err
+	remoteErrors.mu.Unlock()
+}
+
+func errorCode(err error) string {
+	remoteErrors.mu.RLock()
+	defer remoteErrors.mu.RUnlock()
+
+	for code, known := range remoteErrors.byCode {
+		if errors.Is(err, known) {
+			return code
+		}
+	}
+	return ""
+}
+
+func remoteError(r reply) error {
+	remoteErrors.mu.RLock()
+	known, ok := remoteErrors.byCode[r.Code]
+	remoteErrors.mu.RUnlock()
+
+	if ok {
+		return fmt.Errorf("%w: %w", ErrRemoteCommandFailed, known)
+	}
+	return fmt.Errorf("%w: %s", ErrRemoteCommandFailed, r.Error)
+}
+
+type Handler func(ctx context.Context, cmd Command) error
+
+type QueryHandler func(ctx context.Context, cmd Command) (any, error)
+
+type Coordinator struct {
+	mu         sync.RWMutex
+	instanceID string
+	shardCount int
+	shards     map[int]bool
+	leasing    bool
+	claimed    map[int]bool
+	handlers   map[string]QueryHandler
+	cancel     context.CancelFunc
+	seq        atomic.Uint64
+}
+
+var DefaultCoordinator = NewCoordinator()
+
+func NewCoordinator() *Coordinator {
+	return &Coordinator{
+		shardCount: 1,
+		shards:     map[int]bool{0: true},
+		claimed:    make(map[int]bool),
+		handlers:   make(map[string]QueryHandler),
+	}
+}
+
+func (c *Coordinator) Configure(instanceID string, shardCount int, shards []int) {
+	if shardCount < 1 {
+		shardCount = 1
+	}
+
+	owned := make(map[int]bool, len(shards))
+	for _, shard := range shards {
+		if shard >= 0 && shard < shardCount {
+			owned[shard] = true
+		}
+	}
+
+	c.mu.Lock()
+	c.instanceID = instanceID
+	c.shardCount = shardCount
+	c.shards = owned
+	c.mu.Unlock()
+}
+
+func (c *Coordinator) InstanceID() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.instanceID
+}
+
+func (c *Coordinator) Handle(action string, handler Handler) {
+	c.HandleQuery(action, func(ctx context.Context, cmd Command) (any, error) {
+		return nil, handler(ctx, cmd)
+	})
+}
+
+func (c *Coordinator) HandleQuery(action string, handler QueryHandler) {
+	c.mu.Lock()
+	c.handlers[action] = handler
+	c.mu.Unlock()
+}
+
+func (c *Coordinator) ShardForGuild(guildID string) int {
+	c.mu.RLock()
+	shardCount := c.shardCount
+	c.mu.RUnlock()
+	return ShardForGuild(guildID, shardCount)
+}
+
+func ShardForGuild(guildID string, shardCount int) int {
+	if shardCount <= 1 {
+		return 0
+	}
+	id, err := strconv.ParseUint(guildID, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return int((id >> 22) % uint64(shardCount))
+}
+
+func (c *Coordinator) Owns(guildID string) bool {
+	shard := c.ShardForGuild(guildID)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.leasing {
+		return c.shards[shard] && c.claimed[shard]
+	}
+	return c.shards[shard]
+}
+
+func (c *Coordinator) OwnedShards() []int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	shards := make([]int, 0, len(c.shards))
+	for shard := 0; shard < c.shardCount; shard++ {
+		if c.shards[shard] {
+			shards = append(shards, shard)
+		}
+	}
+	return shards
+}
+
+func (c *Coordinator) ShardOwner(ctx context.Context, shard int) (string, error) {
+	client := internalredis.Client()
+	if client == nil {
+		return "", ErrRedisUnavailable
+	}
+
+	owner, err := client.Get(ctx, ownerKey(shard)).Result()
+	if errors.Is(err, redislib.Nil) {
+		return "", nil
+	}
+	return owner, err
+}
+
+func (c *Coordinator) Dispatch(ctx context.Context, cmd Command) error {
+	_, err := c.Query(ctx, cmd)
+	return err
+}
+
+func (c *Coordinator) Query(ctx context.Context, cmd Command) (json.RawMessage, error) {
+	if c.Owns(cmd.GuildID) {
+		return c.execute(ctx, cmd)
+	}
+
+	client := internalredis.Client()
+	if client == nil {
+		return nil, ErrRedisUnavailable
+	}
+
+	cmd.Origin = c.InstanceID()
+	cmd.ReplyTo = fmt.Sprintf("%s%s:%d", replyChannelPrefix, cmd.Origin, c.seq.Add(1))
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, dispatchTimeout)
+		defer cancel()
+	}
+	cmd.Deadline, _ = ctx.Deadline()
+
+	payload, err := json.Marshal(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := client.Subscribe(ctx, cmd.ReplyTo)
+	defer sub.Close()
+	if _, err := sub.Receive(ctx); err != nil {
+		return nil, err
+	}
+
+	receivers, err := client.Publish(ctx, controlChannel(c.ShardForGuild(cmd.GuildID)), payload).Result()
+	if err != nil {
+		return nil, err
+	}
+	if receivers == 0 {
+		return nil, ErrShardUnavailable
+	}
+
+	select {
+	case msg, ok := <-sub.Channel():
+		if !ok {
+			return nil, ErrDispatchTimeout
+		}
+		var r reply
+		if err := json.Unmarshal([]byte(msg.Payload), &r); err != nil {
+			return nil, err
+		}
+		if r.Error != "" {
+			return nil, remoteError(r)
+		}
+		return r.Result, nil
+	case <-ctx.Done():
+		return nil, ErrDispatchTimeout
+	}
+}
+
+func (c *Coordinator) Start() error {
+	client := internalredis.Client()
+	if client == nil {
+		return ErrRedisUnavailable
+	}
+
+	shards := c.OwnedShards()
+	if len(shards) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.mu.Lock()
+	if c.cancel != nil {
+		c.mu.Unlock()
+		cancel()
+		return nil
+	}
+	c.cancel = cancel
+	c.leasing = true
+	c.claimed = make(map[int]bool, len(shards))
+	c.mu.Unlock()
+
+	sub := client.Subscribe(ctx)
+	go c.receiveLoop(ctx, client, sub)
+
+	c.claimShards(ctx, client, sub, shards)
+	go c.refreshLoop(ctx, client, sub, shards)
+
+	claimed := c.ClaimedShards()
+	slog.Info("cluster: coordinator started", "instance", c.InstanceID(), "shards", shards, "claimed", claimed)
+	if len(claimed) < len(shards) {
+		slog.Warn("cluster: some configured shards are owned by other instances; commands for them will be forwarded", "instance", c.InstanceID())
+	}
+	return nil
+}
+
+func (c *Coordinator) ClaimedShards() []int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	shards := make([]int, 0, len(c.claimed))
+	for shard := 0; shard < c.shardCount; shard++ {
+		if c.claimed[shard] {
+			shards = append(shards, shard)
+		}
+	}
+	return shards
+}
+
+func (c *Coordinator) Stop() {
+	c.mu.Lock()
+	cancel := c.cancel
+	c.cancel = nil
+	c.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+
+	claimed := c.ClaimedShards()
+	c.mu.Lock()
+	c.claimed = make(map[int]bool)
+	c.mu.Unlock()
+
+	client := internalredis.Client()
+	if client == nil {
+		return
+	}
+
+	ctx, done := context.WithTimeout(context.Background(), 2*time.Second)
+	defer done()
+
+	instanceID := c.InstanceID()
+	for _, shard := range claimed {
+		if err := releaseOwnerScript.Run(ctx, client, []string{ownerKey(shard)}, instanceID).Err(); err != nil {
+			slog.Warn("cluster: failed to release shard", "shard", shard, "error", err)
+		}
+	}
+}
+
+func (c *Coordinator) execute(ctx context.Context, cmd Command) (json.RawMessage, error) {
+	c.mu.RLock()
+	handler, ok := c.handlers[cmd.Action]
+	c.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAction, cmd.Action)
+	}
+
+	result, err := handler(ctx, cmd)
+	if err != nil || result == nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
+func (c *Coordinator) receiveLoop(ctx context.Context, client *redislib.Client, sub *redislib.PubSub) {
+	defer sub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-sub.Channel():
+			if !ok {
+				return
+			}
+
+			var cmd Command
+			if err := json.Unmarshal([]byte(msg.Payload), &cmd); err != nil {
+				slog.Warn("cluster: invalid control message", "error", err)
+				continue
+			}
+			go c.handleRemote(ctx, client, cmd)
+		}
+	}
+}
+
+func (c *Coordinator) handleRemote(ctx context.Context, client *redislib.Client, cmd Command) {
+	if !c.Owns(cmd.GuildID) {
+		slog.Warn("cluster: ignoring control for a shard not owned by this instance", "action", cmd.Action, "guild_id", cmd.GuildID, "origin", cmd.Origin)
+		return
+	}
+
+	timeout := handlerTimeout
+	if !cmd.Deadline.IsZero() {
+		timeout = time.Until(cmd.Deadline)
+	}
+	execCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var r reply
+	result, err := c.execute(execCtx, cmd)
+	if err != nil {
+		slog.Warn("cluster: control failed", "action", cmd.Action, "guild_id", cmd.GuildID, "origin", cmd.Origin, "error", err)
+		r.Error = err.Error()
+		r.Code = errorCode(err)
+	}
+	r.Result = result
+
+	if cmd.ReplyTo == "" {
+		return
+	}
+	payload, err := json.Marshal(r)
+	if err != nil {
+		return
+	}
+	if err := client.Publish(execCtx, cmd.ReplyTo, payload).Err(); err != nil {
+		slog.Warn("cluster: failed to reply", "origin", cmd.Origin, "error", err)
+	}
+}
+
+func (c *Coordinator) claimShards(ctx context.Context, client *redislib.Client, sub *redislib.PubSub, shards []int) {
+	instanceID := c.InstanceID()
+	for _, shard := range shards {
+		claimCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		held, err := holdShard(claimCtx, client, shard, instanceID)
+		if err != nil {
+			cancel()
+			slog.Warn("cluster: failed to claim shard", "shard", shard, "error", err)
+			continue
+		}
+		c.setClaimed(claimCtx, sub, shard, held)
+		cancel()
+	}
+}
+
+func holdShard(ctx context.Context, client *redislib.Client, shard int, instanceID string) (bool, error) {
+	refreshed, err := refreshOwnerScript.Run(ctx, client, []string{ownerKey(shard)}, instanceID, ownerTTL.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	if refreshed == 1 {
+		return true, nil
+	}
+	return client.SetNX(ctx, ownerKey(shard), instanceID, ownerTTL).Result()
+}
+
+func (c *Coordinator) setClaimed(ctx context.Context, sub *redislib.PubSub, shard int, held bool) {
+	c.mu.Lock()
+	was := c.claimed[shard]
+	c.mu.Unlock()
+
+	switch {
+	case held && !was:
+		if err := sub.Subscribe(ctx, controlChannel(shard)); err != nil {
+			slog.Warn("cluster: failed to subscribe to shard", "shard", shard, "error", err)
+			return
+		}
+		c.mu.Lock()
+		c.claimed[shard] = true
+		c.mu.Unlock()
+		slog.Info("cluster: claimed shard", "shard", shard)
+	case !held && was:
+		c.mu.Lock()
+		delete(c.claimed, shard)
+		c.mu.Unlock()
+		if err := sub.Unsubscribe(ctx, controlChannel(shard)); err != nil {
+			slog.Warn("cluster: failed to unsubscribe from shard", "shard", shard, "error", err)
+		}
+		slog.Warn("cluster: lost ownership of shard to another instance", "shard", shard)
+	}
+}
+
+func (c *Coordinator) refreshLoop(ctx context.Context, client *redislib.Client, sub *redislib.PubSub, shards []int) {
+	ticker := time.NewTicker(ownerRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.claimShards(ctx, client, sub, shards)
+		}
+	}
+}
+
+func controlChannel(shard int) string {
+	return controlChannelPrefix + strconv.Itoa(shard)
+}
+
+func ownerKey(shard int) string {
+	return ownerKeyPrefix + strconv.Itoa(shard)
+}
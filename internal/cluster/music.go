@@ -0,0 +1,305 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/music"
+	"github.com/hxnx/tunebot/internal/shard"
+)
+
+const (
+	ActionSkip           = "music.skip"
+	ActionStop           = "music.stop"
+	ActionTogglePause    = "music.toggle_pause"
+	ActionUpdateSettings = "music.update_settings"
+	ActionState          = "music.state"
+	ActionEnqueue        = "music.enqueue"
+	ActionSeek           = "music.seek"
+	ActionSetVolume      = "music.set_volume"
+	ActionMoveQueue      = "music.move_queue"
+	ActionEnqueueTracks  = "music.enqueue_tracks"
+	ActionImport         = "music.import"
+)
+
+var ErrSessionUnavailable = errors.New("no discord session for the target shard")
+
+type stopPayload struct {
+	ClearQueue bool `json:"clear_queue"`
+}
+
+type enqueuePayload struct {
+	UserID string            `json:"user_id"`
+	Input  string            `json:"input"`
+	Source music.TrackSource `json:"source"`
+}
+
+type enqueueTracksPayload struct {
+	UserID string        `json:"user_id"`
+	Tracks []music.Track `json:"tracks"`
+}
+
+type importPayload struct {
+	UserID  string                   `json:"user_id"`
+	Entries []music.QueueImportEntry `json:"entries"`
+}
+
+type batchResult struct {
+	Items  []music.QueueItem `json:"items"`
+	Failed int               `json:"failed,omitempty"`
+	Code   string            `json:"code,omitempty"`
+	Error  string            `json:"error,omitempty"`
+}
+
+func newBatchResult(items []music.QueueItem, failed int, err error) batchResult {
+	result := batchResult{Items: items, Failed: failed}
+	if err != nil {
+		result.Code, result.Error = errorCode(err), err.Error()
+	}
+	return result
+}
+
+func (r batchResult) err() error {
+	if r.Error == "" {
+		return nil
+	}
+	return remoteError(reply{Code: r.Code, Error: r.Error})
+}
+
+type seekPayload struct {
+	PositionMS int64 `json:"position_ms"`
+}
+
+type volumePayload struct {
+	Volume int `json:"volume"`
+}
+
+type movePayload struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+type PlayerStatus struct {
+	State     music.PlaybackState `json:"state"`
+	Connected bool                `json:"connected"`
+}
+
+func RegisterMusicHandlers(c *Coordinator, manager *music.PlayerManager) {
+	RegisterError("queue_full", music.ErrQueueFull)
+	RegisterError("queue_index_out_of_range", music.ErrQueueIndexOutOfRange)
+	RegisterError("no_voice_channel", music.ErrNoVoiceChannel)
+	RegisterError("not_playing", music.ErrNotPlaying)
+	RegisterError("seek_unsupported", music.ErrSeekUnsupported)
+	RegisterError("seek_out_of_range", music.ErrSeekOutOfRange)
+	RegisterError("invalid_volume", music.ErrInvalidVolume)
+	RegisterError("missing_input", music.ErrMissingInput)
+	RegisterError("resolve_failed", music.ErrResolveFailed)
+	RegisterError("playback_stopped", music.ErrPlaybackStopped)
+	RegisterError("spotify_not_configured", music.ErrSpotifyClientNil)
+
+	c.Handle(ActionSkip, func(ctx context.Context, cmd Command) error {
+		return manager.Get(cmd.GuildID).Skip()
+	})
+	c.Handle(ActionStop, func(ctx context.Context, cmd Command) error {
+		var payload stopPayload
+		if len(cmd.Payload) > 0 {
+			if err := json.Unmarshal(cmd.Payload, &payload); err != nil {
+				return err
+			}
+		}
+		return manager.Get(cmd.GuildID).Stop(payload.ClearQueue)
+	})
+	c.Handle(ActionTogglePause, func(ctx context.Context, cmd Command) error {
+		return manager.Get(cmd.GuildID).TogglePause()
+	})
+	c.Handle(ActionUpdateSettings, func(ctx context.Context, cmd Command) error {
+		var settings music.QueueSettings
+		if err := json.Unmarshal(cmd.Payload, &settings); err != nil {
+			return err
+		}
+		return manager.Get(cmd.GuildID).UpdateSettings(ctx, settings)
+	})
+	c.HandleQuery(ActionState, func(ctx context.Context, cmd Command) (any, error) {
+		player := manager.Get(cmd.GuildID)
+		return PlayerStatus{State: player.State(), Connected: player.HasVoiceConnection()}, nil
+	})
+	c.HandleQuery(ActionEnqueue, func(ctx context.Context, cmd Command) (any, error) {
+		var payload enqueuePayload
+		if err := json.Unmarshal(cmd.Payload, &payload); err != nil {
+			return nil, err
+		}
+		s := c.sessionForGuild(cmd.GuildID)
+		if s == nil {
+			return nil, ErrSessionUnavailable
+		}
+		return manager.Get(cmd.GuildID).EnqueueAndPlay(ctx, s, payload.UserID, payload.Input, payload.Source, 0)
+	})
+	c.HandleQuery(ActionEnqueueTracks, func(ctx context.Context, cmd Command) (any, error) {
+		var payload enqueueTracksPayload
+		if err := json.Unmarshal(cmd.Payload, &payload); err != nil {
+			return nil, err
+		}
+		s := c.sessionForGuild(cmd.GuildID)
+		if s == nil {
+			return nil, ErrSessionUnavailable
+		}
+		items, err := manager.Get(cmd.GuildID).EnqueueTracksAndPlay(ctx, s, payload.UserID, payload.Tracks)
+		if err != nil && len(items) == 0 {
+			return nil, err
+		}
+		return newBatchResult(items, 0, err), nil
+	})
+	c.HandleQuery(ActionImport, func(ctx context.Context, cmd Command) (any, error) {
+		var payload importPayload
+		if err := json.Unmarshal(cmd.Payload, &payload); err != nil {
+			return nil, err
+		}
+		s := c.sessionForGuild(cmd.GuildID)
+		if s == nil {
+			return nil, ErrSessionUnavailable
+		}
+		items, failed, err := manager.Get(cmd.GuildID).ImportAndPlay(ctx, s, payload.UserID, payload.Entries)
+		if err != nil && len(items) == 0 {
+			return nil, err
+		}
+		return newBatchResult(items, failed, err), nil
+	})
+	c.Handle(ActionSeek, func(ctx context.Context, cmd Command) error {
+		var payload seekPayload
+		if err := json.Unmarshal(cmd.Payload, &payload); err != nil {
+			return err
+		}
+		return manager.Get(cmd.GuildID).Seek(time.Duration(payload.PositionMS) * time.Millisecond)
+	})
+	c.Handle(ActionSetVolume, func(ctx context.Context, cmd Command) error {
+		var payload volumePayload
+		if err := json.Unmarshal(cmd.Payload, &payload); err != nil {
+			return err
+		}
+		return manager.Get(cmd.GuildID).SetVolume(ctx, payload.Volume)
+	})
+	c.Handle(ActionMoveQueue, func(ctx context.Context, cmd Command) error {
+		var payload movePayload
+		if err := json.Unmarshal(cmd.Payload, &payload); err != nil {
+			return err
+		}
+		return manager.Get(cmd.GuildID).MoveQueueItem(ctx, payload.From, payload.To)
+	})
+}
+
+func (c *Coordinator) sessionForGuild(guildID string) *discordgo.Session {
+	manager := shard.Default()
+	if manager == nil {
+		return nil
+	}
+	return manager.Session(c.ShardForGuild(guildID))
+}
+
+func Skip(ctx context.Context, guildID string) error {
+	return DefaultCoordinator.Dispatch(ctx, Command{Action: ActionSkip, GuildID: guildID})
+}
+
+func Stop(ctx context.Context, guildID string, clearQueue bool) error {
+	payload, err := json.Marshal(stopPayload{ClearQueue: clearQueue})
+	if err != nil {
+		return err
+	}
+	return DefaultCoordinator.Dispatch(ctx, Command{Action: ActionStop, GuildID: guildID, Payload: payload})
+}
+
+func TogglePause(ctx context.Context, guildID string) error {
+	return DefaultCoordinator.Dispatch(ctx, Command{Action: ActionTogglePause, GuildID: guildID})
+}
+
+func UpdateSettings(ctx context.Context, guildID string, settings music.QueueSettings) error {
+	payload, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	return DefaultCoordinator.Dispatch(ctx, Command{Action: ActionUpdateSettings, GuildID: guildID, Payload: payload})
+}
+
+func State(ctx context.Context, guildID string) (PlayerStatus, error) {
+	var status PlayerStatus
+	result, err := DefaultCoordinator.Query(ctx, Command{Action: ActionState, GuildID: guildID})
+	if err != nil {
+		return status, err
+	}
+	err = json.Unmarshal(result, &status)
+	return status, err
+}
+
+func Enqueue(ctx context.Context, guildID string, userID string, input string, source music.TrackSource) (music.QueueItem, error) {
+	var item music.QueueItem
+	payload, err := json.Marshal(enqueuePayload{UserID: userID, Input: input, Source: source})
+	if err != nil {
+		return item, err
+	}
+	result, err := DefaultCoordinator.Query(ctx, Command{Action: ActionEnqueue, GuildID: guildID, Payload: payload})
+	if err != nil {
+		return item, err
+	}
+	err = json.Unmarshal(result, &item)
+	return item, err
+}
+
+func EnqueueTracks(ctx context.Context, guildID string, userID string, tracks []music.Track) ([]music.QueueItem, error) {
+	payload, err := json.Marshal(enqueueTracksPayload{UserID: userID, Tracks: tracks})
+	if err != nil {
+		return nil, err
+	}
+	result, err := queryBatch(ctx, Command{Action: ActionEnqueueTracks, GuildID: guildID, Payload: payload})
+	if err != nil {
+		return nil, err
+	}
+	return result.Items, result.err()
+}
+
+func Import(ctx context.Context, guildID string, userID string, entries []music.QueueImportEntry) ([]music.QueueItem, int, error) {
+	payload, err := json.Marshal(importPayload{UserID: userID, Entries: entries})
+	if err != nil {
+		return nil, 0, err
+	}
+	result, err := queryBatch(ctx, Command{Action: ActionImport, GuildID: guildID, Payload: payload})
+	if err != nil {
+		return nil, 0, err
+	}
+	return result.Items, result.Failed, result.err()
+}
+
+func queryBatch(ctx context.Context, cmd Command) (batchResult, error) {
+	var result batchResult
+	raw, err := DefaultCoordinator.Query(ctx, cmd)
+	if err != nil {
+		return result, err
+	}
+	err = json.Unmarshal(raw, &result)
+	return result, err
+}
+
+func Seek(ctx context.Context, guildID string, position time.Duration) error {
+	payload, err := json.Marshal(seekPayload{PositionMS: position.Milliseconds()})
+	if err != nil {
+		return err
+	}
+	return DefaultCoordinator.Dispatch(ctx, Command{Action: ActionSeek, GuildID: guildID, Payload: payload})
+}
+
+func SetVolume(ctx context.Context, guildID string, volume int) error {
+	payload, err := json.Marshal(volumePayload{Volume: volume})
+	if err != nil {
+		return err
+	}
+	return DefaultCoordinator.Dispatch(ctx, Command{Action: ActionSetVolume, GuildID: guildID, Payload: payload})
+}
+
+func MoveQueueItem(ctx context.Context, guildID string, from int, to int) error {
+	payload, err := json.Marshal(movePayload{From: from, To: to})
+	if err != nil {
+		return err
+	}
+	return DefaultCoordinator.Dispatch(ctx, Command{Action: ActionMoveQueue, GuildID: guildID, Payload: payload})
+}
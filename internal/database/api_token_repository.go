@@ -0,0 +1,158 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+const apiTokenRepoTimeout = 3 * time.Second
+
+var (
+	ErrAPITokenUnavailable = errors.New("api token storage is not available")
+	ErrAPITokenNotFound    = errors.New("api token not found")
+)
+
+type APIToken struct {
+	ID         int64
+	GuildID    string
+	Name       string
+	CreatedBy  string
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+	ReadOnly   bool
+}
+
+type APITokenRepository struct {
+	db *sql.DB
+}
+
+func NewAPITokenRepository() *APITokenRepository {
+	return &APITokenRepository{db: GetDB()}
+}
+
+func (r *APITokenRepository) Available() bool {
+	return r != nil && r.db != nil
+}
+
+func (r *APITokenRepository) Create(guildID, name, tokenHash, overlayHash, createdBy string) (APIToken, error) {
+	if !r.Available() {
+		return APIToken{}, ErrAPITokenUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), apiTokenRepoTimeout)
+	defer cancel()
+
+	const query = `
+		INSERT INTO api_tokens (guild_id, name, token_hash, overlay_hash, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+
+	token := APIToken{GuildID: guildID, Name: name, CreatedBy: createdBy}
+	err := r.db.QueryRowContext(ctx, query, guildID, name, tokenHash, overlayHash, createdBy).Scan(&token.ID, &token.CreatedAt)
+	return token, err
+}
+
+func (r *APITokenRepository) FindByHash(tokenHash string) (APIToken, error) {
+	if !r.Available() {
+		return APIToken{}, ErrAPITokenUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), apiTokenRepoTimeout)
+	defer cancel()
+
+	const query = `
+		UPDATE api_tokens
+		SET last_used_at = NOW()
+		WHERE (token_hash = $1 OR overlay_hash = $1) AND revoked_at IS NULL
+		RETURNING id, guild_id, name, created_by, created_at, last_used_at, COALESCE(overlay_hash = $1, FALSE)
+	`
+
+	var token APIToken
+	var lastUsed sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(
+		&token.ID,
+		&token.GuildID,
+		&token.Name,
+		&token.CreatedBy,
+		&token.CreatedAt,
+		&lastUsed,
+		&token.ReadOnly,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return APIToken{}, ErrAPITokenNotFound
+	}
+	if err != nil {
+		return APIToken{}, err
+	}
+	if lastUsed.Valid {
+		token.LastUsedAt = &lastUsed.Time
+	}
+	return token, nil
+}
+
+func (r *APITokenRepository) ListByGuild(guildID string) ([]APIToken, error) {
+	if !r.Available() {
+		return nil, ErrAPITokenUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), apiTokenRepoTimeout)
+	defer cancel()
+
+	const query = `
+		SELECT id, guild_id, name, created_by, created_at, last_used_at
+		FROM api_tokens
+		WHERE guild_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := []APIToken{}
+	for rows.Next() {
+		var token APIToken
+		var lastUsed sql.NullTime
+		if err := rows.Scan(&token.ID, &token.GuildID, &token.Name, &token.CreatedBy, &token.CreatedAt, &lastUsed); err != nil {
+			return nil, err
+		}
+		if lastUsed.Valid {
+			token.LastUsedAt = &lastUsed.Time
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, rows.Err()
+}
+
+func (r *APITokenRepository) Revoke(guildID string, id int64) error {
+	if !r.Available() {
+		return ErrAPITokenUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), apiTokenRepoTimeout)
+	defer cancel()
+
+	const query = `
+		UPDATE api_tokens
+		SET revoked_at = NOW()
+		WHERE guild_id = $1 AND id = $2 AND revoked_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, guildID, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrAPITokenNotFound
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const auditRepoTimeout = 2 * time.Second
+
+type OwnerAuditEntry struct {
+	UserID  string
+	GuildID string
+	Action  string
+	Target  string
+	Success bool
+	Detail  string
+}
+
+type AuditRepository struct {
+	db *sql.DB
+}
+
+func NewAuditRepository() *AuditRepository {
+	return &AuditRepository{db: GetDB()}
+}
+
+func (r *AuditRepository) Record(entry OwnerAuditEntry) error {
+	if r == nil || r.db == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), auditRepoTimeout)
+	defer cancel()
+
+	const query = `
+		INSERT INTO owner_audit_log (user_id, guild_id, action, target, success, detail)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		entry.UserID,
+		entry.GuildID,
+		entry.Action,
+		entry.Target,
+		entry.Success,
+		entry.Detail,
+	)
+	return err
+}
@@ -3,8 +3,9 @@ package database
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"sync"
 	"time"
 
@@ -16,6 +17,8 @@ var (
 	once sync.Once
 )
 
+var ErrNotInitialized = errors.New("database is not initialized")
+
 type Config struct {
 	Host     string
 	Port     int
@@ -38,57 +41,67 @@ func (cfg *Config) ConnectionString() string {
 }
 
 func Initalize(cfg *Config) error {
+	if err := Connect(cfg); err != nil {
+		return err
+	}
+
+	if err := runMigrations(); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	slog.Info("database connection established")
+	return nil
+}
+
+func Connect(cfg *Config) error {
 	var initError error
 
 	once.Do(func() {
-		var err error
-		db, err = sql.Open("postgres", cfg.ConnectionString())
+		conn, err := sql.Open("postgres", cfg.ConnectionString())
 		if err != nil {
 			initError = fmt.Errorf("failed to open database: %w", err)
 			return
 		}
 
-		db.SetMaxOpenConns(25)
-		db.SetMaxIdleConns(5)
-		db.SetConnMaxLifetime(5 * time.Minute)
+		conn.SetMaxOpenConns(25)
+		conn.SetMaxIdleConns(5)
+		conn.SetConnMaxLifetime(5 * time.Minute)
 
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
-		if err := db.PingContext(ctx); err != nil {
+		if err := conn.PingContext(ctx); err != nil {
+			conn.Close()
 			initError = fmt.Errorf("failed to ping database: %w", err)
 			return
 		}
 
-		if err := runMigrations(); err != nil {
-			initError = fmt.Errorf("failed to run migrations: %w", err)
-			return
-		}
-
-		log.Printf("Database connection established")
+		db = conn
 	})
 
+	if initError == nil && db == nil {
+		return ErrNotInitialized
+	}
 	return initError
 }
 
 func runMigrations() error {
-	migrations := []string{
-		`
-		CREATE TABLE IF NOT EXISTS dashboard_entries (
-			guild_id TEXT PRIMARY KEY,
-			channel_id TEXT NOT NULL,
-			message_id TEXT NOT NULL,
-			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
-		);
-		`,
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	applied, err := MigrateUp(ctx)
+	if err != nil {
+		return err
 	}
 
-	for _, m := range migrations {
-		if _, err := db.Exec(m); err != nil {
-			return fmt.Errorf("failed to execute migration: %w\nQuery: %s", err, m)
-		}
+	if len(applied) == 0 {
+		slog.Info("database migrations completed", "applied", 0)
+		return nil
 	}
-	log.Printf("Database migrations completed (no migrations registered)")
+	for _, m := range applied {
+		slog.Info("applied migration", "version", m.Version, "name", m.Name)
+	}
+	slog.Info("database migrations completed", "applied", len(applied))
 	return nil
 }
 
@@ -96,6 +109,13 @@ func GetDB() *sql.DB {
 	return db
 }
 
+func Ping(ctx context.Context) error {
+	if db == nil {
+		return ErrNotInitialized
+	}
+	return db.PingContext(ctx)
+}
+
 func Close() error {
 	if db != nil {
 		return db.Close()
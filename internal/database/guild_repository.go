@@ -89,3 +89,82 @@ func (r *GuildRepository) DeleteDashboardEntry(guildID string) error {
 	_, err := r.db.ExecContext(ctx, query, guildID)
 	return err
 }
+
+type AnnouncementSettings struct {
+	ChannelID      string
+	DeletePrevious bool
+}
+
+func (r *GuildRepository) UpsertAnnouncementSettings(guildID string, settings AnnouncementSettings) error {
+	if r == nil || r.db == nil {
+		return nil
+	}
+	if guildID == "" || settings.ChannelID == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), guildRepoTimeout)
+	defer cancel()
+
+	const query = `
+		INSERT INTO announcement_settings (guild_id, channel_id, delete_previous, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (guild_id)
+		DO UPDATE SET
+			channel_id = EXCLUDED.channel_id,
+			delete_previous = EXCLUDED.delete_previous,
+			updated_at = NOW();
+	`
+
+	_, err := r.db.ExecContext(ctx, query, guildID, settings.ChannelID, settings.DeletePrevious)
+	return err
+}
+
+func (r *GuildRepository) GetAnnouncementSettings(guildID string) (AnnouncementSettings, bool, error) {
+	if r == nil || r.db == nil {
+		return AnnouncementSettings{}, false, nil
+	}
+	if guildID == "" {
+		return AnnouncementSettings{}, false, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), guildRepoTimeout)
+	defer cancel()
+
+	const query = `
+		SELECT channel_id, delete_previous
+		FROM announcement_settings
+		WHERE guild_id = $1
+	`
+
+	var settings AnnouncementSettings
+	err := r.db.QueryRowContext(ctx, query, guildID).Scan(&settings.ChannelID, &settings.DeletePrevious)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return AnnouncementSettings{}, false, nil
+		}
+		return AnnouncementSettings{}, false, err
+	}
+
+	return settings, true, nil
+}
+
+func (r *GuildRepository) DeleteAnnouncementSettings(guildID string) error {
+	if r == nil || r.db == nil {
+		return nil
+	}
+	if guildID == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), guildRepoTimeout)
+	defer cancel()
+
+	const query = `
+		DELETE FROM announcement_settings
+		WHERE guild_id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, guildID)
+	return err
+}
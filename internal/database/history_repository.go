@@ -0,0 +1,261 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	historyRepoTimeout  = 2 * time.Second
+	historyStatsTimeout = 5 * time.Second
+)
+
+var ErrHistoryUnavailable = errors.New("play history storage is not available")
+
+type PlayHistoryEntry struct {
+	GuildID    string
+	UserID     string
+	Title      string
+	URL        string
+	Source     string
+	DurationMS int64
+	PlayedMS   int64
+	EndReason  string
+	StartedAt  time.Time
+	EndedAt    time.Time
+}
+
+type HistoryRepository struct {
+	db *sql.DB
+}
+
+func NewHistoryRepository() *HistoryRepository {
+	return &HistoryRepository{db: GetDB()}
+}
+
+func (r *HistoryRepository) Record(entry PlayHistoryEntry) error {
+	if r == nil || r.db == nil {
+		return nil
+	}
+	if entry.GuildID == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), historyRepoTimeout)
+	defer cancel()
+
+	const query = `
+		INSERT INTO play_history (guild_id, user_id, title, url, source, duration_ms, played_ms, end_reason, started_at, ended_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		entry.GuildID,
+		entry.UserID,
+		entry.Title,
+		entry.URL,
+		entry.Source,
+		entry.DurationMS,
+		entry.PlayedMS,
+		entry.EndReason,
+		entry.StartedAt,
+		entry.EndedAt,
+	)
+	return err
+}
+
+type HistoryStatsFilter struct {
+	GuildID  string
+	UserID   string
+	Since    time.Time
+	Location *time.Location
+	Limit    int
+}
+
+type HistorySummary struct {
+	Plays      int
+	ListenedMS int64
+	Requesters int
+	FirstAt    *time.Time
+}
+
+type TrackStat struct {
+	Title      string
+	URL        string
+	Plays      int
+	ListenedMS int64
+}
+
+type RequesterStat struct {
+	UserID     string
+	Plays      int
+	ListenedMS int64
+}
+
+type SourceStat struct {
+	Source     string
+	Plays      int
+	ListenedMS int64
+}
+
+type HistoryStats struct {
+	Summary    HistorySummary
+	TopTracks  []TrackStat
+	Requesters []RequesterStat
+	Sources    []SourceStat
+	Hours      [24]int
+	Rank       int
+}
+
+func (r *HistoryRepository) Stats(filter HistoryStatsFilter) (HistoryStats, error) {
+	var stats HistoryStats
+	if r == nil || r.db == nil {
+		return stats, ErrHistoryUnavailable
+	}
+
+	location := filter.Location
+	if location == nil {
+		location = time.UTC
+	}
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 5
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), historyStatsTimeout)
+	defer cancel()
+
+	where := "guild_id = $1 AND started_at >= $2 AND end_reason <> 'failed'"
+	args := []any{filter.GuildID, filter.Since}
+	if filter.UserID != "" {
+		where += " AND user_id = $3"
+		args = append(args, filter.UserID)
+	}
+	next := fmt.Sprintf("$%d", len(args)+1)
+
+	summaryQuery := `
+		SELECT COUNT(*), COALESCE(SUM(played_ms), 0), COUNT(DISTINCT NULLIF(user_id, '')), MIN(started_at)
+		FROM play_history
+		WHERE ` + where
+	var firstAt sql.NullTime
+	if err := r.db.QueryRowContext(ctx, summaryQuery, args...).Scan(&stats.Summary.Plays, &stats.Summary.ListenedMS, &stats.Summary.Requesters, &firstAt); err != nil {
+		return stats, err
+	}
+	if firstAt.Valid {
+		stats.Summary.FirstAt = &firstAt.Time
+	}
+	if stats.Summary.Plays == 0 {
+		return stats, nil
+	}
+
+	tracksQuery := `
+		SELECT MAX(title), url, COUNT(*), COALESCE(SUM(played_ms), 0)
+		FROM play_history
+		WHERE ` + where + `
+		GROUP BY url
+		ORDER BY COUNT(*) DESC, SUM(played_ms) DESC
+		LIMIT ` + next
+	rows, err := r.db.QueryContext(ctx, tracksQuery, append(args, limit)...)
+	if err != nil {
+		return stats, err
+	}
+	for rows.Next() {
+		var track TrackStat
+		if err := rows.Scan(&track.Title, &track.URL, &track.Plays, &track.ListenedMS); err != nil {
+			rows.Close()
+			return stats, err
+		}
+		stats.TopTracks = append(stats.TopTracks, track)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return stats, err
+	}
+
+	if filter.UserID == "" {
+		requestersQuery := `
+			SELECT user_id, COUNT(*), COALESCE(SUM(played_ms), 0)
+			FROM play_history
+			WHERE ` + where + ` AND user_id <> ''
+			GROUP BY user_id
+			ORDER BY COUNT(*) DESC, SUM(played_ms) DESC
+			LIMIT ` + next
+		rows, err := r.db.QueryContext(ctx, requestersQuery, append(args, limit)...)
+		if err != nil {
+			return stats, err
+		}
+		for rows.Next() {
+			var requester RequesterStat
+			if err := rows.Scan(&requester.UserID, &requester.Plays, &requester.ListenedMS); err != nil {
+				rows.Close()
+				return stats, err
+			}
+			stats.Requesters = append(stats.Requesters, requester)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return stats, err
+		}
+	} else {
+		const rankQuery = `
+			SELECT rank FROM (
+				SELECT user_id, RANK() OVER (ORDER BY COUNT(*) DESC) AS rank
+				FROM play_history
+				WHERE guild_id = $1 AND started_at >= $2 AND end_reason <> 'failed' AND user_id <> ''
+				GROUP BY user_id
+			) AS ranked
+			WHERE user_id = $3
+		`
+		err := r.db.QueryRowContext(ctx, rankQuery, filter.GuildID, filter.Since, filter.UserID).Scan(&stats.Rank)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return stats, err
+		}
+	}
+
+	sourcesQuery := `
+		SELECT source, COUNT(*), COALESCE(SUM(played_ms), 0)
+		FROM play_history
+		WHERE ` + where + `
+		GROUP BY source
+		ORDER BY COUNT(*) DESC`
+	rows, err = r.db.QueryContext(ctx, sourcesQuery, args...)
+	if err != nil {
+		return stats, err
+	}
+	for rows.Next() {
+		var source SourceStat
+		if err := rows.Scan(&source.Source, &source.Plays, &source.ListenedMS); err != nil {
+			rows.Close()
+			return stats, err
+		}
+		stats.Sources = append(stats.Sources, source)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return stats, err
+	}
+
+	hoursQuery := `
+		SELECT EXTRACT(HOUR FROM started_at AT TIME ZONE ` + next + `)::INT, COUNT(*)
+		FROM play_history
+		WHERE ` + where + `
+		GROUP BY 1`
+	rows, err = r.db.QueryContext(ctx, hoursQuery, append(args, location.String())...)
+	if err != nil {
+		return stats, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var hour, plays int
+		if err := rows.Scan(&hour, &plays); err != nil {
+			return stats, err
+		}
+		if hour >= 0 && hour < len(stats.Hours) {
+			stats.Hours[hour] = plays
+		}
+	}
+	return stats, rows.Err()
+}
@@ -0,0 +1,181 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const libraryRepoTimeout = 5 * time.Second
+
+type LibraryTrack struct {
+	Path       string
+	Title      string
+	Artist     string
+	Album      string
+	DurationMS int64
+	Size       int64
+	ModifiedAt time.Time
+}
+
+type LibraryRepository struct {
+	db *sql.DB
+}
+
+func NewLibraryRepository() *LibraryRepository {
+	return &LibraryRepository{db: GetDB()}
+}
+
+func (r *LibraryRepository) Available() bool {
+	return r != nil && r.db != nil
+}
+
+func (r *LibraryRepository) Upsert(t LibraryTrack) error {
+	if !r.Available() || t.Path == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), libraryRepoTimeout)
+	defer cancel()
+
+	const query = `
+		INSERT INTO library_tracks (path, title, artist, album, duration_ms, size, modified_at, indexed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		ON CONFLICT (path)
+		DO UPDATE SET
+			title = EXCLUDED.title,
+			artist = EXCLUDED.artist,
+			album = EXCLUDED.album,
+			duration_ms = EXCLUDED.duration_ms,
+			size = EXCLUDED.size,
+			modified_at = EXCLUDED.modified_at,
+			indexed_at = NOW();
+	`
+
+	_, err := r.db.ExecContext(ctx, query, t.Path, t.Title, t.Artist, t.Album, t.DurationMS, t.Size, t.ModifiedAt)
+	return err
+}
+
+func (r *LibraryRepository) Get(path string) (LibraryTrack, bool, error) {
+	if !r.Available() || path == "" {
+		return LibraryTrack{}, false, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), libraryRepoTimeout)
+	defer cancel()
+
+	const query = `
+		SELECT path, title, artist, album, duration_ms, size, modified_at
+		FROM library_tracks
+		WHERE path = $1
+	`
+
+	var t LibraryTrack
+	err := r.db.QueryRowContext(ctx, query, path).Scan(&t.Path, &t.Title, &t.Artist, &t.Album, &t.DurationMS, &t.Size, &t.ModifiedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return LibraryTrack{}, false, nil
+		}
+		return LibraryTrack{}, false, err
+	}
+
+	return t, true, nil
+}
+
+func (r *LibraryRepository) List() ([]LibraryTrack, error) {
+	if !r.Available() {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), libraryRepoTimeout)
+	defer cancel()
+
+	const query = `
+		SELECT path, title, artist, album, duration_ms, size, modified_at
+		FROM library_tracks
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanLibraryTracks(rows)
+}
+
+func (r *LibraryRepository) Search(terms []string, limit int) ([]LibraryTrack, error) {
+	if !r.Available() || len(terms) == 0 {
+		return nil, nil
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), libraryRepoTimeout)
+	defer cancel()
+
+	clauses := make([]string, 0, len(terms))
+	args := make([]any, 0, len(terms)+1)
+	for _, term := range terms {
+		args = append(args, "%"+escapeLike(term)+"%")
+		placeholder := "$" + strconv.Itoa(len(args))
+		clauses = append(clauses, "(title ILIKE "+placeholder+" OR artist ILIKE "+placeholder+" OR album ILIKE "+placeholder+" OR path ILIKE "+placeholder+")")
+	}
+	args = append(args, limit)
+
+	query := `
+		SELECT path, title, artist, album, duration_ms, size, modified_at
+		FROM library_tracks
+		WHERE ` + strings.Join(clauses, " OR ") + `
+		LIMIT $` + strconv.Itoa(len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanLibraryTracks(rows)
+}
+
+func (r *LibraryRepository) DeleteMissing(paths []string) (int64, error) {
+	if !r.Available() {
+		return 0, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), libraryRepoTimeout)
+	defer cancel()
+
+	const query = `
+		DELETE FROM library_tracks
+		WHERE NOT (path = ANY($1))
+	`
+
+	res, err := r.db.ExecContext(ctx, query, pq.Array(paths))
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func scanLibraryTracks(rows *sql.Rows) ([]LibraryTrack, error) {
+	var tracks []LibraryTrack
+	for rows.Next() {
+		var t LibraryTrack
+		if err := rows.Scan(&t.Path, &t.Title, &t.Artist, &t.Album, &t.DurationMS, &t.Size, &t.ModifiedAt); err != nil {
+			return nil, err
+		}
+		tracks = append(tracks, t)
+	}
+	return tracks, rows.Err()
+}
+
+func escapeLike(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	return replacer.Replace(value)
+}
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS dashboard_entries (
+	guild_id TEXT PRIMARY KEY,
+	channel_id TEXT NOT NULL,
+	message_id TEXT NOT NULL,
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS library_tracks (
+	path TEXT PRIMARY KEY,
+	title TEXT NOT NULL,
+	artist TEXT NOT NULL DEFAULT '',
+	album TEXT NOT NULL DEFAULT '',
+	duration_ms BIGINT NOT NULL DEFAULT 0,
+	size BIGINT NOT NULL DEFAULT 0,
+	modified_at TIMESTAMPTZ NOT NULL,
+	indexed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS playlists (
+	id BIGSERIAL PRIMARY KEY,
+	scope TEXT NOT NULL,
+	owner_id TEXT NOT NULL,
+	name TEXT NOT NULL,
+	created_by TEXT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS playlists_owner_name_idx
+	ON playlists (scope, owner_id, LOWER(name));
+
+CREATE TABLE IF NOT EXISTS playlist_tracks (
+	playlist_id BIGINT NOT NULL REFERENCES playlists(id) ON DELETE CASCADE,
+	position INTEGER NOT NULL,
+	title TEXT NOT NULL,
+	url TEXT NOT NULL,
+	source TEXT NOT NULL,
+	duration_ms BIGINT NOT NULL DEFAULT 0,
+	thumbnail TEXT NOT NULL DEFAULT '',
+	is_live BOOLEAN NOT NULL DEFAULT FALSE,
+	isrc TEXT NOT NULL DEFAULT '',
+	added_by TEXT NOT NULL DEFAULT '',
+	added_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	PRIMARY KEY (playlist_id, position) DEFERRABLE INITIALLY DEFERRED
+);
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS announcement_settings (
+	guild_id TEXT PRIMARY KEY,
+	channel_id TEXT NOT NULL,
+	delete_previous BOOLEAN NOT NULL DEFAULT TRUE,
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS play_history (
+	id BIGSERIAL PRIMARY KEY,
+	guild_id TEXT NOT NULL,
+	user_id TEXT NOT NULL DEFAULT '',
+	title TEXT NOT NULL,
+	url TEXT NOT NULL,
+	source TEXT NOT NULL,
+	duration_ms BIGINT NOT NULL DEFAULT 0,
+	played_ms BIGINT NOT NULL DEFAULT 0,
+	end_reason TEXT NOT NULL,
+	started_at TIMESTAMPTZ NOT NULL,
+	ended_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS play_history_guild_started_idx
+	ON play_history (guild_id, started_at DESC);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS owner_audit_log (
+	id BIGSERIAL PRIMARY KEY,
+	user_id TEXT NOT NULL,
+	guild_id TEXT NOT NULL DEFAULT '',
+	action TEXT NOT NULL,
+	target TEXT NOT NULL DEFAULT '',
+	success BOOLEAN NOT NULL,
+	detail TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS owner_audit_log_created_idx
+	ON owner_audit_log (created_at DESC);
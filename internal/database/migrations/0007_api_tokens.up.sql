@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS api_tokens (
+	id BIGSERIAL PRIMARY KEY,
+	guild_id TEXT NOT NULL,
+	name TEXT NOT NULL,
+	token_hash TEXT NOT NULL UNIQUE,
+	created_by TEXT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	last_used_at TIMESTAMPTZ,
+	revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS api_tokens_guild_idx
+	ON api_tokens (guild_id, created_at DESC);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS scrobble_queue;
+DROP TABLE IF EXISTS scrobbler_links;
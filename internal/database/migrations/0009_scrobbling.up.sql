@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS scrobbler_links (
+	user_id TEXT NOT NULL,
+	service TEXT NOT NULL,
+	token TEXT NOT NULL,
+	username TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	PRIMARY KEY (user_id, service)
+);
+
+CREATE TABLE IF NOT EXISTS scrobble_queue (
+	id BIGSERIAL PRIMARY KEY,
+	user_id TEXT NOT NULL,
+	service TEXT NOT NULL,
+	artist TEXT NOT NULL,
+	track TEXT NOT NULL,
+	duration_ms BIGINT NOT NULL DEFAULT 0,
+	origin_url TEXT NOT NULL DEFAULT '',
+	listened_at TIMESTAMPTZ NOT NULL,
+	attempts INTEGER NOT NULL DEFAULT 0,
+	last_error TEXT,
+	next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	FOREIGN KEY (user_id, service) REFERENCES scrobbler_links (user_id, service) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS scrobble_queue_due_idx
+	ON scrobble_queue (next_attempt_at);
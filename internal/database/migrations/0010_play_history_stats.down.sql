@@ -0,0 +1 @@
+DROP INDEX IF EXISTS play_history_stats_user_idx;
@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS play_history_stats_user_idx
+	ON play_history (guild_id, user_id, started_at DESC)
+	WHERE end_reason <> 'failed';
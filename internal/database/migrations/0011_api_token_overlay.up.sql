@@ -0,0 +1 @@
+ALTER TABLE api_tokens ADD COLUMN IF NOT EXISTS overlay_hash TEXT UNIQUE;
@@ -0,0 +1,327 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const playlistRepoTimeout = 5 * time.Second
+
+const (
+	PlaylistScopeUser  = "user"
+	PlaylistScopeGuild = "guild"
+)
+
+var (
+	ErrPlaylistUnavailable = errors.New("playlist storage is not available")
+	ErrPlaylistExists      = errors.New("playlist already exists")
+	ErrPlaylistNotFound    = errors.New("playlist not found")
+	ErrPlaylistFull        = errors.New("playlist track limit reached")
+)
+
+type Playlist struct {
+	ID         int64
+	Scope      string
+	OwnerID    string
+	Name       string
+	CreatedBy  string
+	TrackCount int
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type PlaylistTrack struct {
+	Position   int
+	Title      string
+	URL        string
+	Source     string
+	DurationMS int64
+	Thumbnail  string
+	IsLive     bool
+	ISRC       string
+	AddedBy    string
+	AddedAt    time.Time
+}
+
+type PlaylistRepository struct {
+	db *sql.DB
+}
+
+func NewPlaylistRepository() *PlaylistRepository {
+	return &PlaylistRepository{db: GetDB()}
+}
+
+func (r *PlaylistRepository) Available() bool {
+	return r != nil && r.db != nil
+}
+
+func (r *PlaylistRepository) Create(scope, ownerID, name, createdBy string) (Playlist, error) {
+	if !r.Available() {
+		return Playlist{}, ErrPlaylistUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), playlistRepoTimeout)
+	defer cancel()
+
+	const query = `
+		INSERT INTO playlists (scope, owner_id, name, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NOW(), NOW())
+		RETURNING id, created_at, updated_at
+	`
+
+	p := Playlist{Scope: scope, OwnerID: ownerID, Name: name, CreatedBy: createdBy}
+	err := r.db.QueryRowContext(ctx, query, scope, ownerID, name, createdBy).Scan(&p.ID, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return Playlist{}, ErrPlaylistExists
+		}
+		return Playlist{}, err
+	}
+	return p, nil
+}
+
+func (r *PlaylistRepository) Find(scope, ownerID, name string) (Playlist, error) {
+	if !r.Available() {
+		return Playlist{}, ErrPlaylistUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), playlistRepoTimeout)
+	defer cancel()
+
+	const query = `
+		SELECT p.id, p.scope, p.owner_id, p.name, p.created_by, p.created_at, p.updated_at,
+			(SELECT COUNT(*) FROM playlist_tracks t WHERE t.playlist_id = p.id)
+		FROM playlists p
+		WHERE p.scope = $1 AND p.owner_id = $2 AND LOWER(p.name) = LOWER($3)
+	`
+
+	var p Playlist
+	err := r.db.QueryRowContext(ctx, query, scope, ownerID, name).Scan(
+		&p.ID, &p.Scope, &p.OwnerID, &p.Name, &p.CreatedBy, &p.CreatedAt, &p.UpdatedAt, &p.TrackCount,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return Playlist{}, ErrPlaylistNotFound
+		}
+		return Playlist{}, err
+	}
+	return p, nil
+}
+
+func (r *PlaylistRepository) Get(id int64) (Playlist, error) {
+	if !r.Available() {
+		return Playlist{}, ErrPlaylistUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), playlistRepoTimeout)
+	defer cancel()
+
+	const query = `
+		SELECT p.id, p.scope, p.owner_id, p.name, p.created_by, p.created_at, p.updated_at,
+			(SELECT COUNT(*) FROM playlist_tracks t WHERE t.playlist_id = p.id)
+		FROM playlists p
+		WHERE p.id = $1
+	`
+
+	var p Playlist
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&p.ID, &p.Scope, &p.OwnerID, &p.Name, &p.CreatedBy, &p.CreatedAt, &p.UpdatedAt, &p.TrackCount,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return Playlist{}, ErrPlaylistNotFound
+		}
+		return Playlist{}, err
+	}
+	return p, nil
+}
+
+func (r *PlaylistRepository) List(userID, guildID string) ([]Playlist, error) {
+	if !r.Available() {
+		return nil, ErrPlaylistUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), playlistRepoTimeout)
+	defer cancel()
+
+	const query = `
+		SELECT p.id, p.scope, p.owner_id, p.name, p.created_by, p.created_at, p.updated_at,
+			(SELECT COUNT(*) FROM playlist_tracks t WHERE t.playlist_id = p.id)
+		FROM playlists p
+		WHERE (p.scope = 'user' AND p.owner_id = $1) OR (p.scope = 'guild' AND p.owner_id = $2)
+		ORDER BY p.scope DESC, LOWER(p.name)
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var playlists []Playlist
+	for rows.Next() {
+		var p Playlist
+		if err := rows.Scan(&p.ID, &p.Scope, &p.OwnerID, &p.Name, &p.CreatedBy, &p.CreatedAt, &p.UpdatedAt, &p.TrackCount); err != nil {
+			return nil, err
+		}
+		playlists = append(playlists, p)
+	}
+	return playlists, rows.Err()
+}
+
+func (r *PlaylistRepository) Delete(id int64) error {
+	if !r.Available() {
+		return ErrPlaylistUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), playlistRepoTimeout)
+	defer cancel()
+
+	res, err := r.db.ExecContext(ctx, `DELETE FROM playlists WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrPlaylistNotFound
+	}
+	return nil
+}
+
+func (r *PlaylistRepository) AddTracks(id int64, tracks []PlaylistTrack, maxTracks int) (int, error) {
+	if !r.Available() {
+		return 0, ErrPlaylistUnavailable
+	}
+	if len(tracks) == 0 {
+		return 0, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), playlistRepoTimeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT id FROM playlists WHERE id = $1 FOR UPDATE`, id); err != nil {
+		return 0, err
+	}
+
+	var count int
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM playlist_tracks WHERE playlist_id = $1`, id).Scan(&count); err != nil {
+		return 0, err
+	}
+	if maxTracks > 0 && count >= maxTracks {
+		return 0, ErrPlaylistFull
+	}
+	if maxTracks > 0 && count+len(tracks) > maxTracks {
+		tracks = tracks[:maxTracks-count]
+	}
+
+	const insert = `
+		INSERT INTO playlist_tracks (playlist_id, position, title, url, source, duration_ms, thumbnail, is_live, isrc, added_by, added_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW())
+	`
+	for idx, t := range tracks {
+		if _, err := tx.ExecContext(ctx, insert, id, count+idx+1, t.Title, t.URL, t.Source, t.DurationMS, t.Thumbnail, t.IsLive, t.ISRC, t.AddedBy); err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE playlists SET updated_at = NOW() WHERE id = $1`, id); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(tracks), nil
+}
+
+func (r *PlaylistRepository) RemoveTrack(id int64, position int) (PlaylistTrack, error) {
+	if !r.Available() {
+		return PlaylistTrack{}, ErrPlaylistUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), playlistRepoTimeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return PlaylistTrack{}, err
+	}
+	defer tx.Rollback()
+
+	const remove = `
+		DELETE FROM playlist_tracks
+		WHERE playlist_id = $1 AND position = $2
+		RETURNING position, title, url, source, duration_ms, thumbnail, is_live, isrc, added_by, added_at
+	`
+
+	var t PlaylistTrack
+	err = tx.QueryRowContext(ctx, remove, id, position).Scan(
+		&t.Position, &t.Title, &t.URL, &t.Source, &t.DurationMS, &t.Thumbnail, &t.IsLive, &t.ISRC, &t.AddedBy, &t.AddedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return PlaylistTrack{}, ErrPlaylistNotFound
+		}
+		return PlaylistTrack{}, err
+	}
+
+	const shift = `
+		UPDATE playlist_tracks
+		SET position = position - 1
+		WHERE playlist_id = $1 AND position > $2
+	`
+	if _, err := tx.ExecContext(ctx, shift, id, position); err != nil {
+		return PlaylistTrack{}, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE playlists SET updated_at = NOW() WHERE id = $1`, id); err != nil {
+		return PlaylistTrack{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return PlaylistTrack{}, err
+	}
+	return t, nil
+}
+
+func (r *PlaylistRepository) Tracks(id int64) ([]PlaylistTrack, error) {
+	if !r.Available() {
+		return nil, ErrPlaylistUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), playlistRepoTimeout)
+	defer cancel()
+
+	const query = `
+		SELECT position, title, url, source, duration_ms, thumbnail, is_live, isrc, added_by, added_at
+		FROM playlist_tracks
+		WHERE playlist_id = $1
+		ORDER BY position
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tracks []PlaylistTrack
+	for rows.Next() {
+		var t PlaylistTrack
+		if err := rows.Scan(&t.Position, &t.Title, &t.URL, &t.Source, &t.DurationMS, &t.Thumbnail, &t.IsLive, &t.ISRC, &t.AddedBy, &t.AddedAt); err != nil {
+			return nil, err
+		}
+		tracks = append(tracks, t)
+	}
+	return tracks, rows.Err()
+}
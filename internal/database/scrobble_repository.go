@@ -0,0 +1,234 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const scrobbleRepoTimeout = 5 * time.Second
+
+var (
+	ErrScrobbleUnavailable   = errors.New("scrobble storage is not available")
+	ErrScrobblerLinkNotFound = errors.New("scrobbler link not found")
+)
+
+type ScrobblerLink struct {
+	UserID    string
+	Service   string
+	Token     string
+	Username  string
+	CreatedAt time.Time
+}
+
+type QueuedScrobble struct {
+	ID         int64
+	UserID     string
+	Service    string
+	Token      string
+	Artist     string
+	Track      string
+	DurationMS int64
+	OriginURL  string
+	ListenedAt time.Time
+	Attempts   int
+	CreatedAt  time.Time
+}
+
+type ScrobbleRepository struct {
+	db *sql.DB
+}
+
+func NewScrobbleRepository() *ScrobbleRepository {
+	return &ScrobbleRepository{db: GetDB()}
+}
+
+func (r *ScrobbleRepository) Available() bool {
+	return r != nil && r.db != nil
+}
+
+func (r *ScrobbleRepository) Link(userID, service, token, username string) error {
+	if !r.Available() {
+		return ErrScrobbleUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), scrobbleRepoTimeout)
+	defer cancel()
+
+	const query = `
+		INSERT INTO scrobbler_links (user_id, service, token, username)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, service)
+		DO UPDATE SET token = EXCLUDED.token, username = EXCLUDED.username, created_at = NOW()
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID, service, token, username)
+	return err
+}
+
+func (r *ScrobbleRepository) Unlink(userID, service string) error {
+	if !r.Available() {
+		return ErrScrobbleUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), scrobbleRepoTimeout)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM scrobbler_links WHERE user_id = $1 AND service = $2`, userID, service)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrScrobblerLinkNotFound
+	}
+	return nil
+}
+
+func (r *ScrobbleRepository) LinksForUsers(userIDs []string) (map[string][]ScrobblerLink, error) {
+	if !r.Available() {
+		return nil, ErrScrobbleUnavailable
+	}
+	links := make(map[string][]ScrobblerLink)
+	if len(userIDs) == 0 {
+		return links, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), scrobbleRepoTimeout)
+	defer cancel()
+
+	const query = `
+		SELECT user_id, service, token, username, created_at
+		FROM scrobbler_links
+		WHERE user_id = ANY($1)
+		ORDER BY user_id, service
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(userIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var link ScrobblerLink
+		if err := rows.Scan(&link.UserID, &link.Service, &link.Token, &link.Username, &link.CreatedAt); err != nil {
+			return nil, err
+		}
+		links[link.UserID] = append(links[link.UserID], link)
+	}
+	return links, rows.Err()
+}
+
+func (r *ScrobbleRepository) PendingCounts(userID string) (map[string]int, error) {
+	if !r.Available() {
+		return nil, ErrScrobbleUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), scrobbleRepoTimeout)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `SELECT service, COUNT(*) FROM scrobble_queue WHERE user_id = $1 GROUP BY service`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var service string
+		var count int
+		if err := rows.Scan(&service, &count); err != nil {
+			return nil, err
+		}
+		counts[service] = count
+	}
+	return counts, rows.Err()
+}
+
+func (r *ScrobbleRepository) Enqueue(s QueuedScrobble) error {
+	if !r.Available() {
+		return ErrScrobbleUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), scrobbleRepoTimeout)
+	defer cancel()
+
+	const query = `
+		INSERT INTO scrobble_queue (user_id, service, artist, track, duration_ms, origin_url, listened_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, s.UserID, s.Service, s.Artist, s.Track, s.DurationMS, s.OriginURL, s.ListenedAt)
+	return err
+}
+
+func (r *ScrobbleRepository) ClaimDue(limit int, lease time.Duration) ([]QueuedScrobble, error) {
+	if !r.Available() {
+		return nil, ErrScrobbleUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), scrobbleRepoTimeout)
+	defer cancel()
+
+	const query = `
+		UPDATE scrobble_queue AS q
+		SET attempts = q.attempts + 1,
+			next_attempt_at = NOW() + make_interval(secs => $2)
+		FROM scrobbler_links AS l
+		WHERE l.user_id = q.user_id AND l.service = q.service AND q.id IN (
+			SELECT id FROM scrobble_queue
+			WHERE next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING q.id, q.user_id, q.service, l.token, q.artist, q.track, q.duration_ms, q.origin_url, q.listened_at, q.attempts, q.created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	scrobbles := []QueuedScrobble{}
+	for rows.Next() {
+		var s QueuedScrobble
+		if err := rows.Scan(&s.ID, &s.UserID, &s.Service, &s.Token, &s.Artist, &s.Track, &s.DurationMS, &s.OriginURL, &s.ListenedAt, &s.Attempts, &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		scrobbles = append(scrobbles, s)
+	}
+	return scrobbles, rows.Err()
+}
+
+func (r *ScrobbleRepository) Delete(id int64) error {
+	if !r.Available() {
+		return ErrScrobbleUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), scrobbleRepoTimeout)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, `DELETE FROM scrobble_queue WHERE id = $1`, id)
+	return err
+}
+
+func (r *ScrobbleRepository) Reschedule(id int64, next time.Time, lastError string) error {
+	if !r.Available() {
+		return ErrScrobbleUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), scrobbleRepoTimeout)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, `UPDATE scrobble_queue SET next_attempt_at = $2, last_error = $3 WHERE id = $1`, id, next, lastError)
+	return err
+}
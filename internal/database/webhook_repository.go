@@ -0,0 +1,309 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+const webhookRepoTimeout = 5 * time.Second
+
+var (
+	ErrWebhookUnavailable = errors.New("webhook storage is not available")
+	ErrWebhookNotFound    = errors.New("webhook not found")
+)
+
+type Webhook struct {
+	ID            int64
+	GuildID       string
+	URL           string
+	Secret        string
+	CreatedBy     string
+	CreatedAt     time.Time
+	LastSuccessAt *time.Time
+	LastFailureAt *time.Time
+	Pending       int
+	DeadLetters   int
+}
+
+type WebhookDelivery struct {
+	ID        int64
+	WebhookID int64
+	GuildID   string
+	URL       string
+	Secret    string
+	Event     string
+	Payload   string
+	Attempts  int
+	CreatedAt time.Time
+}
+
+type WebhookRepository struct {
+	db *sql.DB
+}
+
+func NewWebhookRepository() *WebhookRepository {
+	return &WebhookRepository{db: GetDB()}
+}
+
+func (r *WebhookRepository) Available() bool {
+	return r != nil && r.db != nil
+}
+
+func (r *WebhookRepository) Create(guildID, url, secret, createdBy string) (Webhook, error) {
+	if !r.Available() {
+		return Webhook{}, ErrWebhookUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookRepoTimeout)
+	defer cancel()
+
+	const query = `
+		INSERT INTO webhooks (guild_id, url, secret, created_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	hook := Webhook{GuildID: guildID, URL: url, Secret: secret, CreatedBy: createdBy}
+	err := r.db.QueryRowContext(ctx, query, guildID, url, secret, createdBy).Scan(&hook.ID, &hook.CreatedAt)
+	return hook, err
+}
+
+func (r *WebhookRepository) ListByGuild(guildID string) ([]Webhook, error) {
+	if !r.Available() {
+		return nil, ErrWebhookUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookRepoTimeout)
+	defer cancel()
+
+	const query = `
+		SELECT
+			w.id, w.guild_id, w.url, w.secret, w.created_by, w.created_at, w.last_success_at, w.last_failure_at,
+			(SELECT COUNT(*) FROM webhook_deliveries d WHERE d.webhook_id = w.id),
+			(SELECT COUNT(*) FROM webhook_dead_letters l WHERE l.webhook_id = w.id)
+		FROM webhooks w
+		WHERE w.guild_id = $1
+		ORDER BY w.created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hooks := []Webhook{}
+	for rows.Next() {
+		var hook Webhook
+		var lastSuccess, lastFailure sql.NullTime
+		if err := rows.Scan(
+			&hook.ID,
+			&hook.GuildID,
+			&hook.URL,
+			&hook.Secret,
+			&hook.CreatedBy,
+			&hook.CreatedAt,
+			&lastSuccess,
+			&lastFailure,
+			&hook.Pending,
+			&hook.DeadLetters,
+		); err != nil {
+			return nil, err
+		}
+		if lastSuccess.Valid {
+			hook.LastSuccessAt = &lastSuccess.Time
+		}
+		if lastFailure.Valid {
+			hook.LastFailureAt = &lastFailure.Time
+		}
+		hooks = append(hooks, hook)
+	}
+	return hooks, rows.Err()
+}
+
+func (r *WebhookRepository) Delete(guildID string, id int64) error {
+	if !r.Available() {
+		return ErrWebhookUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookRepoTimeout)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhooks WHERE guild_id = $1 AND id = $2`, guildID, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepository) EnqueueForGuild(guildID, event, payload string) (int64, error) {
+	if !r.Available() {
+		return 0, ErrWebhookUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookRepoTimeout)
+	defer cancel()
+
+	const query = `
+		INSERT INTO webhook_deliveries (webhook_id, event, payload)
+		SELECT id, $2, $3 FROM webhooks WHERE guild_id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query, guildID, event, payload)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *WebhookRepository) EnqueueForWebhook(guildID string, id int64, event, payload string) error {
+	if !r.Available() {
+		return ErrWebhookUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookRepoTimeout)
+	defer cancel()
+
+	const query = `
+		INSERT INTO webhook_deliveries (webhook_id, event, payload)
+		SELECT id, $3, $4 FROM webhooks WHERE guild_id = $1 AND id = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, guildID, id, event, payload)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepository) ClaimDue(limit int, lease time.Duration) ([]WebhookDelivery, error) {
+	if !r.Available() {
+		return nil, ErrWebhookUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookRepoTimeout)
+	defer cancel()
+
+	const query = `
+		UPDATE webhook_deliveries AS d
+		SET attempts = d.attempts + 1,
+			next_attempt_at = NOW() + make_interval(secs => $2)
+		FROM webhooks AS w
+		WHERE w.id = d.webhook_id AND d.id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING d.id, d.webhook_id, w.guild_id, w.url, w.secret, d.event, d.payload, d.attempts, d.created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []WebhookDelivery{}
+	for rows.Next() {
+		var d WebhookDelivery
+		if err := rows.Scan(&d.ID, &d.WebhookID, &d.GuildID, &d.URL, &d.Secret, &d.Event, &d.Payload, &d.Attempts, &d.CreatedAt); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+func (r *WebhookRepository) MarkDelivered(d WebhookDelivery) error {
+	if !r.Available() {
+		return ErrWebhookUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookRepoTimeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM webhook_deliveries WHERE id = $1`, d.ID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE webhooks SET last_success_at = NOW() WHERE id = $1`, d.WebhookID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *WebhookRepository) Reschedule(d WebhookDelivery, next time.Time, lastError string) error {
+	if !r.Available() {
+		return ErrWebhookUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookRepoTimeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE webhook_deliveries SET next_attempt_at = $2, last_error = $3 WHERE id = $1`, d.ID, next, lastError); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE webhooks SET last_failure_at = NOW() WHERE id = $1`, d.WebhookID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *WebhookRepository) DeadLetter(d WebhookDelivery, lastError string) error {
+	if !r.Available() {
+		return ErrWebhookUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookRepoTimeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	const insert = `
+		INSERT INTO webhook_dead_letters (webhook_id, guild_id, url, event, payload, attempts, last_error, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	if _, err := tx.ExecContext(ctx, insert, d.WebhookID, d.GuildID, d.URL, d.Event, d.Payload, d.Attempts, lastError, d.CreatedAt); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM webhook_deliveries WHERE id = $1`, d.ID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE webhooks SET last_failure_at = NOW() WHERE id = $1`, d.WebhookID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/database"
+	announcements "github.com/hxnx/tunebot/internal/features/announcements"
+	shared "github.com/hxnx/tunebot/internal/features/shared"
+	"github.com/hxnx/tunebot/internal/logging"
+)
+
+func Set(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	if i.GuildID == "" {
+		shared.RespondEphemeral(s, i, "이 명령어는 서버에서만 사용하실 수 있습니다.")
+		return
+	}
+	if !hasManageChannelsPermission(i) {
+		shared.RespondEphemeral(s, i, "채널을 설정할 권한이 없습니다.")
+		return
+	}
+
+	channelID := shared.GetOptionString(options, "채널")
+	if channelID == "" {
+		shared.RespondEphemeral(s, i, "알림을 보낼 채널을 선택해 주세요.")
+		return
+	}
+
+	settings := database.AnnouncementSettings{
+		ChannelID:      channelID,
+		DeletePrevious: true,
+	}
+	for _, opt := range options {
+		if opt.Name == "이전카드삭제" {
+			settings.DeletePrevious = opt.BoolValue()
+		}
+	}
+
+	if err := announcements.SetSettings(i.GuildID, settings); err != nil {
+		logging.ForInteraction(s, i).Error("failed to save announcement settings", "error", err)
+		shared.RespondEphemeral(s, i, "알림 채널 설정을 저장하지 못했습니다.")
+		return
+	}
+
+	content := fmt.Sprintf("이제 <#%s> 채널에 재생 중인 곡을 알려드립니다.", channelID)
+	if settings.DeletePrevious {
+		content += "\n새 곡이 시작되면 이전 알림은 삭제됩니다."
+	}
+	shared.RespondEphemeral(s, i, content)
+}
+
+func Clear(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.GuildID == "" {
+		shared.RespondEphemeral(s, i, "이 명령어는 서버에서만 사용하실 수 있습니다.")
+		return
+	}
+	if !hasManageChannelsPermission(i) {
+		shared.RespondEphemeral(s, i, "채널을 설정할 권한이 없습니다.")
+		return
+	}
+
+	if err := announcements.ClearSettings(i.GuildID); err != nil {
+		logging.ForInteraction(s, i).Error("failed to clear announcement settings", "error", err)
+		shared.RespondEphemeral(s, i, "알림 채널 설정을 해제하지 못했습니다.")
+		return
+	}
+
+	shared.RespondEphemeral(s, i, "재생 알림을 해제했습니다.")
+}
+
+func hasManageChannelsPermission(i *discordgo.InteractionCreate) bool {
+	if i.Member == nil {
+		return false
+	}
+
+	perms := i.Member.Permissions
+	return perms&discordgo.PermissionManageChannels != 0
+}
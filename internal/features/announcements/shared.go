@@ -0,0 +1,219 @@
+package announcements
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/database"
+	"github.com/hxnx/tunebot/internal/logging"
+	"github.com/hxnx/tunebot/internal/music"
+)
+
+var AccentColor = 0x3C6AA1
+
+type announcementCard struct {
+	ChannelID string
+	MessageID string
+}
+
+var announcementState = struct {
+	mu       sync.Mutex
+	settings map[string]database.AnnouncementSettings
+	loaded   map[string]bool
+	cards    map[string]announcementCard
+}{
+	settings: make(map[string]database.AnnouncementSettings),
+	loaded:   make(map[string]bool),
+	cards:    make(map[string]announcementCard),
+}
+
+var registerOnce sync.Once
+
+var enabled atomic.Bool
+
+func init() {
+	enabled.Store(true)
+}
+
+func SetEnabled(value bool) {
+	enabled.Store(value)
+}
+
+func Register(manager *music.PlayerManager) {
+	if manager == nil {
+		return
+	}
+	registerOnce.Do(func() {
+		manager.Subscribe(handlePlayerEvent)
+	})
+}
+
+func GetSettings(guildID string) (database.AnnouncementSettings, bool) {
+	announcementState.mu.Lock()
+	if announcementState.loaded[guildID] {
+		settings, ok := announcementState.settings[guildID]
+		announcementState.mu.Unlock()
+		return settings, ok
+	}
+	announcementState.mu.Unlock()
+
+	settings, ok, err := database.NewGuildRepository().GetAnnouncementSettings(guildID)
+	if err != nil {
+		slog.Warn("failed to load announcement settings", "guild_id", guildID, "error", err)
+		return database.AnnouncementSettings{}, false
+	}
+
+	announcementState.mu.Lock()
+	announcementState.loaded[guildID] = true
+	if ok {
+		announcementState.settings[guildID] = settings
+	}
+	announcementState.mu.Unlock()
+	return settings, ok
+}
+
+func SetSettings(guildID string, settings database.AnnouncementSettings) error {
+	if err := database.NewGuildRepository().UpsertAnnouncementSettings(guildID, settings); err != nil {
+		return err
+	}
+
+	announcementState.mu.Lock()
+	announcementState.loaded[guildID] = true
+	announcementState.settings[guildID] = settings
+	announcementState.mu.Unlock()
+	return nil
+}
+
+func ClearSettings(guildID string) error {
+	if err := database.NewGuildRepository().DeleteAnnouncementSettings(guildID); err != nil {
+		return err
+	}
+
+	announcementState.mu.Lock()
+	announcementState.loaded[guildID] = true
+	delete(announcementState.settings, guildID)
+	delete(announcementState.cards, guildID)
+	announcementState.mu.Unlock()
+	return nil
+}
+
+func BuildAnnouncementComponents(item music.QueueItem) []discordgo.MessageComponent {
+	track := item.Track
+
+	title := strings.TrimSpace(track.Title)
+	if title == "" {
+		title = "알 수 없는 곡"
+	}
+	if track.URL != "" {
+		title = fmt.Sprintf("[%s](%s)", title, track.URL)
+	}
+
+	lines := []string{fmt.Sprintf("▶️ **%s**", title)}
+
+	details := []string{}
+	if track.IsLive || track.Duration <= 0 {
+		details = append(details, "🔴 실시간")
+	} else {
+		details = append(details, fmt.Sprintf("⏱️ %s", formatDuration(track.Duration)))
+	}
+	if requester := strings.TrimSpace(track.RequestedBy); requester != "" {
+		details = append(details, fmt.Sprintf("요청자: <@%s>", requester))
+	}
+	lines = append(lines, strings.Join(details, " · "))
+
+	content := discordgo.TextDisplay{Content: strings.Join(lines, "\n")}
+
+	var body discordgo.MessageComponent = content
+	if thumb := strings.TrimSpace(track.Thumbnail); thumb != "" {
+		body = discordgo.Section{
+			Components: []discordgo.MessageComponent{content},
+			Accessory: discordgo.Thumbnail{
+				Media: discordgo.UnfurledMediaItem{URL: thumb},
+			},
+		}
+	}
+
+	accent := AccentColor
+	return []discordgo.MessageComponent{
+		discordgo.Container{
+			AccentColor: &accent,
+			Components:  []discordgo.MessageComponent{body},
+		},
+	}
+}
+
+func handlePlayerEvent(event music.PlayerEvent) {
+	if !enabled.Load() || event.Session == nil || event.GuildID == "" {
+		return
+	}
+
+	switch event.Type {
+	case music.PlayerEventTrackStarted:
+		announceTrack(event)
+	case music.PlayerEventTrackEnded:
+		if event.Reason == music.TrackEndStopped {
+			settings, ok := GetSettings(event.GuildID)
+			if ok && settings.DeletePrevious {
+				deleteCard(event.Session, event.GuildID)
+			}
+		}
+	}
+}
+
+func announceTrack(event music.PlayerEvent) {
+	settings, ok := GetSettings(event.GuildID)
+	if !ok || settings.ChannelID == "" {
+		return
+	}
+
+	if settings.DeletePrevious {
+		deleteCard(event.Session, event.GuildID)
+	}
+
+	msg, err := event.Session.ChannelMessageSendComplex(settings.ChannelID, &discordgo.MessageSend{
+		Components:      BuildAnnouncementComponents(event.Item),
+		Flags:           discordgo.MessageFlagsIsComponentsV2,
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+	if err != nil {
+		logging.ForGuild(event.Session, event.GuildID).Warn("failed to send now-playing announcement", "error", err)
+		return
+	}
+
+	announcementState.mu.Lock()
+	announcementState.cards[event.GuildID] = announcementCard{
+		ChannelID: msg.ChannelID,
+		MessageID: msg.ID,
+	}
+	announcementState.mu.Unlock()
+}
+
+func deleteCard(s *discordgo.Session, guildID string) {
+	announcementState.mu.Lock()
+	card, ok := announcementState.cards[guildID]
+	delete(announcementState.cards, guildID)
+	announcementState.mu.Unlock()
+
+	if !ok {
+		return
+	}
+	if err := s.ChannelMessageDelete(card.ChannelID, card.MessageID); err != nil {
+		logging.ForGuild(s, guildID).Warn("failed to delete previous announcement", "error", err)
+	}
+}
+
+func formatDuration(d time.Duration) string {
+	totalSeconds := int(d.Seconds())
+	hours := totalSeconds / 3600
+	min := (totalSeconds % 3600) / 60
+	sec := totalSeconds % 60
+	if hours > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", hours, min, sec)
+	}
+	return fmt.Sprintf("%02d:%02d", min, sec)
+}
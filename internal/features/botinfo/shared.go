@@ -2,13 +2,17 @@ package botinfo
 
 import (
 	"fmt"
-	"log"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/logging"
+	"github.com/hxnx/tunebot/internal/shard"
 )
 
+const maxShardRows = 16
+
 var botStartedAt = time.Now()
 
 func BuildBotInfoComponents(s *discordgo.Session) []discordgo.MessageComponent {
@@ -46,25 +50,77 @@ func BuildBotInfoComponents(s *discordgo.Session) []discordgo.MessageComponent {
 	divider := true
 	spacing := discordgo.SeparatorSpacingSizeSmall
 
+	components := []discordgo.MessageComponent{
+		discordgo.TextDisplay{Content: "**TuneBot 정보**"},
+		discordgo.TextDisplay{Content: "현재 상태를 확인해 주세요."},
+		discordgo.Separator{Divider: &divider, Spacing: &spacing},
+		discordgo.TextDisplay{Content: fmt.Sprintf("**API 지연:** %s", apiLatency)},
+		discordgo.TextDisplay{Content: fmt.Sprintf("**게이트웨이 지연:** %s", gatewayLatency)},
+		discordgo.TextDisplay{Content: fmt.Sprintf("**서버 수:** %d", guilds)},
+		discordgo.TextDisplay{Content: fmt.Sprintf("**현재 샤드 / 총 샤드:** %d / %d", currentShard, shards)},
+		discordgo.TextDisplay{Content: fmt.Sprintf("**업타임:** %s", uptime)},
+		discordgo.TextDisplay{Content: fmt.Sprintf("**메모리 사용량:** %.2f MB", float64(mem.Alloc)/1024.0/1024.0)},
+	}
+
+	if table := buildShardTable(); table != "" {
+		components = append(components,
+			discordgo.Separator{Divider: &divider, Spacing: &spacing},
+			discordgo.TextDisplay{Content: "**샤드 상태**"},
+			discordgo.TextDisplay{Content: table},
+		)
+	}
+
+	components = append(components, discordgo.TextDisplay{Content: fmt.Sprintf("갱신됨 <t:%d:R>", time.Now().Unix())})
+
 	return []discordgo.MessageComponent{
 		discordgo.Container{
 			AccentColor: &colorLilac,
-			Components: []discordgo.MessageComponent{
-				discordgo.TextDisplay{Content: "**TuneBot 정보**"},
-				discordgo.TextDisplay{Content: "현재 상태를 확인해 주세요."},
-				discordgo.Separator{Divider: &divider, Spacing: &spacing},
-				discordgo.TextDisplay{Content: fmt.Sprintf("**API 지연:** %s", apiLatency)},
-				discordgo.TextDisplay{Content: fmt.Sprintf("**게이트웨이 지연:** %s", gatewayLatency)},
-				discordgo.TextDisplay{Content: fmt.Sprintf("**서버 수:** %d", guilds)},
-				discordgo.TextDisplay{Content: fmt.Sprintf("**현재 샤드 / 총 샤드:** %d / %d", currentShard, shards)},
-				discordgo.TextDisplay{Content: fmt.Sprintf("**업타임:** %s", uptime)},
-				discordgo.TextDisplay{Content: fmt.Sprintf("**메모리 사용량:** %.2f MB", float64(mem.Alloc)/1024.0/1024.0)},
-				discordgo.TextDisplay{Content: fmt.Sprintf("갱신됨 <t:%d:R>", time.Now().Unix())},
-			},
+			Components:  components,
 		},
 	}
 }
 
+func buildShardTable() string {
+	manager := shard.Default()
+	if manager == nil {
+		return ""
+	}
+
+	statuses := manager.Statuses()
+	lines := make([]string, 0, min(len(statuses), maxShardRows)+1)
+	for idx, st := range statuses {
+		if idx >= maxShardRows {
+			lines = append(lines, fmt.Sprintf("외 %d개 샤드", len(statuses)-maxShardRows))
+			break
+		}
+
+		heartbeat := "-"
+		if !st.LastHeartbeat.IsZero() {
+			heartbeat = fmt.Sprintf("<t:%d:R>", st.LastHeartbeat.Unix())
+		}
+		line := fmt.Sprintf("%s `#%d` %s · %s · 서버 %d개 · 하트비트 %s", shardStateIcon(st.State), st.ID, st.State, st.Latency, st.Guilds, heartbeat)
+		if st.Reconnects > 0 {
+			line += fmt.Sprintf(" · 재연결 %d회", st.Reconnects)
+		}
+		if st.NextRetry != nil {
+			line += fmt.Sprintf(" · 재시도 <t:%d:R>", st.NextRetry.Unix())
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func shardStateIcon(state shard.State) string {
+	switch state {
+	case shard.StateReady:
+		return "🟢"
+	case shard.StateConnecting, shard.StateBackoff:
+		return "🟡"
+	default:
+		return "🔴"
+	}
+}
+
 func RespondBotInfo(s *discordgo.Session, i *discordgo.InteractionCreate, respType discordgo.InteractionResponseType) {
 	if s == nil || i == nil {
 		return
@@ -80,6 +136,6 @@ func RespondBotInfo(s *discordgo.Session, i *discordgo.InteractionCreate, respTy
 		},
 	})
 	if err != nil {
-		log.Printf("failed to respond to bot info: %v", err)
+		logging.ForInteraction(s, i).Warn("failed to respond to bot info", "error", err)
 	}
 }
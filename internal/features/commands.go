@@ -3,28 +3,105 @@ package commands
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/cluster"
+	announcementcmd "github.com/hxnx/tunebot/internal/features/announcements/commands"
 	botinfocmd "github.com/hxnx/tunebot/internal/features/botinfo/commands"
-	dashboard "github.com/hxnx/tunebot/internal/features/dashboard"
+	"github.com/hxnx/tunebot/internal/features/dashboard"
 	dashboardcmd "github.com/hxnx/tunebot/internal/features/dashboard/commands"
 	dashboardlisteners "github.com/hxnx/tunebot/internal/features/dashboard/listeners"
+	librarycmd "github.com/hxnx/tunebot/internal/features/library/commands"
 	"github.com/hxnx/tunebot/internal/features/modals"
 	musiccmd "github.com/hxnx/tunebot/internal/features/music/commands"
 	musiclisteners "github.com/hxnx/tunebot/internal/features/music/listeners"
 	queueview "github.com/hxnx/tunebot/internal/features/music/queueview"
+	"github.com/hxnx/tunebot/internal/features/owner"
+	ownercmd "github.com/hxnx/tunebot/internal/features/owner/commands"
 	pingcmd "github.com/hxnx/tunebot/internal/features/ping/commands"
 	pinglisteners "github.com/hxnx/tunebot/internal/features/ping/listeners"
+	playlistcmd "github.com/hxnx/tunebot/internal/features/playlist/commands"
+	playlistlisteners "github.com/hxnx/tunebot/internal/features/playlist/listeners"
+	scrobblecmd "github.com/hxnx/tunebot/internal/features/scrobbling/commands"
+	scrobblelisteners "github.com/hxnx/tunebot/internal/features/scrobbling/listeners"
 	shared "github.com/hxnx/tunebot/internal/features/shared"
+	statscmd "github.com/hxnx/tunebot/internal/features/stats/commands"
+	statslisteners "github.com/hxnx/tunebot/internal/features/stats/listeners"
+	webcontrolcmd "github.com/hxnx/tunebot/internal/features/webcontrol/commands"
+	webhookcmd "github.com/hxnx/tunebot/internal/features/webhooks/commands"
+	"github.com/hxnx/tunebot/internal/logging"
+	"github.com/hxnx/tunebot/internal/metrics"
 	"github.com/hxnx/tunebot/internal/music"
 )
 
 const musicQueueDefaultLimit = int64(10)
 
 var (
+	playlistMinPosition   = float64(1)
+	apiTokenMinID         = float64(1)
+	webhookMinID          = float64(1)
+	scrobbleServiceOption = &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "서비스",
+		Description: "스크로블 서비스",
+		Required:    true,
+		Choices: []*discordgo.ApplicationCommandOptionChoice{
+			{
+				Name:  "ListenBrainz",
+				Value: "listenbrainz",
+			},
+			{
+				Name:  "Last.fm",
+				Value: "lastfm",
+			},
+		},
+	}
+	statsWindowOption = &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "기간",
+		Description: "집계 기간 (기본: 최근 7일)",
+		Required:    false,
+		Choices: []*discordgo.ApplicationCommandOptionChoice{
+			{
+				Name:  "최근 7일",
+				Value: "7d",
+			},
+			{
+				Name:  "최근 30일",
+				Value: "30d",
+			},
+			{
+				Name:  "전체 기간",
+				Value: "all",
+			},
+		},
+	}
+	playlistNameOption = &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "이름",
+		Description: "플레이리스트 이름",
+		Required:    true,
+	}
+	playlistScopeOption = &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "범위",
+		Description: "개인/서버 (기본: 개인 → 서버 순으로 찾기)",
+		Required:    false,
+		Choices: []*discordgo.ApplicationCommandOptionChoice{
+			{
+				Name:  "개인",
+				Value: "user",
+			},
+			{
+				Name:  "서버",
+				Value: "guild",
+			},
+		},
+	}
+
 	CommandList = []*discordgo.ApplicationCommand{
 		{
 			Name:        "핑",
@@ -42,6 +119,15 @@ var (
 					Type:        discordgo.ApplicationCommandOptionSubCommand,
 					Name:        "재생",
 					Description: "노래를 검색해 재생합니다",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:         discordgo.ApplicationCommandOptionString,
+							Name:         "query",
+							Description:  "노래 제목 또는 URL (비워두면 검색 창을 엽니다)",
+							Required:     false,
+							Autocomplete: true,
+						},
+					},
 				},
 				{
 					Type:        discordgo.ApplicationCommandOptionSubCommand,
@@ -67,6 +153,52 @@ var (
 					},
 				},
 
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "내보내기",
+					Description: "현재 대기열을 파일로 내보냅니다",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "형식",
+							Description: "파일 형식 (기본: JSON)",
+							Required:    false,
+							Choices: []*discordgo.ApplicationCommandOptionChoice{
+								{
+									Name:  "JSON",
+									Value: "json",
+								},
+								{
+									Name:  "M3U8",
+									Value: "m3u8",
+								},
+								{
+									Name:  "XSPF",
+									Value: "xspf",
+								},
+							},
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "가져오기",
+					Description: "내보낸 파일이나 M3U 목록으로 대기열을 채웁니다",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionAttachment,
+							Name:        "파일",
+							Description: "JSON/M3U8/XSPF 파일 (없으면 붙여넣기 창을 엽니다)",
+							Required:    false,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionBoolean,
+							Name:        "설정적용",
+							Description: "파일에 저장된 반복/셔플/볼륨 설정도 적용합니다",
+							Required:    false,
+						},
+					},
+				},
 				{
 					Type:        discordgo.ApplicationCommandOptionSubCommand,
 					Name:        "반복",
@@ -96,6 +228,10 @@ var (
 				},
 			},
 		},
+		{
+			Type: discordgo.MessageApplicationCommand,
+			Name: musiccmd.PlayMessageCommandName,
+		},
 		{
 			Name:        "대시보드",
 			Description: "TuneBot 대시보드를 설정합니다",
@@ -115,12 +251,334 @@ var (
 				},
 			},
 		},
+		{
+			Name:        "플레이리스트",
+			Description: "저장된 플레이리스트를 관리합니다",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "생성",
+					Description: "새 플레이리스트를 만듭니다",
+					Options:     []*discordgo.ApplicationCommandOption{playlistNameOption, playlistScopeOption},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "현재곡추가",
+					Description: "재생 중인 곡을 플레이리스트에 추가합니다",
+					Options:     []*discordgo.ApplicationCommandOption{playlistNameOption, playlistScopeOption},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "대기열추가",
+					Description: "현재 대기열의 곡을 플레이리스트에 추가합니다",
+					Options:     []*discordgo.ApplicationCommandOption{playlistNameOption, playlistScopeOption},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "곡삭제",
+					Description: "플레이리스트에서 곡을 삭제합니다",
+					Options: []*discordgo.ApplicationCommandOption{
+						playlistNameOption,
+						{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "번호",
+							Description: "삭제할 곡 번호",
+							Required:    true,
+							MinValue:    &playlistMinPosition,
+						},
+						playlistScopeOption,
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "목록",
+					Description: "사용할 수 있는 플레이리스트를 표시합니다",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "보기",
+					Description: "플레이리스트의 곡을 표시합니다",
+					Options:     []*discordgo.ApplicationCommandOption{playlistNameOption, playlistScopeOption},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "재생",
+					Description: "플레이리스트의 곡을 대기열에 추가합니다",
+					Options:     []*discordgo.ApplicationCommandOption{playlistNameOption, playlistScopeOption},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "삭제",
+					Description: "플레이리스트를 삭제합니다",
+					Options:     []*discordgo.ApplicationCommandOption{playlistNameOption, playlistScopeOption},
+				},
+			},
+		},
+		{
+			Name:        "알림채널",
+			Description: "재생 중인 곡을 알릴 채널을 설정합니다",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "설정",
+					Description: "재생 알림 채널을 지정합니다",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:         discordgo.ApplicationCommandOptionChannel,
+							Name:         "채널",
+							Description:  "알림을 보낼 텍스트 채널",
+							Required:     true,
+							ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionBoolean,
+							Name:        "이전카드삭제",
+							Description: "새 곡이 시작되면 이전 알림을 삭제합니다 (기본: 예)",
+							Required:    false,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "해제",
+					Description: "재생 알림을 끕니다",
+				},
+			},
+		},
+		{
+			Name:        "라이브러리",
+			Description: "로컬 음악 라이브러리 관리 (봇 소유자 전용)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "갱신",
+					Description: "라이브러리 폴더를 다시 스캔합니다",
+				},
+			},
+		},
+		{
+			Name:        "웹제어",
+			Description: "웹/모바일 제어용 API 토큰을 관리합니다 (DJ 전용)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "토큰발급",
+					Description: "새 API 토큰을 발급합니다",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "이름",
+							Description: "토큰을 구분할 이름 (예: 휴대폰)",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "토큰목록",
+					Description: "이 서버에 발급된 토큰을 확인합니다",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "토큰폐기",
+					Description: "토큰을 폐기합니다",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "번호",
+							Description: "토큰목록에 표시된 번호",
+							Required:    true,
+							MinValue:    &apiTokenMinID,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "웹훅",
+			Description: "재생 이벤트를 외부 서비스로 보내는 웹훅을 관리합니다 (서버 관리자 전용)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "등록",
+					Description: "새 웹훅 주소를 등록합니다",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "주소",
+							Description: "이벤트를 받을 http(s) 주소",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "목록",
+					Description: "등록된 웹훅과 전송 상태를 확인합니다",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "삭제",
+					Description: "웹훅을 삭제합니다",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "번호",
+							Description: "목록에 표시된 번호",
+							Required:    true,
+							MinValue:    &webhookMinID,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "테스트",
+					Description: "웹훅으로 테스트 이벤트를 보냅니다",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "번호",
+							Description: "목록에 표시된 번호",
+							Required:    true,
+							MinValue:    &webhookMinID,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "통계",
+			Description: "서버의 청취 통계와 순위를 확인합니다",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "서버",
+					Description: "인기 곡, 요청 순위, 시간대와 출처별 통계를 확인합니다",
+					Options: []*discordgo.ApplicationCommandOption{
+						statsWindowOption,
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "내통계",
+					Description: "내가 요청한 곡의 통계를 확인합니다",
+					Options: []*discordgo.ApplicationCommandOption{
+						statsWindowOption,
+					},
+				},
+			},
+		},
+		{
+			Name:        "스크로블",
+			Description: "들은 곡을 Last.fm / ListenBrainz에 기록합니다",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "연결",
+					Description: "스크로블 서비스 계정을 연결합니다",
+					Options: []*discordgo.ApplicationCommandOption{
+						scrobbleServiceOption,
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "토큰",
+							Description: "ListenBrainz 사용자 토큰 (Last.fm은 입력하지 않습니다)",
+							Required:    false,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "해제",
+					Description: "스크로블 서비스 연결을 해제합니다",
+					Options: []*discordgo.ApplicationCommandOption{
+						scrobbleServiceOption,
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "상태",
+					Description: "연결된 서비스와 전송 대기 현황을 확인합니다",
+				},
+			},
+		},
+		{
+			Name:        "관리",
+			Description: "봇 운영 명령어 (봇 소유자 전용)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "동기화",
+					Description: "슬래시 커맨드를 다시 등록합니다",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "범위",
+							Description: "서버/전역 (기본: 서버)",
+							Required:    false,
+							Choices: []*discordgo.ApplicationCommandOptionChoice{
+								{
+									Name:  "서버",
+									Value: "guild",
+								},
+								{
+									Name:  "전역",
+									Value: "global",
+								},
+							},
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "플레이어",
+					Description: "모든 서버의 활성 플레이어를 확인합니다",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "강제정지",
+					Description: "서버의 재생을 강제로 정지하고 대기열을 비웁니다",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "서버",
+							Description: "서버 ID (기본: 현재 서버)",
+							Required:    false,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "설정리로드",
+					Description: "설정 파일과 환경 변수를 다시 불러옵니다",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "검색캐시삭제",
+					Description: "검색 결과 캐시를 비웁니다",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "진단",
+					Description: "런타임과 연결 상태를 확인합니다",
+				},
+			},
+		},
 	}
 	commandHandlers = map[string]func(s *discordgo.Session, i *discordgo.InteractionCreate){
-		"핑":    pingcmd.Ping,
-		"봇정보":  botinfocmd.Info,
-		"노래":   handleMusicGroupCommand,
-		"대시보드": dashboardcmd.SetupDashboard,
+		"핑":      pingcmd.Ping,
+		"봇정보":    botinfocmd.Info,
+		"노래":     handleMusicGroupCommand,
+		"대시보드":   dashboardcmd.SetupDashboard,
+		"플레이리스트": handlePlaylistGroupCommand,
+		"라이브러리":  handleLibraryGroupCommand,
+		"알림채널":   handleAnnouncementGroupCommand,
+		"관리":     handleOwnerGroupCommand,
+		"웹제어":    handleWebControlGroupCommand,
+		"웹훅":     handleWebhookGroupCommand,
+		"스크로블":   handleScrobbleGroupCommand,
+		"통계":     handleStatsGroupCommand,
+
+		musiccmd.PlayMessageCommandName: musiccmd.PlayMessage,
 	}
 )
 
@@ -138,7 +596,7 @@ func handleMusicGroupCommand(s *discordgo.Session, i *discordgo.InteractionCreat
 
 	switch sub.Name {
 	case "재생":
-		musiccmd.Play(s, i)
+		musiccmd.Play(s, i, sub.Options)
 	case "정지":
 		musiccmd.Stop(s, i)
 	case "스킵":
@@ -147,11 +605,230 @@ func handleMusicGroupCommand(s *discordgo.Session, i *discordgo.InteractionCreat
 		handleMusicQueueSubcommand(s, i, sub.Options)
 	case "반복":
 		handleMusicRepeatSubcommand(s, i, sub.Options)
+	case "내보내기":
+		musiccmd.Export(s, i, sub.Options)
+	case "가져오기":
+		musiccmd.Import(s, i, sub.Options)
 	default:
 		shared.RespondEphemeral(s, i, "지원하지 않는 노래 명령입니다.")
 	}
 }
 
+func handlePlaylistGroupCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type != discordgo.InteractionApplicationCommand {
+		return
+	}
+
+	sub := getSubcommandOption(i.ApplicationCommandData())
+	if sub == nil {
+		shared.RespondEphemeral(s, i, "사용할 명령을 선택해 주세요.")
+		return
+	}
+
+	switch sub.Name {
+	case "생성":
+		playlistcmd.Create(s, i, sub.Options)
+	case "현재곡추가":
+		playlistcmd.AddCurrent(s, i, sub.Options)
+	case "대기열추가":
+		playlistcmd.AddQueue(s, i, sub.Options)
+	case "곡삭제":
+		playlistcmd.Remove(s, i, sub.Options)
+	case "목록":
+		playlistcmd.List(s, i)
+	case "보기":
+		playlistcmd.Show(s, i, sub.Options)
+	case "재생":
+		playlistcmd.Play(s, i, sub.Options)
+	case "삭제":
+		playlistcmd.Delete(s, i, sub.Options)
+	default:
+		shared.RespondEphemeral(s, i, "지원하지 않는 플레이리스트 명령입니다.")
+	}
+}
+
+func handleAnnouncementGroupCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type != discordgo.InteractionApplicationCommand {
+		return
+	}
+
+	sub := getSubcommandOption(i.ApplicationCommandData())
+	if sub == nil {
+		shared.RespondEphemeral(s, i, "사용할 명령을 선택해 주세요.")
+		return
+	}
+
+	switch sub.Name {
+	case "설정":
+		announcementcmd.Set(s, i, sub.Options)
+	case "해제":
+		announcementcmd.Clear(s, i)
+	default:
+		shared.RespondEphemeral(s, i, "지원하지 않는 알림 명령입니다.")
+	}
+}
+
+func handleLibraryGroupCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type != discordgo.InteractionApplicationCommand {
+		return
+	}
+
+	if !owner.RequireOwner(s, i) {
+		return
+	}
+
+	sub := getSubcommandOption(i.ApplicationCommandData())
+	if sub == nil {
+		shared.RespondEphemeral(s, i, "사용할 명령을 선택해 주세요.")
+		return
+	}
+
+	switch sub.Name {
+	case "갱신":
+		librarycmd.Refresh(s, i)
+	default:
+		shared.RespondEphemeral(s, i, "지원하지 않는 라이브러리 명령입니다.")
+	}
+}
+
+func handleOwnerGroupCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type != discordgo.InteractionApplicationCommand {
+		return
+	}
+
+	if !owner.RequireOwner(s, i) {
+		return
+	}
+
+	sub := getSubcommandOption(i.ApplicationCommandData())
+	if sub == nil {
+		shared.RespondEphemeral(s, i, "사용할 명령을 선택해 주세요.")
+		return
+	}
+
+	switch sub.Name {
+	case "동기화":
+		handleOwnerSync(s, i, sub.Options)
+	case "플레이어":
+		ownercmd.Players(s, i)
+	case "강제정지":
+		ownercmd.ForceStop(s, i, sub.Options)
+	case "설정리로드":
+		ownercmd.ReloadConfig(s, i)
+	case "검색캐시삭제":
+		ownercmd.ClearSearchCache(s, i)
+	case "진단":
+		ownercmd.Diagnostics(s, i)
+	default:
+		shared.RespondEphemeral(s, i, "지원하지 않는 관리 명령입니다.")
+	}
+}
+
+func handleWebControlGroupCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type != discordgo.InteractionApplicationCommand {
+		return
+	}
+
+	sub := getSubcommandOption(i.ApplicationCommandData())
+	if sub == nil {
+		shared.RespondEphemeral(s, i, "사용할 명령을 선택해 주세요.")
+		return
+	}
+
+	switch sub.Name {
+	case "토큰발급":
+		webcontrolcmd.IssueToken(s, i, sub.Options)
+	case "토큰목록":
+		webcontrolcmd.ListTokens(s, i)
+	case "토큰폐기":
+		webcontrolcmd.RevokeToken(s, i, sub.Options)
+	default:
+		shared.RespondEphemeral(s, i, "지원하지 않는 웹제어 명령입니다.")
+	}
+}
+
+func handleWebhookGroupCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type != discordgo.InteractionApplicationCommand {
+		return
+	}
+
+	sub := getSubcommandOption(i.ApplicationCommandData())
+	if sub == nil {
+		shared.RespondEphemeral(s, i, "사용할 명령을 선택해 주세요.")
+		return
+	}
+
+	switch sub.Name {
+	case "등록":
+		webhookcmd.Add(s, i, sub.Options)
+	case "목록":
+		webhookcmd.List(s, i)
+	case "삭제":
+		webhookcmd.Delete(s, i, sub.Options)
+	case "테스트":
+		webhookcmd.Test(s, i, sub.Options)
+	default:
+		shared.RespondEphemeral(s, i, "지원하지 않는 웹훅 명령입니다.")
+	}
+}
+
+func handleStatsGroupCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type != discordgo.InteractionApplicationCommand {
+		return
+	}
+
+	sub := getSubcommandOption(i.ApplicationCommandData())
+	if sub == nil {
+		shared.RespondEphemeral(s, i, "사용할 명령을 선택해 주세요.")
+		return
+	}
+
+	switch sub.Name {
+	case "서버":
+		statscmd.Guild(s, i, sub.Options)
+	case "내통계":
+		statscmd.Me(s, i, sub.Options)
+	default:
+		shared.RespondEphemeral(s, i, "지원하지 않는 통계 명령입니다.")
+	}
+}
+
+func handleScrobbleGroupCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type != discordgo.InteractionApplicationCommand {
+		return
+	}
+
+	sub := getSubcommandOption(i.ApplicationCommandData())
+	if sub == nil {
+		shared.RespondEphemeral(s, i, "사용할 명령을 선택해 주세요.")
+		return
+	}
+
+	switch sub.Name {
+	case "연결":
+		scrobblecmd.Link(s, i, sub.Options)
+	case "해제":
+		scrobblecmd.Unlink(s, i, sub.Options)
+	case "상태":
+		scrobblecmd.Status(s, i)
+	default:
+		shared.RespondEphemeral(s, i, "지원하지 않는 스크로블 명령입니다.")
+	}
+}
+
+func handleAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ApplicationCommandData()
+	if data.Name != "노래" {
+		return
+	}
+
+	sub := getSubcommandOption(data)
+	if sub == nil || sub.Name != "재생" {
+		return
+	}
+	musiccmd.PlayAutocomplete(s, i, sub.Options)
+}
+
 func handleMusicQueueSubcommand(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
 	if i.GuildID == "" {
 		shared.RespondEphemeral(s, i, "이 명령어는 서버에서만 사용할 수 있습니다.")
@@ -174,7 +851,7 @@ func handleMusicQueueSubcommand(s *discordgo.Session, i *discordgo.InteractionCr
 
 	items, err := store.List(ctx, i.GuildID, 0)
 	if err != nil {
-		log.Printf("queue error: %v", err)
+		logging.ForInteraction(s, i).Error("failed to load queue", "error", err)
 		shared.RespondEphemeral(s, i, "대기열을 불러오지 못했습니다.")
 		return
 	}
@@ -194,7 +871,7 @@ func handleMusicQueueSubcommand(s *discordgo.Session, i *discordgo.InteractionCr
 			Flags:      discordgo.MessageFlagsIsComponentsV2 | discordgo.MessageFlagsEphemeral,
 		},
 	}); err != nil {
-		log.Printf("queue respond failed: %v", err)
+		logging.ForInteraction(s, i).Warn("queue respond failed", "error", err)
 	}
 }
 
@@ -241,17 +918,11 @@ func handleMusicRepeatSubcommand(s *discordgo.Session, i *discordgo.InteractionC
 		return
 	}
 
-	if err := store.SetSettings(ctx, i.GuildID, settings); err != nil {
+	if err := cluster.UpdateSettings(ctx, i.GuildID, settings); err != nil {
 		shared.RespondEphemeral(s, i, "설정 저장에 실패했습니다.")
 		return
 	}
 
-	dashboard.UpdateDashboardSettingsCache(i.GuildID, settings)
-
-	if err := dashboard.UpdateDashboardByGuild(s, i.GuildID); err != nil {
-		log.Printf("failed to update dashboard after repeat set: %v", err)
-	}
-
 	shared.RespondEphemeral(s, i, fmt.Sprintf("반복 모드를 %s으로 설정했습니다.", label))
 }
 
@@ -274,7 +945,7 @@ func RegisterCommands(s *discordgo.Session, appID string, guildID string) ([]*di
 		scope = fmt.Sprintf("guild:%s", guildID)
 	}
 
-	log.Printf("Registering %d commands (%s)", len(CommandList), scope)
+	slog.Info("registering commands", "count", len(CommandList), "scope", scope)
 
 	cmds, err := s.ApplicationCommandBulkOverwrite(appID, guildID, CommandList)
 	if err != nil {
@@ -295,6 +966,10 @@ func AddHandlers(s *discordgo.Session) {
 		musiclisteners.HandleVoiceStateUpdate(s, vs)
 	})
 
+	s.AddHandler(func(s *discordgo.Session, r *discordgo.RateLimit) {
+		dashboard.HandleRateLimit(s, r)
+	})
+
 	s.AddHandler(func(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		if modals.DefaultAwaiter.HandleInteraction(i) {
 			return
@@ -304,8 +979,11 @@ func AddHandlers(s *discordgo.Session) {
 		case discordgo.InteractionApplicationCommand:
 			data := i.ApplicationCommandData()
 			if handler, ok := commandHandlers[data.Name]; ok {
+				metrics.CommandInvocations.WithLabelValues(data.Name).Inc()
 				handler(s, i)
 			}
+		case discordgo.InteractionApplicationCommandAutocomplete:
+			handleAutocomplete(s, i)
 		case discordgo.InteractionModalSubmit:
 			if dashboardlisteners.RouteDashboardComponent(s, i) {
 				return
@@ -317,9 +995,18 @@ func AddHandlers(s *discordgo.Session) {
 			if musiclisteners.RouteMusicComponent(s, i) {
 				return
 			}
+			if playlistlisteners.RoutePlaylistComponent(s, i) {
+				return
+			}
 			if dashboardlisteners.RouteDashboardComponent(s, i) {
 				return
 			}
+			if scrobblelisteners.RouteScrobbleComponent(s, i) {
+				return
+			}
+			if statslisteners.RouteStatsComponent(s, i) {
+				return
+			}
 		default:
 			return
 		}
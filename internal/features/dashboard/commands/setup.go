@@ -2,11 +2,11 @@ package commands
 
 import (
 	"fmt"
-	"log"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/hxnx/tunebot/internal/database"
 	dashboard "github.com/hxnx/tunebot/internal/features/dashboard"
+	"github.com/hxnx/tunebot/internal/logging"
 )
 
 func SetupDashboard(s *discordgo.Session, i *discordgo.InteractionCreate) {
@@ -55,7 +55,7 @@ func SetupDashboard(s *discordgo.Session, i *discordgo.InteractionCreate) {
 			ParentID: categoryID,
 		})
 		if err != nil {
-			log.Printf("failed to create dashboard channel: %v", err)
+			logging.ForInteraction(s, i).Error("failed to create dashboard channel", "error", err)
 			dashboard.RespondEphemeral(s, i, "대시보드 채널 생성에 실패했습니다.")
 			return
 		}
@@ -63,7 +63,7 @@ func SetupDashboard(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	}
 
 	if err := dashboard.DeletePreviousDashboard(s, i.GuildID); err != nil {
-		log.Printf("failed to delete previous dashboard message: %v", err)
+		logging.ForInteraction(s, i).Error("failed to delete previous dashboard message", "error", err)
 	}
 
 	dashboardMessage, err := s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
@@ -72,7 +72,7 @@ func SetupDashboard(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	})
 
 	if err != nil {
-		log.Printf("failed to send dashboard message: %v", err)
+		logging.ForInteraction(s, i).Error("failed to send dashboard message", "error", err)
 		dashboard.RespondEphemeral(s, i, "대시보드 메시지 생성에 실패했습니다.")
 		return
 	}
@@ -84,11 +84,11 @@ func SetupDashboard(s *discordgo.Session, i *discordgo.InteractionCreate) {
 
 	repo := database.NewGuildRepository()
 	if err := repo.UpsertDashboardEntry(i.GuildID, channelID, dashboardMessage.ID); err != nil {
-		log.Printf("failed to save dashboard entry: %v", err)
+		logging.ForInteraction(s, i).Error("failed to save dashboard entry", "error", err)
 	}
 
 	if err := dashboard.UpdateDashboardByGuild(s, i.GuildID); err != nil {
-		log.Printf("failed to start dashboard updater: %v", err)
+		logging.ForInteraction(s, i).Error("failed to start dashboard updater", "error", err)
 	}
 
 	dashboard.RespondEphemeral(s, i, fmt.Sprintf("대시보드 채널을 설정했습니다.\n<#%s>", channelID))
@@ -0,0 +1,138 @@
+package dashboard
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/logging"
+	"github.com/hxnx/tunebot/internal/metrics"
+	"github.com/hxnx/tunebot/internal/music"
+)
+
+const dashboardEventDebounce = 750 * time.Millisecond
+
+var pendingDashboardUpdates = struct {
+	mu      sync.Mutex
+	byGuild map[string]*time.Timer
+}{
+	byGuild: make(map[string]*time.Timer),
+}
+
+var registerOnce sync.Once
+
+func Register(manager *music.PlayerManager) {
+	if manager == nil {
+		return
+	}
+	registerOnce.Do(func() {
+		manager.Subscribe(handlePlayerEvent)
+	})
+}
+
+func handlePlayerEvent(event music.PlayerEvent) {
+	if event.GuildID == "" {
+		return
+	}
+
+	switch event.Type {
+	case music.PlayerEventSettingsChanged:
+		UpdateDashboardSettingsCache(event.GuildID, event.Settings)
+	case music.PlayerEventQueueChanged:
+		invalidateDashboardQueueCount(event.GuildID)
+	}
+
+	scheduleDashboardUpdate(event.Session, event.GuildID)
+}
+
+func scheduleDashboardUpdate(s *discordgo.Session, guildID string) {
+	if s == nil || guildID == "" {
+		return
+	}
+
+	pendingDashboardUpdates.mu.Lock()
+	defer pendingDashboardUpdates.mu.Unlock()
+
+	if timer, ok := pendingDashboardUpdates.byGuild[guildID]; ok {
+		timer.Reset(dashboardEventDebounce)
+		return
+	}
+
+	pendingDashboardUpdates.byGuild[guildID] = time.AfterFunc(dashboardEventDebounce, func() {
+		pendingDashboardUpdates.mu.Lock()
+		delete(pendingDashboardUpdates.byGuild, guildID)
+		pendingDashboardUpdates.mu.Unlock()
+
+		if err := UpdateDashboardByGuild(s, guildID); err != nil && !errors.Is(err, ErrDashboardNotFound) {
+			logging.ForGuild(s, guildID).Error("dashboard event update failed", "error", err)
+		}
+	})
+}
+
+func cancelDashboardUpdate(guildID string) {
+	pendingDashboardUpdates.mu.Lock()
+	defer pendingDashboardUpdates.mu.Unlock()
+
+	if timer, ok := pendingDashboardUpdates.byGuild[guildID]; ok {
+		timer.Stop()
+		delete(pendingDashboardUpdates.byGuild, guildID)
+	}
+}
+
+func invalidateDashboardQueueCount(guildID string) {
+	dashboardStoreCache.mu.Lock()
+	entry := dashboardStoreCache.byGuild[guildID]
+	entry.hasQueueCount = false
+	dashboardStoreCache.byGuild[guildID] = entry
+	dashboardStoreCache.mu.Unlock()
+	clearRedisQueueCountCache(guildID)
+}
+
+var dashboardEdits = struct {
+	mu       sync.Mutex
+	inFlight map[string]int
+}{
+	inFlight: make(map[string]int),
+}
+
+func trackDashboardEdit(messageID string, started bool) {
+	if messageID == "" {
+		return
+	}
+
+	dashboardEdits.mu.Lock()
+	defer dashboardEdits.mu.Unlock()
+
+	if started {
+		dashboardEdits.inFlight[messageID]++
+		return
+	}
+	if dashboardEdits.inFlight[messageID] <= 1 {
+		delete(dashboardEdits.inFlight, messageID)
+		return
+	}
+	dashboardEdits.inFlight[messageID]--
+}
+
+func HandleRateLimit(s *discordgo.Session, r *discordgo.RateLimit) {
+	if r == nil || r.URL == "" {
+		return
+	}
+
+	dashboardEdits.mu.Lock()
+	defer dashboardEdits.mu.Unlock()
+
+	for messageID := range dashboardEdits.inFlight {
+		if strings.Contains(r.URL, "/messages/"+messageID) {
+			metrics.DashboardRateLimits.Inc()
+			return
+		}
+	}
+}
+
+func isRateLimitError(err error) bool {
+	var rateLimitErr *discordgo.RateLimitError
+	return errors.As(err, &rateLimitErr)
+}
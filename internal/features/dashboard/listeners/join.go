@@ -2,10 +2,10 @@ package listeners
 
 import (
 	"errors"
-	"log"
 
 	"github.com/bwmarrin/discordgo"
 	dashboard "github.com/hxnx/tunebot/internal/features/dashboard"
+	"github.com/hxnx/tunebot/internal/logging"
 	"github.com/hxnx/tunebot/internal/music"
 )
 
@@ -24,13 +24,10 @@ func handleDashboardJoin(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	player := music.DefaultPlayerManager.Get(i.GuildID)
 	if player.HasVoiceConnection() {
 		if err := player.Stop(false); err != nil && !errors.Is(err, music.ErrPlaybackStopped) {
-			log.Printf("dashboard join: failed to leave voice channel: %v", err)
+			logging.ForInteraction(s, i).Error("dashboard join: failed to leave voice channel", "error", err)
 			dashboard.RespondEphemeral(s, i, "음성 채널 퇴장에 실패했습니다.")
 			return
 		}
-		if err := dashboard.UpdateDashboardByGuild(s, i.GuildID); err != nil {
-			log.Printf("dashboard join: failed to update dashboard after leave: %v", err)
-		}
 		dashboard.RespondEphemeral(s, i, "음성 채널에서 퇴장했습니다.")
 		return
 	}
@@ -41,19 +38,16 @@ func handleDashboardJoin(s *discordgo.Session, i *discordgo.InteractionCreate) {
 			dashboard.RespondEphemeral(s, i, "먼저 음성 채널에 접속해 주세요.")
 			return
 		}
-		log.Printf("dashboard join: failed to find voice channel: %v", err)
+		logging.ForInteraction(s, i).Error("dashboard join: failed to find voice channel", "error", err)
 		dashboard.RespondEphemeral(s, i, "음성 채널 정보를 확인할 수 없습니다.")
 		return
 	}
 
 	if err := player.JoinVoice(s, channelID); err != nil {
-		log.Printf("dashboard join: failed to join voice channel: %v", err)
+		logging.ForInteraction(s, i).Error("dashboard join: failed to join voice channel", "error", err)
 		dashboard.RespondEphemeral(s, i, "음성 채널 참가에 실패했습니다.")
 		return
 	}
 
-	if err := dashboard.UpdateDashboardByGuild(s, i.GuildID); err != nil {
-		log.Printf("dashboard join: failed to update dashboard after join: %v", err)
-	}
 	dashboard.RespondEphemeral(s, i, "음성 채널에 참가했습니다.")
 }
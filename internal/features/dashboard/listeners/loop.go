@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/cluster"
 	dashboard "github.com/hxnx/tunebot/internal/features/dashboard"
 	"github.com/hxnx/tunebot/internal/music"
 )
@@ -39,7 +40,7 @@ func handleDashboardLoop(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		settings.RepeatMode = music.RepeatModeNone
 	}
 
-	if err := store.SetSettings(ctx, i.GuildID, settings); err != nil {
+	if err := cluster.UpdateSettings(ctx, i.GuildID, settings); err != nil {
 		dashboard.RespondEphemeral(s, i, "설정 저장에 실패했습니다.")
 		return
 	}
@@ -1,11 +1,13 @@
 package listeners
 
 import (
+	"context"
 	"errors"
-	"log"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/cluster"
 	dashboard "github.com/hxnx/tunebot/internal/features/dashboard"
+	"github.com/hxnx/tunebot/internal/logging"
 	"github.com/hxnx/tunebot/internal/music"
 )
 
@@ -27,7 +29,7 @@ func handleDashboardPause(s *discordgo.Session, i *discordgo.InteractionCreate)
 			dashboard.RespondEphemeral(s, i, "먼저 음성 채널에 접속해 주세요.")
 			return
 		}
-		log.Printf("dashboard pause: failed to find voice channel: %v", err)
+		logging.ForInteraction(s, i).Error("dashboard pause: failed to find voice channel", "error", err)
 		dashboard.RespondEphemeral(s, i, "음성 채널 정보를 확인할 수 없습니다.")
 		return
 	}
@@ -39,8 +41,8 @@ func handleDashboardPause(s *discordgo.Session, i *discordgo.InteractionCreate)
 		return
 	}
 
-	if err := player.TogglePause(); err != nil {
-		log.Printf("dashboard pause: toggle failed: %v", err)
+	if err := cluster.TogglePause(context.Background(), i.GuildID); err != nil {
+		logging.ForInteraction(s, i).Error("dashboard pause: toggle failed", "error", err)
 		dashboard.RespondEphemeral(s, i, "일시정지/재개에 실패했습니다.")
 		return
 	}
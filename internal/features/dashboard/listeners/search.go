@@ -2,12 +2,12 @@ package listeners
 
 import (
 	"context"
-	"log"
-	"os"
+	"errors"
 	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/logging"
 
 	musicsearch "github.com/hxnx/tunebot/internal/features/music/search"
 	"github.com/hxnx/tunebot/internal/music"
@@ -45,7 +45,7 @@ func handleDashboardSearch(s *discordgo.Session, i *discordgo.InteractionCreate)
 				},
 			},
 			discordgo.Label{
-				Label:       "플랫폼 (auto / yt / sp / sc)",
+				Label:       "플랫폼",
 				Description: "자동 선택 또는 직접 선택하세요",
 				Component: discordgo.SelectMenu{
 					MenuType:    discordgo.StringSelectMenu,
@@ -69,6 +69,26 @@ func handleDashboardSearch(s *discordgo.Session, i *discordgo.InteractionCreate)
 							Label: "SoundCloud",
 							Value: "soundcloud",
 						},
+						{
+							Label: "Bandcamp (URL)",
+							Value: "bandcamp",
+						},
+						{
+							Label: "Vimeo (URL)",
+							Value: "vimeo",
+						},
+						{
+							Label: "Twitch (URL)",
+							Value: "twitch",
+						},
+						{
+							Label: "Mixcloud (URL)",
+							Value: "mixcloud",
+						},
+						{
+							Label: "Local Library",
+							Value: "local",
+						},
 					},
 				},
 			},
@@ -79,7 +99,7 @@ func handleDashboardSearch(s *discordgo.Session, i *discordgo.InteractionCreate)
 		Type: discordgo.InteractionResponseModal,
 		Data: modal,
 	}); err != nil {
-		log.Printf("dashboard search: modal open failed: %v", err)
+		logging.ForInteraction(s, i).Error("dashboard search: modal open failed", "error", err)
 	}
 }
 
@@ -118,7 +138,7 @@ func respondPublic(s *discordgo.Session, i *discordgo.InteractionCreate, content
 			Flags:      discordgo.MessageFlagsIsComponentsV2,
 		},
 	}); err != nil {
-		log.Printf("dashboard search: respond failed: %v", err)
+		logging.ForInteraction(s, i).Error("dashboard search: respond failed", "error", err)
 	}
 }
 
@@ -155,7 +175,7 @@ func sendFollowupEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate,
 		Flags:      discordgo.MessageFlagsIsComponentsV2,
 	})
 	if err != nil {
-		log.Printf("dashboard search: followup failed: %v", err)
+		logging.ForInteraction(s, i).Error("dashboard search: followup failed", "error", err)
 	}
 }
 
@@ -171,7 +191,7 @@ func sendFollowupSearchResults(s *discordgo.Session, i *discordgo.InteractionCre
 		Flags:      discordgo.MessageFlagsIsComponentsV2,
 	})
 	if err != nil {
-		log.Printf("dashboard search: followup results failed: %v", err)
+		logging.ForInteraction(s, i).Error("dashboard search: followup results failed", "error", err)
 	}
 }
 
@@ -192,14 +212,14 @@ func handleDashboardSearchModalSubmit(s *discordgo.Session, i *discordgo.Interac
 			Flags: discordgo.MessageFlagsIsComponentsV2,
 		},
 	}); err != nil {
-		log.Printf("dashboard search: defer failed: %v", err)
+		logging.ForInteraction(s, i).Error("dashboard search: defer failed", "error", err)
 		return
 	}
 
 	data := i.ModalSubmitData()
 	query := strings.TrimSpace(getModalInputValue(data, dashboardSearchInputID))
 	if query == "" {
-		log.Printf("dashboard search: empty input, modal components: %s", formatModalComponents(data))
+		logging.ForInteraction(s, i).Warn("dashboard search: empty input", "components", formatModalComponents(data))
 		sendFollowupEphemeral(s, i, "입력값이 비어 있습니다.")
 		return
 	}
@@ -207,7 +227,7 @@ func handleDashboardSearchModalSubmit(s *discordgo.Session, i *discordgo.Interac
 	provider := strings.TrimSpace(getModalSelectValue(data, dashboardSearchProviderInputID))
 	sourceHint := parseProviderHint(provider)
 	if provider != "" && strings.ToLower(provider) != "auto" && sourceHint == music.TrackSourceUnknown {
-		sendFollowupEphemeral(s, i, "지원하지 않는 플랫폼입니다. 자동 / youtube / spotify / soundcloud 중에서 선택해 주세요.")
+		sendFollowupEphemeral(s, i, "지원하지 않는 플랫폼입니다. 목록에서 플랫폼을 선택해 주세요.")
 		return
 	}
 	if sourceHint == music.TrackSourceUnknown {
@@ -217,16 +237,13 @@ func handleDashboardSearchModalSubmit(s *discordgo.Session, i *discordgo.Interac
 	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Second)
 	defer cancel()
 
-	var spotifyClient *music.SpotifyClient
-	spotifyID := strings.TrimSpace(os.Getenv("SPOTIFY_CLIENT_ID"))
-	spotifySecret := strings.TrimSpace(os.Getenv("SPOTIFY_CLIENT_SECRET"))
-	if spotifyID != "" && spotifySecret != "" {
-		spotifyClient = music.NewSpotifyClient(spotifyID, spotifySecret)
-	}
-
-	results, err := music.SearchTracks(ctx, query, sourceHint, musicsearch.MaxResults, music.NewYTDLPResolver(), spotifyClient)
+	results, err := music.SearchTracks(ctx, query, sourceHint, musicsearch.MaxResults, music.DefaultPlayerManager.Providers())
 	if err != nil {
-		log.Printf("dashboard search: search failed: %v", err)
+		logging.ForInteraction(s, i).Error("dashboard search: search failed", "error", err)
+		if errors.Is(err, music.ErrSearchUnsupported) {
+			sendFollowupEphemeral(s, i, "이 플랫폼은 검색을 지원하지 않습니다. URL을 입력해 주세요.")
+			return
+		}
 		sendFollowupEphemeral(s, i, "검색에 실패했습니다.")
 		return
 	}
@@ -3,8 +3,8 @@ package dashboard
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
 	"strconv"
 	"strings"
 	"sync"
@@ -12,6 +12,8 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/hxnx/tunebot/internal/database"
+	"github.com/hxnx/tunebot/internal/logging"
+	"github.com/hxnx/tunebot/internal/metrics"
 	"github.com/hxnx/tunebot/internal/music"
 	internalredis "github.com/hxnx/tunebot/internal/redis"
 	redislib "github.com/redis/go-redis/v9"
@@ -19,6 +21,8 @@ import (
 
 const DefaultDashboardChannelName = "🎵-tunebot"
 
+var ErrDashboardNotFound = errors.New("dashboard message not found")
+
 type DashboardEntry struct {
 	ChannelID string
 	MessageID string
@@ -31,25 +35,6 @@ var dashboardState = struct {
 	byGuild: make(map[string]DashboardEntry),
 }
 
-var nowPlayingUpdater = struct {
-	mu     sync.Mutex
-	cancel map[string]context.CancelFunc
-}{
-	cancel: make(map[string]context.CancelFunc),
-}
-
-type dashboardRenderState struct {
-	lastHash    string
-	lastUpdated time.Time
-}
-
-var dashboardRenderCache = struct {
-	mu      sync.Mutex
-	byGuild map[string]dashboardRenderState
-}{
-	byGuild: make(map[string]dashboardRenderState),
-}
-
 type dashboardStoreCacheEntry struct {
 	settings        music.QueueSettings
 	queueCount      int64
@@ -67,16 +52,22 @@ var dashboardStoreCache = struct {
 }
 
 const (
-	dashboardUpdateTickerInterval = 8 * time.Second
-	dashboardAutoUpdateBucket     = 8 * time.Second
-	dashboardMinUpdateInterval    = 5 * time.Second
-	dashboardSettingsCacheTTL     = 30 * time.Second
-	dashboardQueueCacheTTL        = 15 * time.Second
-	dashboardSettingsCacheKey     = "dashboard:cache:settings:"
-	dashboardQueueCacheKey        = "dashboard:cache:queue:"
+	dashboardSettingsCacheTTL = 30 * time.Second
+	dashboardQueueCacheTTL    = 15 * time.Second
+	dashboardSettingsCacheKey = "dashboard:cache:settings:"
+	dashboardQueueCacheKey    = "dashboard:cache:queue:"
+	dashboardEntryKey         = "dashboard:entry:"
 )
 
 func GetDashboardEntry(guildID string) (DashboardEntry, bool) {
+	if getRedisClient() != nil {
+		entry, ok, err := getRedisDashboardEntry(guildID)
+		if err == nil {
+			return entry, ok
+		}
+		logging.ForGuild(nil, guildID).Warn("dashboard entry redis read failed, using local state", "error", err)
+	}
+
 	dashboardState.mu.RLock()
 	defer dashboardState.mu.RUnlock()
 	entry, ok := dashboardState.byGuild[guildID]
@@ -87,15 +78,16 @@ func SetDashboardEntry(guildID string, entry DashboardEntry) {
 	dashboardState.mu.Lock()
 	dashboardState.byGuild[guildID] = entry
 	dashboardState.mu.Unlock()
+	setRedisDashboardEntry(guildID, entry)
 }
 
 func ClearDashboardEntry(guildID string) {
-	stopDashboardAutoUpdater(guildID)
-	clearDashboardRenderState(guildID)
+	cancelDashboardUpdate(guildID)
 	clearDashboardStoreCache(guildID)
 	dashboardState.mu.Lock()
 	delete(dashboardState.byGuild, guildID)
 	dashboardState.mu.Unlock()
+	clearRedisDashboardEntry(guildID)
 }
 
 func DeletePreviousDashboard(s *discordgo.Session, guildID string) error {
@@ -176,8 +168,8 @@ func buildDashboardSnapshot(guildID string) dashboardSnapshot {
 			title = "알 수 없는 제목"
 		}
 		safeTitle := escapeDashboardText(title)
-		if state.Track.URL != "" {
-			snapshot.NowPlayingTitle = fmt.Sprintf("🎧 **[%s](%s)**", safeTitle, state.Track.URL)
+		if link := state.Track.LinkURL(); link != "" {
+			snapshot.NowPlayingTitle = fmt.Sprintf("🎧 **[%s](%s)**", safeTitle, link)
 		} else {
 			snapshot.NowPlayingTitle = fmt.Sprintf("🎧 **%s**", safeTitle)
 		}
@@ -198,6 +190,24 @@ func buildDashboardSnapshot(guildID string) dashboardSnapshot {
 			label = "스포티파이"
 		case "soundcloud":
 			label = "사운드클라우드"
+		case "bandcamp":
+			label = "밴드캠프"
+		case "vimeo":
+			label = "비메오"
+		case "twitch":
+			label = "트위치"
+			if state.Track.IsLive {
+				label = "트위치 생방송"
+			}
+		case "mixcloud":
+			label = "믹스클라우드"
+		case "local":
+			label = "로컬 라이브러리"
+		case "http":
+			label = "직접 링크"
+			if state.Track.IsLive {
+				label = "인터넷 라디오"
+			}
 		default:
 			if sourceKey != "" {
 				label = strings.ToUpper(sourceKey)
@@ -211,8 +221,19 @@ func buildDashboardSnapshot(guildID string) dashboardSnapshot {
 			snapshot.NowPlayingRequester = fmt.Sprintf("요청자: <@%s>", requester)
 		}
 
-		progressBar := buildProgressBar(state.Position, state.Track.Duration, 12)
-		snapshot.NowPlayingProgress = fmt.Sprintf("`%s` `%s` `%s`", formatDuration(state.Position), progressBar, formatDuration(state.Track.Duration))
+		if state.Track.IsLive || state.Track.Duration <= 0 {
+			snapshot.NowPlayingProgress = "`🔴 실시간`"
+		} else {
+			if state.PausedAt != nil {
+				snapshot.NowPlayingProgress = fmt.Sprintf("`⏸ %s / %s`", formatDuration(state.Position), formatDuration(state.Track.Duration))
+			} else {
+				snapshot.NowPlayingProgress = fmt.Sprintf("`%s`", formatDuration(state.Track.Duration))
+				if state.Position < state.Track.Duration {
+					endsAt := time.Now().Add(state.Track.Duration - state.Position)
+					snapshot.NowPlayingProgress += fmt.Sprintf(" · <t:%d:R> 종료", endsAt.Unix())
+				}
+			}
+		}
 
 		snapshot.NowPlayingThumb = strings.TrimSpace(state.Track.Thumbnail)
 	}
@@ -328,22 +349,6 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%02d:%02d", min, sec)
 }
 
-func buildProgressBar(position, duration time.Duration, size int) string {
-	if size <= 0 {
-		size = 12
-	}
-	if duration <= 0 {
-		return "○" + strings.Repeat("─", size)
-	}
-	ratio := float64(position) / float64(duration)
-	ratio = max(0.0, min(1.0, ratio))
-	marker := int(ratio * float64(size))
-	marker = min(size, max(0, marker))
-	left := strings.Repeat("━", marker)
-	right := strings.Repeat("─", size-marker)
-	return fmt.Sprintf("%s◉%s", left, right)
-}
-
 func escapeDashboardText(text string) string {
 	replacer := strings.NewReplacer(
 		"*", "\\*",
@@ -356,60 +361,6 @@ func escapeDashboardText(text string) string {
 	return replacer.Replace(text)
 }
 
-func buildPlaybackHash(guildID string, state music.PlaybackState) string {
-	if !state.IsPlaying || state.Track == nil {
-		return "stopped"
-	}
-	bucket := int(state.Position / dashboardAutoUpdateBucket)
-	paused := state.PausedAt != nil
-
-	if loopLabel, queueCount, ok := peekDashboardStoreSummary(guildID); ok {
-		return fmt.Sprintf("playing:%s:%d:paused=%t:loop=%s:queue=%d", state.Track.ID, bucket, paused, loopLabel, queueCount)
-	}
-
-	return fmt.Sprintf("playing:%s:%d:%t", state.Track.ID, bucket, paused)
-}
-
-func shouldAutoUpdateDashboard(guildID string, state music.PlaybackState) bool {
-	if guildID == "" {
-		return false
-	}
-	hash := buildPlaybackHash(guildID, state)
-
-	dashboardRenderCache.mu.Lock()
-	prev, ok := dashboardRenderCache.byGuild[guildID]
-	dashboardRenderCache.mu.Unlock()
-
-	if ok && prev.lastHash == hash && time.Since(prev.lastUpdated) < dashboardMinUpdateInterval {
-		return false
-	}
-
-	return !ok || prev.lastHash != hash
-}
-
-func recordDashboardRenderState(guildID string, state music.PlaybackState) {
-	if guildID == "" {
-		return
-	}
-	hash := buildPlaybackHash(guildID, state)
-
-	dashboardRenderCache.mu.Lock()
-	dashboardRenderCache.byGuild[guildID] = dashboardRenderState{
-		lastHash:    hash,
-		lastUpdated: time.Now(),
-	}
-	dashboardRenderCache.mu.Unlock()
-}
-
-func clearDashboardRenderState(guildID string) {
-	if guildID == "" {
-		return
-	}
-	dashboardRenderCache.mu.Lock()
-	delete(dashboardRenderCache.byGuild, guildID)
-	dashboardRenderCache.mu.Unlock()
-}
-
 func getRedisClient() *redislib.Client {
 	return internalredis.Client()
 }
@@ -499,6 +450,71 @@ func setRedisQueueCountCache(guildID string, count int64) {
 	_ = client.Set(ctx, dashboardQueueCacheKey+guildID, strconv.FormatInt(count, 10), dashboardQueueCacheTTL).Err()
 }
 
+func getRedisDashboardEntry(guildID string) (DashboardEntry, bool, error) {
+	if guildID == "" {
+		return DashboardEntry{}, false, nil
+	}
+	client := getRedisClient()
+	if client == nil {
+		return DashboardEntry{}, false, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	values, err := client.HGetAll(ctx, dashboardEntryKey+guildID).Result()
+	if err != nil {
+		return DashboardEntry{}, false, err
+	}
+	if values["channel_id"] == "" || values["message_id"] == "" {
+		return DashboardEntry{}, false, nil
+	}
+
+	return DashboardEntry{ChannelID: values["channel_id"], MessageID: values["message_id"]}, true, nil
+}
+
+func setRedisDashboardEntry(guildID string, entry DashboardEntry) {
+	if guildID == "" {
+		return
+	}
+	client := getRedisClient()
+	if client == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	_ = client.HSet(ctx, dashboardEntryKey+guildID, "channel_id", entry.ChannelID, "message_id", entry.MessageID).Err()
+}
+
+func clearRedisDashboardEntry(guildID string) {
+	if guildID == "" {
+		return
+	}
+	client := getRedisClient()
+	if client == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	_ = client.Del(ctx, dashboardEntryKey+guildID).Err()
+}
+
+func clearRedisQueueCountCache(guildID string) {
+	client := getRedisClient()
+	if client == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	_ = client.Del(ctx, dashboardQueueCacheKey+guildID).Err()
+}
+
 func clearRedisDashboardCache(guildID string) {
 	if guildID == "" {
 		return
@@ -620,52 +636,6 @@ func clearDashboardStoreCache(guildID string) {
 	dashboardStoreCache.mu.Unlock()
 }
 
-func peekDashboardStoreSummary(guildID string) (string, int64, bool) {
-	if guildID == "" {
-		return "", 0, false
-	}
-
-	settings, hasSettings := getRedisSettingsCache(guildID)
-	queueCount, hasQueueCount := getRedisQueueCountCache(guildID)
-
-	if !hasSettings || !hasQueueCount {
-		dashboardStoreCache.mu.Lock()
-		entry := dashboardStoreCache.byGuild[guildID]
-		dashboardStoreCache.mu.Unlock()
-
-		now := time.Now()
-		if !hasSettings && entry.hasSettings && now.Before(entry.settingsExpires) {
-			settings = entry.settings
-			hasSettings = true
-		}
-		if !hasQueueCount && entry.hasQueueCount && now.Before(entry.queueExpires) {
-			queueCount = entry.queueCount
-			hasQueueCount = true
-		}
-	}
-
-	loopLabel := ""
-	ok := false
-
-	if hasSettings {
-		switch settings.RepeatMode {
-		case music.RepeatModeTrack:
-			loopLabel = "곡 반복"
-		case music.RepeatModeQueue:
-			loopLabel = "대기열 반복"
-		default:
-			loopLabel = "꺼짐"
-		}
-		ok = true
-	}
-
-	if hasQueueCount {
-		ok = true
-	}
-
-	return loopLabel, queueCount, ok
-}
-
 func UpdateDashboardSettingsCache(guildID string, settings music.QueueSettings) {
 	if guildID == "" {
 		return
@@ -707,7 +677,7 @@ func RespondUpdateDashboardMessage(s *discordgo.Session, i *discordgo.Interactio
 		},
 	})
 	if err != nil {
-		log.Printf("failed to update dashboard message: %v", err)
+		logging.ForInteraction(s, i).Error("failed to update dashboard message", "error", err)
 	}
 }
 
@@ -724,90 +694,29 @@ func UpdateDashboardByGuild(s *discordgo.Session, guildID string) error {
 			return fmt.Errorf("failed to load dashboard entry: %w", err)
 		}
 		if !repoOK || channelID == "" || messageID == "" {
-			return fmt.Errorf("dashboard message not found")
+			return ErrDashboardNotFound
 		}
 		entry = DashboardEntry{ChannelID: channelID, MessageID: messageID}
 		SetDashboardEntry(guildID, entry)
 	}
 
-	state := music.DefaultPlayerManager.Get(guildID).State()
-	if state.IsPlaying && state.Track != nil {
-		startDashboardAutoUpdater(s, guildID)
-	} else {
-		stopDashboardAutoUpdater(guildID)
-	}
-
 	components := BuildDashboardComponents(guildID)
 
+	trackDashboardEdit(entry.MessageID, true)
+	defer trackDashboardEdit(entry.MessageID, false)
+
 	_, err := s.ChannelMessageEditComplex(&discordgo.MessageEdit{
 		ID:         entry.MessageID,
 		Channel:    entry.ChannelID,
 		Components: &components,
 		Flags:      discordgo.MessageFlagsIsComponentsV2,
 	})
-	if err == nil {
-		recordDashboardRenderState(guildID, state)
+	if isRateLimitError(err) {
+		metrics.DashboardRateLimits.Inc()
 	}
 	return err
 }
 
-func startDashboardAutoUpdater(s *discordgo.Session, guildID string) {
-	if s == nil || guildID == "" {
-		return
-	}
-
-	nowPlayingUpdater.mu.Lock()
-	if _, exists := nowPlayingUpdater.cancel[guildID]; exists {
-		nowPlayingUpdater.mu.Unlock()
-		return
-	}
-	ctx, cancel := context.WithCancel(context.Background())
-	nowPlayingUpdater.cancel[guildID] = cancel
-	nowPlayingUpdater.mu.Unlock()
-
-	go func() {
-		ticker := time.NewTicker(dashboardUpdateTickerInterval)
-		defer ticker.Stop()
-
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-ticker.C:
-				state := music.DefaultPlayerManager.Get(guildID).State()
-				if state.IsPlaying && state.Track != nil {
-					if shouldAutoUpdateDashboard(guildID, state) {
-						if err := UpdateDashboardByGuild(s, guildID); err != nil {
-							log.Printf("dashboard auto-update failed: %v", err)
-						}
-					}
-					continue
-				}
-				stopDashboardAutoUpdater(guildID)
-				_ = UpdateDashboardByGuild(s, guildID)
-				return
-			}
-		}
-	}()
-}
-
-func stopDashboardAutoUpdater(guildID string) {
-	if guildID == "" {
-		return
-	}
-
-	nowPlayingUpdater.mu.Lock()
-	cancel, ok := nowPlayingUpdater.cancel[guildID]
-	if ok {
-		delete(nowPlayingUpdater.cancel, guildID)
-	}
-	nowPlayingUpdater.mu.Unlock()
-
-	if ok && cancel != nil {
-		cancel()
-	}
-}
-
 func RespondEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, content string) {
 	if s == nil || i == nil {
 		return
@@ -836,6 +745,6 @@ func RespondEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, cont
 		},
 	})
 	if err != nil {
-		log.Printf("failed to respond: %v", err)
+		logging.ForInteraction(s, i).Error("failed to respond", "error", err)
 	}
 }
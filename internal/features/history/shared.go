@@ -0,0 +1,82 @@
+package history
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hxnx/tunebot/internal/database"
+	"github.com/hxnx/tunebot/internal/logging"
+	"github.com/hxnx/tunebot/internal/music"
+)
+
+var startedAt = struct {
+	mu      sync.Mutex
+	byGuild map[string]time.Time
+}{
+	byGuild: make(map[string]time.Time),
+}
+
+var registerOnce sync.Once
+
+var enabled atomic.Bool
+
+func init() {
+	enabled.Store(true)
+}
+
+func SetEnabled(value bool) {
+	enabled.Store(value)
+}
+
+func Register(manager *music.PlayerManager) {
+	if manager == nil {
+		return
+	}
+	registerOnce.Do(func() {
+		manager.Subscribe(handlePlayerEvent)
+	})
+}
+
+func handlePlayerEvent(event music.PlayerEvent) {
+	if !enabled.Load() || event.GuildID == "" {
+		return
+	}
+
+	switch event.Type {
+	case music.PlayerEventTrackStarted:
+		startedAt.mu.Lock()
+		startedAt.byGuild[event.GuildID] = event.At
+		startedAt.mu.Unlock()
+	case music.PlayerEventTrackEnded:
+		startedAt.mu.Lock()
+		started, ok := startedAt.byGuild[event.GuildID]
+		delete(startedAt.byGuild, event.GuildID)
+		startedAt.mu.Unlock()
+		if !ok {
+			started = event.At
+		}
+
+		track := event.Item.Track
+		played := event.At.Sub(started)
+		if track.Duration > 0 && played > track.Duration {
+			played = track.Duration
+		}
+
+		entry := database.PlayHistoryEntry{
+			GuildID:    event.GuildID,
+			UserID:     track.RequestedBy,
+			Title:      track.Title,
+			URL:        track.URL,
+			Source:     string(track.Source),
+			DurationMS: track.Duration.Milliseconds(),
+			PlayedMS:   played.Milliseconds(),
+			EndReason:  string(event.Reason),
+			StartedAt:  started,
+			EndedAt:    event.At,
+		}
+		if err := database.NewHistoryRepository().Record(entry); err != nil {
+			logging.ForGuild(event.Session, event.GuildID).Warn("failed to record play history", "error", err)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	shared "github.com/hxnx/tunebot/internal/features/shared"
+	"github.com/hxnx/tunebot/internal/logging"
+	"github.com/hxnx/tunebot/internal/music"
+)
+
+const libraryScanTimeout = 30 * time.Minute
+
+var accentColor = 0xC9A0FF
+
+func Refresh(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	provider, ok := music.DefaultPlayerManager.Providers().Get(music.TrackSourceLocal)
+	library, isLocal := provider.(*music.LocalProvider)
+	if !ok || !isLocal {
+		shared.RespondEphemeral(s, i, "로컬 라이브러리가 설정되어 있지 않습니다. `LOCAL_LIBRARY_DIR`을 확인해 주세요.")
+		return
+	}
+
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	}); err != nil {
+		logging.ForInteraction(s, i).Warn("library refresh defer failed", "error", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), libraryScanTimeout)
+	defer cancel()
+
+	result, err := library.Scan(ctx)
+	if err != nil {
+		logging.ForInteraction(s, i).Error("library scan failed", "error", err)
+		switch {
+		case errors.Is(err, music.ErrLocalScanInProgress):
+			sendFollowup(s, i, "이미 라이브러리를 갱신하는 중입니다.")
+		case errors.Is(err, music.ErrLocalLibraryUnavailable):
+			sendFollowup(s, i, "로컬 라이브러리를 사용할 수 없습니다. 데이터베이스 연결을 확인해 주세요.")
+		default:
+			sendFollowup(s, i, "라이브러리 갱신에 실패했습니다.")
+		}
+		return
+	}
+
+	content := fmt.Sprintf("라이브러리를 갱신했습니다.\n파일 %d개 확인 · %d개 갱신 · %d개 삭제", result.Scanned, result.Updated, result.Removed)
+	if result.Failed > 0 {
+		content += fmt.Sprintf("\n읽지 못한 파일 %d개", result.Failed)
+	}
+	sendFollowup(s, i, content)
+}
+
+func sendFollowup(s *discordgo.Session, i *discordgo.InteractionCreate, content string) {
+	divider := true
+	spacing := discordgo.SeparatorSpacingSizeSmall
+
+	components := []discordgo.MessageComponent{
+		discordgo.Container{
+			AccentColor: &accentColor,
+			Components: []discordgo.MessageComponent{
+				discordgo.TextDisplay{Content: "로컬 라이브러리"},
+				discordgo.Separator{Divider: &divider, Spacing: &spacing},
+				discordgo.TextDisplay{Content: content},
+			},
+		},
+	}
+
+	_, err := s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Components: components,
+		Flags:      discordgo.MessageFlagsEphemeral | discordgo.MessageFlagsIsComponentsV2,
+	})
+	if err != nil {
+		logging.ForInteraction(s, i).Warn("library followup failed", "error", err)
+	}
+}
@@ -0,0 +1,189 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	musicsearch "github.com/hxnx/tunebot/internal/features/music/search"
+	shared "github.com/hxnx/tunebot/internal/features/shared"
+	"github.com/hxnx/tunebot/internal/logging"
+	"github.com/hxnx/tunebot/internal/music"
+)
+
+const (
+	autocompleteMinQueryLength = 2
+	autocompleteDebounce       = 350 * time.Millisecond
+	autocompleteWait           = 2 * time.Second
+	autocompleteSearchTimeout  = 20 * time.Second
+	autocompleteChoiceMaxLen   = 100
+)
+
+var autocompleteState = struct {
+	mu       sync.Mutex
+	latest   map[string]uint64
+	seq      uint64
+	inflight map[string]chan struct{}
+}{
+	latest:   make(map[string]uint64),
+	inflight: make(map[string]chan struct{}),
+}
+
+func PlayAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	query := strings.TrimSpace(shared.GetOptionString(options, "query"))
+	if len([]rune(query)) < autocompleteMinQueryLength {
+		respondAutocomplete(s, i, nil)
+		return
+	}
+
+	if looksLikeURL(query) {
+		respondAutocomplete(s, i, []*discordgo.ApplicationCommandOptionChoice{queryChoice(query)})
+		return
+	}
+
+	sourceHint := detectSourceHint(query)
+	if results, ok := music.CachedSearchResults(query, sourceHint, musicsearch.MaxResults); ok {
+		respondAutocomplete(s, i, buildAutocompleteChoices(query, results))
+		return
+	}
+
+	key := i.GuildID + ":" + shared.GetInteractionUserID(i)
+	seq := markAutocompleteRequest(key)
+	time.Sleep(autocompleteDebounce)
+	if !isLatestAutocompleteRequest(key, seq) {
+		respondAutocomplete(s, i, []*discordgo.ApplicationCommandOptionChoice{queryChoice(query)})
+		return
+	}
+
+	done := startAutocompleteSearch(query, sourceHint)
+	select {
+	case <-done:
+	case <-time.After(autocompleteWait):
+	}
+
+	results, _ := music.CachedSearchResults(query, sourceHint, musicsearch.MaxResults)
+	respondAutocomplete(s, i, buildAutocompleteChoices(query, results))
+}
+
+func markAutocompleteRequest(key string) uint64 {
+	autocompleteState.mu.Lock()
+	defer autocompleteState.mu.Unlock()
+
+	autocompleteState.seq++
+	autocompleteState.latest[key] = autocompleteState.seq
+	return autocompleteState.seq
+}
+
+func isLatestAutocompleteRequest(key string, seq uint64) bool {
+	autocompleteState.mu.Lock()
+	defer autocompleteState.mu.Unlock()
+
+	if autocompleteState.latest[key] != seq {
+		return false
+	}
+	delete(autocompleteState.latest, key)
+	return true
+}
+
+func startAutocompleteSearch(query string, sourceHint music.TrackSource) <-chan struct{} {
+	key := string(sourceHint) + ":" + strings.ToLower(query)
+
+	autocompleteState.mu.Lock()
+	if done, ok := autocompleteState.inflight[key]; ok {
+		autocompleteState.mu.Unlock()
+		return done
+	}
+	done := make(chan struct{})
+	autocompleteState.inflight[key] = done
+	autocompleteState.mu.Unlock()
+
+	go func() {
+		defer func() {
+			autocompleteState.mu.Lock()
+			delete(autocompleteState.inflight, key)
+			autocompleteState.mu.Unlock()
+			close(done)
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), autocompleteSearchTimeout)
+		defer cancel()
+
+		if _, err := music.SearchTracks(ctx, query, sourceHint, musicsearch.MaxResults, music.DefaultPlayerManager.Providers()); err != nil {
+			slog.Debug("play autocomplete: search failed", "query", query, "source", sourceHint, "error", err)
+		}
+	}()
+
+	return done
+}
+
+func buildAutocompleteChoices(query string, results []music.Track) []*discordgo.ApplicationCommandOptionChoice {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(results)+1)
+	for _, track := range results {
+		if track.URL == "" || len(track.URL) > autocompleteChoiceMaxLen {
+			continue
+		}
+		name := fmt.Sprintf("%s (%s)", track.Title, formatAutocompleteDuration(track.Duration))
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  truncateRunes(name, autocompleteChoiceMaxLen),
+			Value: track.URL,
+		})
+	}
+	if len(choices) == 0 {
+		choices = append(choices, queryChoice(query))
+	}
+	return choices
+}
+
+func queryChoice(query string) *discordgo.ApplicationCommandOptionChoice {
+	return &discordgo.ApplicationCommandOptionChoice{
+		Name:  truncateRunes("🔎 "+query, autocompleteChoiceMaxLen),
+		Value: clipRunes(query, autocompleteChoiceMaxLen),
+	}
+}
+
+func respondAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate, choices []*discordgo.ApplicationCommandOptionChoice) {
+	if choices == nil {
+		choices = []*discordgo.ApplicationCommandOptionChoice{}
+	}
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	}); err != nil {
+		logging.ForInteraction(s, i).Error("play autocomplete respond failed", "error", err)
+	}
+}
+
+func formatAutocompleteDuration(d time.Duration) string {
+	if d <= 0 {
+		return "실시간"
+	}
+	total := int(d.Seconds())
+	return fmt.Sprintf("%d:%02d", total/60, total%60)
+}
+
+func truncateRunes(value string, limit int) string {
+	runes := []rune(value)
+	if len(runes) <= limit {
+		return value
+	}
+	return string(runes[:limit-1]) + "…"
+}
+
+func clipRunes(value string, limit int) string {
+	runes := []rune(value)
+	if len(runes) <= limit {
+		return value
+	}
+	return string(runes[:limit])
+}
+
+func looksLikeURL(value string) bool {
+	lower := strings.ToLower(value)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "spotify:")
+}
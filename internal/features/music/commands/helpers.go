@@ -8,17 +8,7 @@ import (
 )
 
 func detectSourceHint(input string) music.TrackSource {
-	lower := strings.ToLower(input)
-	switch {
-	case strings.Contains(lower, "spotify.com") || strings.HasPrefix(lower, "spotify:track:"):
-		return music.TrackSourceSpotify
-	case strings.Contains(lower, "soundcloud.com"):
-		return music.TrackSourceSoundCloud
-	case strings.Contains(lower, "youtube.com") || strings.Contains(lower, "youtu.be"):
-		return music.TrackSourceYouTube
-	default:
-		return music.TrackSourceUnknown
-	}
+	return music.DefaultPlayerManager.Providers().Detect(strings.TrimSpace(input))
 }
 
 func getModalInputValue(data discordgo.ModalSubmitInteractionData, customID string) string {
@@ -107,6 +97,16 @@ func parseProviderHint(provider string) music.TrackSource {
 		return music.TrackSourceSpotify
 	case "soundcloud", "sc":
 		return music.TrackSourceSoundCloud
+	case "bandcamp", "bc":
+		return music.TrackSourceBandcamp
+	case "vimeo":
+		return music.TrackSourceVimeo
+	case "twitch":
+		return music.TrackSourceTwitch
+	case "mixcloud", "mc":
+		return music.TrackSourceMixcloud
+	case "local", "library":
+		return music.TrackSourceLocal
 	default:
 		return music.TrackSourceUnknown
 	}
@@ -3,15 +3,16 @@ package commands
 import (
 	"context"
 	"errors"
-	"log"
-	"os"
+	"fmt"
 	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/cluster"
 	"github.com/hxnx/tunebot/internal/features/modals"
 	musicsearch "github.com/hxnx/tunebot/internal/features/music/search"
 	shared "github.com/hxnx/tunebot/internal/features/shared"
+	"github.com/hxnx/tunebot/internal/logging"
 	"github.com/hxnx/tunebot/internal/music"
 )
 
@@ -21,7 +22,7 @@ const (
 	playSearchProviderInputID = "play_search_provider_input"
 )
 
-func Play(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func Play(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
 	if i.Type != discordgo.InteractionApplicationCommand {
 		return
 	}
@@ -36,6 +37,11 @@ func Play(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		return
 	}
 
+	if query := strings.TrimSpace(shared.GetOptionString(options, "query")); query != "" {
+		playQuery(s, i, userID, query)
+		return
+	}
+
 	modal := &discordgo.InteractionResponseData{
 		CustomID: playSearchModalID,
 		Title:    "노래 검색",
@@ -52,7 +58,7 @@ func Play(s *discordgo.Session, i *discordgo.InteractionCreate) {
 				},
 			},
 			discordgo.Label{
-				Label:       "플랫폼 (자동/유튜브/스포티파이/사운드클라우드)",
+				Label:       "플랫폼",
 				Description: "자동 선택 또는 직접 선택하세요",
 				Component: discordgo.SelectMenu{
 					MenuType:    discordgo.StringSelectMenu,
@@ -76,6 +82,26 @@ func Play(s *discordgo.Session, i *discordgo.InteractionCreate) {
 							Label: "사운드클라우드",
 							Value: "soundcloud",
 						},
+						{
+							Label: "밴드캠프 (URL)",
+							Value: "bandcamp",
+						},
+						{
+							Label: "비메오 (URL)",
+							Value: "vimeo",
+						},
+						{
+							Label: "트위치 (URL)",
+							Value: "twitch",
+						},
+						{
+							Label: "믹스클라우드 (URL)",
+							Value: "mixcloud",
+						},
+						{
+							Label: "로컬 라이브러리",
+							Value: "local",
+						},
 					},
 				},
 			},
@@ -84,12 +110,12 @@ func Play(s *discordgo.Session, i *discordgo.InteractionCreate) {
 
 	response, err := modals.DefaultAwaiter.ShowAndAwaitModal(s, i, modal, 60*time.Second)
 	if err != nil {
-		log.Printf("play modal failed: %v", err)
+		logging.ForInteraction(s, i).Error("play modal failed", "error", err)
 		return
 	}
 
 	if err := deferEphemeral(response.Interaction, s); err != nil {
-		log.Printf("play modal defer failed: %v", err)
+		logging.ForInteraction(s, i).Error("play modal defer failed", "error", err)
 		return
 	}
 
@@ -102,7 +128,7 @@ func Play(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	provider := strings.TrimSpace(getModalSelectValue(response.Data, playSearchProviderInputID))
 	sourceHint := parseProviderHint(provider)
 	if provider != "" && strings.ToLower(provider) != "auto" && sourceHint == music.TrackSourceUnknown {
-		sendFollowupEphemeral(s, response.Interaction, "지원하지 않는 플랫폼입니다. 자동/유튜브/스포티파이/사운드클라우드 중에서 선택해 주세요.")
+		sendFollowupEphemeral(s, response.Interaction, "지원하지 않는 플랫폼입니다. 목록에서 플랫폼을 선택해 주세요.")
 		return
 	}
 	if sourceHint == music.TrackSourceUnknown {
@@ -112,20 +138,15 @@ func Play(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Second)
 	defer cancel()
 
-	var spotifyClient *music.SpotifyClient
-	spotifyID := strings.TrimSpace(os.Getenv("SPOTIFY_CLIENT_ID"))
-	spotifySecret := strings.TrimSpace(os.Getenv("SPOTIFY_CLIENT_SECRET"))
-	if spotifyID != "" && spotifySecret != "" {
-		spotifyClient = music.NewSpotifyClient(spotifyID, spotifySecret)
-	}
-
-	results, err := music.SearchTracks(ctx, query, sourceHint, musicsearch.MaxResults, music.NewYTDLPResolver(), spotifyClient)
+	results, err := music.SearchTracks(ctx, query, sourceHint, musicsearch.MaxResults, music.DefaultPlayerManager.Providers())
 	if err != nil {
 		switch {
 		case errors.Is(err, music.ErrSpotifyClientNil):
 			sendFollowupEphemeral(s, response.Interaction, "Spotify 검색을 사용하려면 SPOTIFY_CLIENT_ID/SECRET 설정이 필요합니다.")
+		case errors.Is(err, music.ErrSearchUnsupported):
+			sendFollowupEphemeral(s, response.Interaction, "이 플랫폼은 검색을 지원하지 않습니다. URL을 입력해 주세요.")
 		default:
-			log.Printf("play search failed: %v", err)
+			logging.ForInteraction(s, i).Error("play search failed", "error", err)
 			sendFollowupEphemeral(s, response.Interaction, "검색에 실패했습니다.")
 		}
 		return
@@ -145,6 +166,38 @@ func Play(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	sendFollowupSearchResults(s, response.Interaction, query, results)
 }
 
+func playQuery(s *discordgo.Session, i *discordgo.InteractionCreate, userID string, query string) {
+	if err := deferEphemeral(i, s); err != nil {
+		logging.ForInteraction(s, i).Error("play query defer failed", "error", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	item, err := cluster.Enqueue(ctx, i.GuildID, userID, query, detectSourceHint(query))
+	if err != nil {
+		switch {
+		case errors.Is(err, music.ErrNoVoiceChannel):
+			sendFollowupEphemeral(s, i, "먼저 음성 채널에 입장해 주세요.")
+		case errors.Is(err, music.ErrSpotifyClientNil):
+			sendFollowupEphemeral(s, i, "Spotify 링크를 재생하려면 SPOTIFY_CLIENT_ID/SECRET 설정이 필요합니다.")
+		case errors.Is(err, music.ErrQueueFull):
+			sendFollowupEphemeral(s, i, "대기열이 가득 찼습니다.")
+		default:
+			logging.ForInteraction(s, i).Error("play query failed", "error", err)
+			sendFollowupEphemeral(s, i, "재생 요청에 실패했습니다.")
+		}
+		return
+	}
+
+	title := fmt.Sprintf("**%s**", item.Track.Title)
+	if link := item.Track.LinkURL(); link != "" {
+		title = fmt.Sprintf("[**%s**](%s)", item.Track.Title, link)
+	}
+	sendFollowupEphemeral(s, i, fmt.Sprintf("🎵 %s을(를) 대기열에 추가했습니다.", title))
+}
+
 func deferEphemeral(i *discordgo.InteractionCreate, s *discordgo.Session) error {
 	if s == nil || i == nil {
 		return nil
@@ -188,7 +241,7 @@ func sendFollowupEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate,
 		Flags:      discordgo.MessageFlagsEphemeral | discordgo.MessageFlagsIsComponentsV2,
 	})
 	if err != nil {
-		log.Printf("play followup failed: %v", err)
+		logging.ForInteraction(s, i).Error("play followup failed", "error", err)
 	}
 }
 
@@ -204,6 +257,6 @@ func sendFollowupSearchResults(s *discordgo.Session, i *discordgo.InteractionCre
 		Flags:      discordgo.MessageFlagsIsComponentsV2,
 	})
 	if err != nil {
-		log.Printf("play followup results failed: %v", err)
+		logging.ForInteraction(s, i).Error("play followup results failed", "error", err)
 	}
 }
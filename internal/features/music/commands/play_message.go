@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/cluster"
+	shared "github.com/hxnx/tunebot/internal/features/shared"
+	"github.com/hxnx/tunebot/internal/logging"
+	"github.com/hxnx/tunebot/internal/music"
+)
+
+const (
+	PlayMessageCommandName = "이 곡 재생"
+	playMessageMaxURLs     = 10
+)
+
+var messageURLPattern = regexp.MustCompile(`https?://[^\s<>|]+`)
+
+func PlayMessage(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type != discordgo.InteractionApplicationCommand {
+		return
+	}
+	if i.GuildID == "" {
+		shared.RespondEphemeral(s, i, "이 명령어는 서버에서만 사용할 수 있습니다.")
+		return
+	}
+
+	userID := shared.GetInteractionUserID(i)
+	if userID == "" {
+		shared.RespondEphemeral(s, i, "사용자 정보를 확인할 수 없습니다.")
+		return
+	}
+
+	data := i.ApplicationCommandData()
+	var message *discordgo.Message
+	if data.Resolved != nil {
+		message = data.Resolved.Messages[data.TargetID]
+	}
+	if message == nil {
+		shared.RespondEphemeral(s, i, "메시지를 불러올 수 없습니다.")
+		return
+	}
+
+	urls := extractSupportedURLs(message)
+	if len(urls) == 0 {
+		shared.RespondEphemeral(s, i, "이 메시지에서 재생할 수 있는 링크를 찾지 못했습니다.")
+		return
+	}
+
+	if err := deferEphemeral(i, s); err != nil {
+		logging.ForInteraction(s, i).Error("play message defer failed", "error", err)
+		return
+	}
+
+	var added, failed []string
+	for _, url := range urls {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		item, err := cluster.Enqueue(ctx, i.GuildID, userID, url, detectSourceHint(url))
+		cancel()
+		if err != nil {
+			if errors.Is(err, music.ErrNoVoiceChannel) {
+				sendFollowupEphemeral(s, i, "먼저 음성 채널에 입장해 주세요.")
+				return
+			}
+			if errors.Is(err, music.ErrQueueFull) {
+				failed = append(failed, fmt.Sprintf("⛔ <%s> (대기열이 가득 찼습니다.)", url))
+				continue
+			}
+			logging.ForInteraction(s, i).Error("play message: enqueue failed", "url", url, "error", err)
+			failed = append(failed, fmt.Sprintf("❌ <%s>", url))
+			continue
+		}
+
+		title := fmt.Sprintf("**%s**", item.Track.Title)
+		if link := item.Track.LinkURL(); link != "" {
+			title = fmt.Sprintf("[**%s**](%s)", item.Track.Title, link)
+		}
+		added = append(added, "✅ "+title)
+	}
+
+	lines := []string{fmt.Sprintf("추가 %d곡 · 실패 %d곡", len(added), len(failed))}
+	lines = append(lines, added...)
+	lines = append(lines, failed...)
+	sendFollowupEphemeral(s, i, strings.Join(lines, "\n"))
+}
+
+func extractSupportedURLs(message *discordgo.Message) []string {
+	candidates := messageURLPattern.FindAllString(message.Content, -1)
+	for _, embed := range message.Embeds {
+		if embed != nil && embed.URL != "" {
+			candidates = append(candidates, embed.URL)
+		}
+	}
+
+	seen := make(map[string]struct{}, len(candidates))
+	urls := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		url := strings.TrimRight(candidate, ".,!?)]}>'\"")
+		if _, ok := seen[url]; ok {
+			continue
+		}
+		seen[url] = struct{}{}
+
+		if detectSourceHint(url) == music.TrackSourceUnknown {
+			continue
+		}
+		urls = append(urls, url)
+		if len(urls) >= playMessageMaxURLs {
+			break
+		}
+	}
+	return urls
+}
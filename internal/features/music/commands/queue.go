@@ -3,12 +3,12 @@ package commands
 import (
 	"context"
 	"fmt"
-	"log"
 	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
 	shared "github.com/hxnx/tunebot/internal/features/shared"
+	"github.com/hxnx/tunebot/internal/logging"
 	"github.com/hxnx/tunebot/internal/music"
 )
 
@@ -40,7 +40,7 @@ func Queue(s *discordgo.Session, i *discordgo.InteractionCreate) {
 
 	items, err := store.List(ctx, i.GuildID, limit)
 	if err != nil {
-		log.Printf("queue error: %v", err)
+		logging.ForInteraction(s, i).Error("queue error", "error", err)
 		shared.RespondEphemeral(s, i, "대기열을 불러오지 못했습니다.")
 		return
 	}
@@ -52,7 +52,11 @@ func Queue(s *discordgo.Session, i *discordgo.InteractionCreate) {
 
 	lines := make([]string, 0, len(items))
 	for idx, item := range items {
-		lines = append(lines, fmt.Sprintf("%d. [%s](%s)", idx+1, item.Track.Title, item.Track.URL))
+		if link := item.Track.LinkURL(); link != "" {
+			lines = append(lines, fmt.Sprintf("%d. [%s](%s)", idx+1, item.Track.Title, link))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%d. %s", idx+1, item.Track.Title))
 	}
 
 	shared.RespondEphemeral(s, i, strings.Join(lines, "\n"))
@@ -1,9 +1,11 @@
 package commands
 
 import (
+	"context"
+
 	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/cluster"
 	shared "github.com/hxnx/tunebot/internal/features/shared"
-	"github.com/hxnx/tunebot/internal/music"
 )
 
 func Skip(s *discordgo.Session, i *discordgo.InteractionCreate) {
@@ -15,8 +17,7 @@ func Skip(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		return
 	}
 
-	player := music.DefaultPlayerManager.Get(i.GuildID)
-	if err := player.Skip(); err != nil {
+	if err := cluster.Skip(context.Background(), i.GuildID); err != nil {
 		shared.RespondEphemeral(s, i, "스킵할 곡이 없습니다.")
 		return
 	}
@@ -1,10 +1,11 @@
 package commands
 
 import (
+	"context"
+
 	"github.com/bwmarrin/discordgo"
-	dashboard "github.com/hxnx/tunebot/internal/features/dashboard"
+	"github.com/hxnx/tunebot/internal/cluster"
 	shared "github.com/hxnx/tunebot/internal/features/shared"
-	"github.com/hxnx/tunebot/internal/music"
 )
 
 func Stop(s *discordgo.Session, i *discordgo.InteractionCreate) {
@@ -16,12 +17,10 @@ func Stop(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		return
 	}
 
-	player := music.DefaultPlayerManager.Get(i.GuildID)
-	if err := player.Stop(true); err != nil {
+	if err := cluster.Stop(context.Background(), i.GuildID, true); err != nil {
 		shared.RespondEphemeral(s, i, "정지할 재생이 없습니다.")
 		return
 	}
 
-	_ = dashboard.UpdateDashboardByGuild(s, i.GuildID)
 	shared.RespondEphemeral(s, i, "재생을 정지하고 대기열을 비웠습니다.")
 }
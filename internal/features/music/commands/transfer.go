@@ -0,0 +1,262 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/cluster"
+	"github.com/hxnx/tunebot/internal/features/modals"
+	shared "github.com/hxnx/tunebot/internal/features/shared"
+	"github.com/hxnx/tunebot/internal/logging"
+	"github.com/hxnx/tunebot/internal/music"
+)
+
+const (
+	importModalID      = "queue_import_modal"
+	importTextInputID  = "queue_import_text"
+	importMaxFileSize  = 1024 * 1024
+	importTotalTimeout = 10 * time.Minute
+)
+
+var exportContentTypes = map[music.ExportFormat]string{
+	music.ExportFormatJSON: "application/json",
+	music.ExportFormatM3U8: "audio/x-mpegurl",
+	music.ExportFormatXSPF: "application/xspf+xml",
+}
+
+func Export(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	if i.GuildID == "" {
+		shared.RespondEphemeral(s, i, "이 명령어는 서버에서만 사용할 수 있습니다.")
+		return
+	}
+
+	format := music.ExportFormat(strings.TrimSpace(shared.GetOptionString(options, "형식")))
+	if format == "" {
+		format = music.ExportFormatJSON
+	}
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		shared.RespondEphemeral(s, i, "지원하지 않는 형식입니다.")
+		return
+	}
+
+	store := music.NewQueueStoreFromDefault()
+	if store == nil {
+		shared.RespondEphemeral(s, i, "대기열을 조회할 수 없습니다.")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	items, err := store.List(ctx, i.GuildID, 0)
+	if err != nil {
+		logging.ForInteraction(s, i).Error("queue export: list failed", "error", err)
+		shared.RespondEphemeral(s, i, "대기열을 불러오지 못했습니다.")
+		return
+	}
+
+	if current := music.DefaultPlayerManager.Get(i.GuildID).State().Track; current != nil {
+		items = append([]music.QueueItem{{Track: *current}}, items...)
+	}
+	if len(items) == 0 {
+		shared.RespondEphemeral(s, i, "내보낼 곡이 없습니다.")
+		return
+	}
+
+	settings, err := store.GetSettings(ctx, i.GuildID)
+	if err != nil {
+		logging.ForInteraction(s, i).Error("queue export: settings failed", "error", err)
+	}
+
+	data, err := music.NewQueueExport(items, settings).Encode(format)
+	if err != nil {
+		logging.ForInteraction(s, i).Error("queue export: encode failed", "error", err)
+		shared.RespondEphemeral(s, i, "대기열을 내보내지 못했습니다.")
+		return
+	}
+
+	filename := fmt.Sprintf("tunebot-queue-%s.%s", time.Now().UTC().Format("20060102-150405"), format)
+
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("📤 대기열 %d곡을 내보냈습니다. `/노래 가져오기`로 다른 서버에서 불러올 수 있습니다.", len(items)),
+			Files: []*discordgo.File{
+				{
+					Name:        filename,
+					ContentType: contentType,
+					Reader:      bytes.NewReader(data),
+				},
+			},
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	}); err != nil {
+		logging.ForInteraction(s, i).Error("queue export respond failed", "error", err)
+	}
+}
+
+func Import(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	if i.GuildID == "" {
+		shared.RespondEphemeral(s, i, "이 명령어는 서버에서만 사용할 수 있습니다.")
+		return
+	}
+
+	userID := shared.GetInteractionUserID(i)
+	if userID == "" {
+		shared.RespondEphemeral(s, i, "사용자 정보를 확인할 수 없습니다.")
+		return
+	}
+
+	applySettings := shared.GetOptionBool(options, "설정적용")
+
+	var attachment *discordgo.MessageAttachment
+	if id := shared.GetOptionString(options, "파일"); id != "" {
+		if resolved := i.ApplicationCommandData().Resolved; resolved != nil {
+			attachment = resolved.Attachments[id]
+		}
+	}
+
+	target := i
+	var raw []byte
+	if attachment != nil {
+		if attachment.Size > importMaxFileSize {
+			shared.RespondEphemeral(s, i, "파일이 너무 큽니다. (최대 1MB)")
+			return
+		}
+		if err := deferEphemeral(i, s); err != nil {
+			logging.ForInteraction(s, i).Error("queue import defer failed", "error", err)
+			return
+		}
+
+		data, err := downloadAttachment(attachment.URL)
+		if err != nil {
+			logging.ForInteraction(s, i).Error("queue import: download failed", "error", err)
+			sendFollowupEphemeral(s, i, "첨부 파일을 내려받지 못했습니다.")
+			return
+		}
+		raw = data
+	} else {
+		response, err := modals.DefaultAwaiter.ShowAndAwaitModal(s, i, &discordgo.InteractionResponseData{
+			CustomID: importModalID,
+			Title:    "대기열 가져오기",
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:    importTextInputID,
+							Label:       "M3U 목록 또는 URL (한 줄에 하나)",
+							Style:       discordgo.TextInputParagraph,
+							Placeholder: "#EXTM3U\nhttps://...",
+							Required:    true,
+							MaxLength:   4000,
+						},
+					},
+				},
+			},
+		}, 5*time.Minute)
+		if err != nil {
+			logging.ForInteraction(s, i).Error("queue import modal failed", "error", err)
+			return
+		}
+
+		target = response.Interaction
+		if err := deferEphemeral(target, s); err != nil {
+			logging.ForInteraction(s, i).Error("queue import defer failed", "error", err)
+			return
+		}
+		raw = []byte(getModalInputValue(response.Data, importTextInputID))
+	}
+
+	parsed, err := music.ParseQueueImport(raw)
+	if err != nil {
+		switch {
+		case errors.Is(err, music.ErrUnsupportedSchemaVersion):
+			sendFollowupEphemeral(s, target, "이 파일은 더 최신 버전의 TuneBot에서 만들어졌습니다. 봇을 업데이트해 주세요.")
+		default:
+			logging.ForInteraction(s, i).Error("queue import: parse failed", "error", err)
+			sendFollowupEphemeral(s, target, "가져올 수 있는 항목이 없습니다. JSON/M3U8/XSPF 형식인지 확인해 주세요.")
+		}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), importTotalTimeout)
+	defer cancel()
+
+	items, failed, err := cluster.Import(ctx, i.GuildID, userID, parsed.Entries)
+	if err != nil && len(items) == 0 {
+		switch {
+		case errors.Is(err, music.ErrNoVoiceChannel):
+			sendFollowupEphemeral(s, target, "먼저 음성 채널에 입장해 주세요.")
+		case errors.Is(err, music.ErrQueueFull):
+			sendFollowupEphemeral(s, target, "대기열이 가득 찼습니다.")
+		default:
+			logging.ForInteraction(s, i).Error("queue import failed", "error", err)
+			sendFollowupEphemeral(s, target, "대기열을 가져오지 못했습니다.")
+		}
+		return
+	}
+	if err != nil {
+		logging.ForInteraction(s, i).Error("queue import partially failed", "error", err)
+	}
+
+	lines := []string{fmt.Sprintf("📥 %d곡을 대기열에 추가했습니다.", len(items))}
+	if skipped := failed + parsed.Skipped; skipped > 0 {
+		lines = append(lines, fmt.Sprintf("건너뛴 항목: %d개", skipped))
+	}
+
+	if applySettings && parsed.Settings != nil {
+		if err := applyImportedSettings(ctx, i.GuildID, *parsed.Settings); err != nil {
+			logging.ForInteraction(s, i).Error("queue import: apply settings failed", "error", err)
+			lines = append(lines, "설정은 적용하지 못했습니다.")
+		} else {
+			lines = append(lines, "반복/셔플/볼륨 설정을 적용했습니다.")
+		}
+	}
+
+	sendFollowupEphemeral(s, target, strings.Join(lines, "\n"))
+}
+
+func applyImportedSettings(ctx context.Context, guildID string, imported music.QueueSettings) error {
+	store := music.NewQueueStoreFromDefault()
+	if store == nil {
+		return music.ErrQueueStoreNil
+	}
+
+	settings, err := store.GetSettings(ctx, guildID)
+	if err != nil {
+		return err
+	}
+
+	switch imported.RepeatMode {
+	case music.RepeatModeNone, music.RepeatModeTrack, music.RepeatModeQueue:
+		settings.RepeatMode = imported.RepeatMode
+	}
+	settings.Shuffle = imported.Shuffle
+	if imported.Volume > 0 && imported.Volume <= 200 {
+		settings.Volume = imported.Volume
+	}
+
+	return cluster.UpdateSettings(ctx, guildID, settings)
+}
+
+func downloadAttachment(url string) ([]byte, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("attachment status %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, importMaxFileSize))
+}
@@ -4,8 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
-	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -14,6 +12,7 @@ import (
 	dashboard "github.com/hxnx/tunebot/internal/features/dashboard"
 	queueview "github.com/hxnx/tunebot/internal/features/music/queueview"
 	search "github.com/hxnx/tunebot/internal/features/music/search"
+	"github.com/hxnx/tunebot/internal/logging"
 	"github.com/hxnx/tunebot/internal/music"
 )
 
@@ -40,7 +39,7 @@ func HandleMusicComponent(s *discordgo.Session, i *discordgo.InteractionCreate)
 	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseDeferredMessageUpdate,
 	}); err != nil {
-		log.Printf("music search: defer failed: %v", err)
+		logging.ForInteraction(s, i).Error("music search: defer failed", "error", err)
 		return
 	}
 
@@ -64,12 +63,6 @@ func HandleMusicComponent(s *discordgo.Session, i *discordgo.InteractionCreate)
 	track := session.Results[index]
 
 	player := music.DefaultPlayerManager.Get(i.GuildID)
-	spotifyID := strings.TrimSpace(os.Getenv("SPOTIFY_CLIENT_ID"))
-	spotifySecret := strings.TrimSpace(os.Getenv("SPOTIFY_CLIENT_SECRET"))
-	if spotifyID != "" && spotifySecret != "" {
-		playerManager := music.DefaultPlayerManager.WithSpotify(music.NewSpotifyClient(spotifyID, spotifySecret))
-		player = playerManager.Get(i.GuildID)
-	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
@@ -81,8 +74,10 @@ func HandleMusicComponent(s *discordgo.Session, i *discordgo.InteractionCreate)
 			sendFollowupEphemeral(s, i, "먼저 음성 채널에 입장해 주세요.")
 		case errors.Is(err, music.ErrSpotifyClientNil):
 			sendFollowupEphemeral(s, i, "현재 Spotify 트랙은 재생할 수 없습니다.")
+		case errors.Is(err, music.ErrQueueFull):
+			sendFollowupEphemeral(s, i, "대기열이 가득 찼습니다.")
 		default:
-			log.Printf("music search: enqueue failed: %v", err)
+			logging.ForInteraction(s, i).Error("music search: enqueue failed", "error", err)
 			sendFollowupEphemeral(s, i, "재생 요청에 실패했습니다.")
 		}
 		return
@@ -90,7 +85,6 @@ func HandleMusicComponent(s *discordgo.Session, i *discordgo.InteractionCreate)
 
 	search.DeleteSession(i.GuildID, userID)
 	sendFollowupQueueAdded(s, i, item)
-	_ = dashboard.UpdateDashboardByGuild(s, i.GuildID)
 }
 
 func handleQueuePagination(s *discordgo.Session, i *discordgo.InteractionCreate, customID string) {
@@ -119,7 +113,7 @@ func handleQueuePagination(s *discordgo.Session, i *discordgo.InteractionCreate,
 
 	items, err := store.List(ctx, i.GuildID, 0)
 	if err != nil {
-		log.Printf("queue page error: %v", err)
+		logging.ForInteraction(s, i).Error("queue page error", "error", err)
 		respondEphemeral(s, i, "대기열을 불러오지 못했습니다.")
 		return
 	}
@@ -137,7 +131,7 @@ func handleQueuePagination(s *discordgo.Session, i *discordgo.InteractionCreate,
 			Flags:      discordgo.MessageFlagsIsComponentsV2,
 		},
 	}); err != nil {
-		log.Printf("queue page respond failed: %v", err)
+		logging.ForInteraction(s, i).Error("queue page respond failed", "error", err)
 	}
 }
 
@@ -251,7 +245,7 @@ func sendFollowupEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate,
 		Flags:      flags,
 	})
 	if err != nil {
-		log.Printf("music search: followup failed: %v", err)
+		logging.ForInteraction(s, i).Error("music search: followup failed", "error", err)
 	}
 }
 
@@ -261,8 +255,8 @@ func sendFollowupQueueAdded(s *discordgo.Session, i *discordgo.InteractionCreate
 	}
 
 	description := fmt.Sprintf("**%s**", item.Track.Title)
-	if item.Track.URL != "" {
-		description = fmt.Sprintf("[**%s**](%s)", item.Track.Title, item.Track.URL)
+	if link := item.Track.LinkURL(); link != "" {
+		description = fmt.Sprintf("[**%s**](%s)", item.Track.Title, link)
 	}
 
 	lines := []string{
@@ -282,8 +276,6 @@ func sendFollowupQueueAdded(s *discordgo.Session, i *discordgo.InteractionCreate
 				fmt.Sprintf("📍 순서: #%d", size),
 				fmt.Sprintf("📋 대기열: %s", queueText),
 			)
-
-			dashboard.UpdateDashboardQueueCountCache(i.GuildID, size)
 		}
 	}
 
@@ -316,7 +308,7 @@ func sendFollowupQueueAdded(s *discordgo.Session, i *discordgo.InteractionCreate
 	}
 
 	if i.Interaction == nil {
-		log.Printf("music search: queue update failed: missing interaction context")
+		logging.ForGuild(s, "").Warn("music search: queue update failed: missing interaction context")
 		return
 	}
 
@@ -324,7 +316,7 @@ func sendFollowupQueueAdded(s *discordgo.Session, i *discordgo.InteractionCreate
 		Components: &components,
 		Flags:      discordgo.MessageFlagsIsComponentsV2,
 	}); err != nil {
-		log.Printf("music search: queue update failed: %v", err)
+		logging.ForInteraction(s, i).Error("music search: queue update failed", "error", err)
 	}
 }
 
@@ -3,14 +3,13 @@ package listeners
 import (
 	"context"
 	"errors"
-	"fmt"
-	"os"
 	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
 	dashboard "github.com/hxnx/tunebot/internal/features/dashboard"
 	search "github.com/hxnx/tunebot/internal/features/music/search"
+	"github.com/hxnx/tunebot/internal/logging"
 	"github.com/hxnx/tunebot/internal/music"
 )
 
@@ -53,13 +52,6 @@ func HandleMusicMessage(s *discordgo.Session, m *discordgo.MessageCreate) {
 		return
 	}
 
-	spotifyID := strings.TrimSpace(os.Getenv("SPOTIFY_CLIENT_ID"))
-	spotifySecret := strings.TrimSpace(os.Getenv("SPOTIFY_CLIENT_SECRET"))
-	var spotifyClient *music.SpotifyClient
-	if spotifyID != "" && spotifySecret != "" {
-		spotifyClient = music.NewSpotifyClient(spotifyID, spotifySecret)
-	}
-
 	sourceHint := resolveSourceHint(content)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
@@ -88,13 +80,13 @@ func HandleMusicMessage(s *discordgo.Session, m *discordgo.MessageCreate) {
 	})
 	scheduleDelete(s, m.ChannelID, m.ID, dashboardAutoDeleteDelay)
 
-	results, err := music.SearchTracks(ctx, content, sourceHint, search.MaxResults, music.NewYTDLPResolver(), spotifyClient)
+	results, err := music.SearchTracks(ctx, content, sourceHint, search.MaxResults, music.DefaultPlayerManager.Providers())
 	if err != nil {
 		errorText := "검색에 실패했습니다."
 		if errors.Is(err, music.ErrSpotifyClientNil) {
 			errorText = "Spotify 기능은 비활성화되어 있습니다."
 		} else {
-			fmt.Printf("music message: search failed: %v\n", err)
+			logging.ForGuild(s, m.GuildID).Error("music message: search failed", "user_id", m.Author.ID, "query", content, "error", err)
 		}
 
 		if loadingMsg != nil {
@@ -188,17 +180,7 @@ func resolveSourceHint(input string) music.TrackSource {
 }
 
 func detectSourceHint(input string) music.TrackSource {
-	lower := strings.ToLower(input)
-	switch {
-	case strings.Contains(lower, "spotify.com") || strings.HasPrefix(lower, "spotify:track:"):
-		return music.TrackSourceSpotify
-	case strings.Contains(lower, "soundcloud.com"):
-		return music.TrackSourceSoundCloud
-	case strings.Contains(lower, "youtube.com") || strings.Contains(lower, "youtu.be"):
-		return music.TrackSourceYouTube
-	default:
-		return music.TrackSourceUnknown
-	}
+	return music.DefaultPlayerManager.Providers().Detect(strings.TrimSpace(input))
 }
 
 func scheduleDelete(s *discordgo.Session, channelID, messageID string, delay time.Duration) {
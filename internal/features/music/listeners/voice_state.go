@@ -1,30 +1,51 @@
 package listeners
 
 import (
-	"log"
+	"sync"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/hxnx/tunebot/internal/database"
-	dashboard "github.com/hxnx/tunebot/internal/features/dashboard"
+	"github.com/hxnx/tunebot/internal/logging"
 	"github.com/hxnx/tunebot/internal/music"
 )
 
+var autoLeaveTimers = struct {
+	mu      sync.Mutex
+	byGuild map[string]*time.Timer
+}{
+	byGuild: make(map[string]*time.Timer),
+}
+
 func HandleVoiceStateUpdate(s *discordgo.Session, vs *discordgo.VoiceStateUpdate) {
 	if s == nil || vs == nil || vs.GuildID == "" {
 		return
 	}
 
+	if !botChannelEmpty(s, vs.GuildID) {
+		cancelAutoLeave(vs.GuildID)
+		return
+	}
+
+	timeout := music.DefaultPlayerManager.Limits().AutoLeaveTimeout
+	if timeout <= 0 {
+		return
+	}
+	scheduleAutoLeave(s, vs.GuildID, timeout)
+}
+
+func botChannelEmpty(s *discordgo.Session, guildID string) bool {
 	botID := ""
 	if s.State != nil && s.State.User != nil {
 		botID = s.State.User.ID
 	}
 	if botID == "" {
-		return
+		return false
 	}
 
-	guild := getGuildWithVoiceStates(s, vs.GuildID)
+	guild := getGuildWithVoiceStates(s, guildID)
 	if guild == nil {
-		return
+		return false
 	}
 
 	botChannelID := ""
@@ -35,32 +56,54 @@ func HandleVoiceStateUpdate(s *discordgo.Session, vs *discordgo.VoiceStateUpdate
 		}
 	}
 	if botChannelID == "" {
-		return
+		return false
 	}
 
-	hasOtherUser := false
 	for _, state := range guild.VoiceStates {
-		if state.ChannelID != botChannelID || state.UserID == botID {
-			continue
+		if state.ChannelID == botChannelID && state.UserID != botID {
+			return false
 		}
-		hasOtherUser = true
-		break
 	}
+	return true
+}
+
+func scheduleAutoLeave(s *discordgo.Session, guildID string, timeout time.Duration) {
+	autoLeaveTimers.mu.Lock()
+	defer autoLeaveTimers.mu.Unlock()
 
-	if hasOtherUser {
+	if _, ok := autoLeaveTimers.byGuild[guildID]; ok {
 		return
 	}
 
-	player := music.DefaultPlayerManager.Get(vs.GuildID)
+	autoLeaveTimers.byGuild[guildID] = time.AfterFunc(timeout, func() {
+		autoLeaveTimers.mu.Lock()
+		delete(autoLeaveTimers.byGuild, guildID)
+		autoLeaveTimers.mu.Unlock()
+
+		if botChannelEmpty(s, guildID) {
+			leaveEmptyChannel(s, guildID)
+		}
+	})
+}
+
+func cancelAutoLeave(guildID string) {
+	autoLeaveTimers.mu.Lock()
+	defer autoLeaveTimers.mu.Unlock()
+
+	if timer, ok := autoLeaveTimers.byGuild[guildID]; ok {
+		timer.Stop()
+		delete(autoLeaveTimers.byGuild, guildID)
+	}
+}
+
+func leaveEmptyChannel(s *discordgo.Session, guildID string) {
+	player := music.DefaultPlayerManager.Get(guildID)
 	if err := player.Stop(true); err != nil {
 		return
 	}
-	if err := dashboard.UpdateDashboardByGuild(s, vs.GuildID); err != nil {
-		log.Printf("failed to update dashboard after auto-stop: %v", err)
-	}
 
 	repo := database.NewGuildRepository()
-	channelID, _, ok, err := repo.GetDashboardEntry(vs.GuildID)
+	channelID, _, ok, err := repo.GetDashboardEntry(guildID)
 	if err != nil || !ok || channelID == "" {
 		return
 	}
@@ -70,7 +113,7 @@ func HandleVoiceStateUpdate(s *discordgo.Session, vs *discordgo.VoiceStateUpdate
 		Color:       0x3C6AA1,
 	}
 	if _, err := s.ChannelMessageSendEmbed(channelID, embed); err != nil {
-		log.Printf("failed to send voice-empty notice: %v", err)
+		logging.ForGuild(s, guildID).Error("failed to send voice-empty notice", "channel_id", channelID, "error", err)
 	}
 }
 
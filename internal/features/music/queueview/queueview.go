@@ -26,7 +26,28 @@ type PageInfo struct {
 }
 
 func BuildQueueComponents(items []music.QueueItem, page int, perPage int) ([]discordgo.MessageComponent, PageInfo) {
-	total := len(items)
+	tracks := make([]music.Track, 0, len(items))
+	for _, item := range items {
+		tracks = append(tracks, item.Track)
+	}
+
+	return BuildTrackListComponents(TrackList{
+		Heading:      "📋 **대기열**",
+		EmptyMessage: "대기열이 비어 있습니다.",
+		Tracks:       tracks,
+		PageCustomID: MakeQueuePageCustomID,
+	}, page, perPage)
+}
+
+type TrackList struct {
+	Heading      string
+	EmptyMessage string
+	Tracks       []music.Track
+	PageCustomID func(page int, perPage int) string
+}
+
+func BuildTrackListComponents(list TrackList, page int, perPage int) ([]discordgo.MessageComponent, PageInfo) {
+	total := len(list.Tracks)
 	if perPage <= 0 {
 		perPage = DefaultPerPage
 	}
@@ -40,18 +61,18 @@ func BuildQueueComponents(items []music.QueueItem, page int, perPage int) ([]dis
 	lines := make([]string, 0, end-start)
 	for i := start; i < end; i++ {
 		index := i + 1
-		title := strings.TrimSpace(items[i].Track.Title)
+		title := strings.TrimSpace(list.Tracks[i].Title)
 		if title == "" {
 			title = "알 수 없는 제목"
 		}
-		if items[i].Track.URL != "" {
-			lines = append(lines, fmt.Sprintf("%d. [%s](%s)", index, title, items[i].Track.URL))
+		if link := list.Tracks[i].LinkURL(); link != "" {
+			lines = append(lines, fmt.Sprintf("%d. [%s](%s)", index, title, link))
 		} else {
 			lines = append(lines, fmt.Sprintf("%d. %s", index, title))
 		}
 	}
 
-	listContent := "대기열이 비어 있습니다."
+	listContent := list.EmptyMessage
 	if len(lines) > 0 {
 		listContent = strings.Join(lines, "\n")
 	}
@@ -76,7 +97,7 @@ func BuildQueueComponents(items []music.QueueItem, page int, perPage int) ([]dis
 		discordgo.Container{
 			AccentColor: &accent,
 			Components: []discordgo.MessageComponent{
-				discordgo.TextDisplay{Content: "📋 **대기열**"},
+				discordgo.TextDisplay{Content: list.Heading},
 				discordgo.TextDisplay{Content: fmt.Sprintf("페이지 **%d/%d** · 표시 **%d곡**", page, totalPages, end-start)},
 				discordgo.Separator{Divider: &divider, Spacing: &spacing},
 				discordgo.TextDisplay{Content: listContent},
@@ -86,13 +107,13 @@ func BuildQueueComponents(items []music.QueueItem, page int, perPage int) ([]dis
 						discordgo.Button{
 							Style:    discordgo.SecondaryButton,
 							Label:    "이전",
-							CustomID: MakeQueuePageCustomID(page-1, perPage),
+							CustomID: list.PageCustomID(page-1, perPage),
 							Disabled: prevDisabled,
 						},
 						discordgo.Button{
 							Style:    discordgo.SecondaryButton,
 							Label:    "다음",
-							CustomID: MakeQueuePageCustomID(page+1, perPage),
+							CustomID: list.PageCustomID(page+1, perPage),
 							Disabled: nextDisabled,
 						},
 					},
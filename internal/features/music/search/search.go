@@ -1,13 +1,17 @@
 package search
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"log/slog"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/hxnx/tunebot/internal/music"
+	internalredis "github.com/hxnx/tunebot/internal/redis"
 )
 
 const (
@@ -27,6 +31,8 @@ type Session struct {
 	CreatedAt time.Time
 }
 
+const sessionKeyPrefix = "music:search:session:"
+
 var store = struct {
 	mu   sync.RWMutex
 	data map[string]Session
@@ -43,12 +49,40 @@ func SaveSession(s Session) {
 		return
 	}
 	s.CreatedAt = time.Now().UTC()
+
+	if client := internalredis.Client(); client != nil {
+		payload, err := json.Marshal(s)
+		if err != nil {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		defer cancel()
+		if err := client.Set(ctx, sessionKeyPrefix+sessionKey(s.GuildID, s.UserID), payload, SearchSessionTTL).Err(); err != nil {
+			slog.Error("failed to save search session", "guild_id", s.GuildID, "user_id", s.UserID, "error", err)
+		}
+		return
+	}
+
 	store.mu.Lock()
 	store.data[sessionKey(s.GuildID, s.UserID)] = s
 	store.mu.Unlock()
 }
 
 func GetSession(guildID, userID string) (Session, bool) {
+	if client := internalredis.Client(); client != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		defer cancel()
+		raw, err := client.Get(ctx, sessionKeyPrefix+sessionKey(guildID, userID)).Bytes()
+		if err != nil {
+			return Session{}, false
+		}
+		var session Session
+		if err := json.Unmarshal(raw, &session); err != nil {
+			return Session{}, false
+		}
+		return session, true
+	}
+
 	store.mu.RLock()
 	session, ok := store.data[sessionKey(guildID, userID)]
 	store.mu.RUnlock()
@@ -63,6 +97,12 @@ func GetSession(guildID, userID string) (Session, bool) {
 }
 
 func DeleteSession(guildID, userID string) {
+	if client := internalredis.Client(); client != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		defer cancel()
+		_ = client.Del(ctx, sessionKeyPrefix+sessionKey(guildID, userID)).Err()
+	}
+
 	store.mu.Lock()
 	delete(store.data, sessionKey(guildID, userID))
 	store.mu.Unlock()
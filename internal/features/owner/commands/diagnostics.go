@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/cluster"
+	"github.com/hxnx/tunebot/internal/database"
+	"github.com/hxnx/tunebot/internal/features/owner"
+	shared "github.com/hxnx/tunebot/internal/features/shared"
+	"github.com/hxnx/tunebot/internal/music"
+	"github.com/hxnx/tunebot/internal/redis"
+	"github.com/hxnx/tunebot/internal/shard"
+)
+
+const diagnosticsPingTimeout = 3 * time.Second
+
+var processStartedAt = time.Now()
+
+func Diagnostics(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if !owner.RequireOwner(s, i) {
+		return
+	}
+
+	owner.Audit(s, shared.GetInteractionUserID(i), i.GuildID, "diagnostics", "", nil)
+	respondComponents(s, i, "**진단 정보**", buildDiagnostics())
+}
+
+func buildDiagnostics() string {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	lines := []string{
+		fmt.Sprintf("**인스턴스:** `%s`", cluster.DefaultCoordinator.InstanceID()),
+		fmt.Sprintf("**Go:** %s · %s/%s", runtime.Version(), runtime.GOOS, runtime.GOARCH),
+		fmt.Sprintf("**업타임:** %s", time.Since(processStartedAt).Round(time.Second)),
+		fmt.Sprintf("**고루틴:** %d", runtime.NumGoroutine()),
+		fmt.Sprintf("**메모리:** 힙 %.2f MB · 시스템 %.2f MB · GC %d회", float64(mem.HeapAlloc)/1024.0/1024.0, float64(mem.Sys)/1024.0/1024.0, mem.NumGC),
+		fmt.Sprintf("**플레이어:** 재생 중 %d · 음성 연결 %d", music.DefaultPlayerManager.ActivePlayers(), music.DefaultPlayerManager.VoiceConnections()),
+	}
+
+	if manager := shard.Default(); manager != nil {
+		ready := 0
+		statuses := manager.Statuses()
+		for _, st := range statuses {
+			if st.State == shard.StateReady {
+				ready++
+			}
+		}
+		lines = append(lines, fmt.Sprintf("**샤드:** 준비 %d / 담당 %d (전체 %d)", ready, len(statuses), manager.ShardCount()))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), diagnosticsPingTimeout)
+	defer cancel()
+
+	lines = append(lines,
+		fmt.Sprintf("**Redis:** %s", pingStatus(ctx, redis.Ping)),
+		fmt.Sprintf("**PostgreSQL:** %s", pingStatus(ctx, database.Ping)),
+		fmt.Sprintf("**실행 파일:** %s", binaryStatus("ffmpeg", "yt-dlp")),
+	)
+
+	return strings.Join(lines, "\n")
+}
+
+func pingStatus(ctx context.Context, ping func(context.Context) error) string {
+	started := time.Now()
+	if err := ping(ctx); err != nil {
+		return fmt.Sprintf("🔴 %v", err)
+	}
+	return fmt.Sprintf("🟢 %s", time.Since(started).Round(time.Millisecond))
+}
+
+func binaryStatus(names ...string) string {
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		icon := "🟢"
+		if _, err := exec.LookPath(name); err != nil {
+			icon = "🔴"
+		}
+		parts = append(parts, fmt.Sprintf("%s %s", icon, name))
+	}
+	return strings.Join(parts, " · ")
+}
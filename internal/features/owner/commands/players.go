@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/features/owner"
+	shared "github.com/hxnx/tunebot/internal/features/shared"
+	"github.com/hxnx/tunebot/internal/logging"
+	"github.com/hxnx/tunebot/internal/music"
+)
+
+const maxPlayerRows = 20
+
+func Players(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if !owner.RequireOwner(s, i) {
+		return
+	}
+
+	summaries := music.DefaultPlayerManager.Summaries()
+	owner.Audit(s, shared.GetInteractionUserID(i), i.GuildID, "players.list", "", nil)
+
+	lines := make([]string, 0, min(len(summaries), maxPlayerRows)+1)
+	for idx, summary := range summaries {
+		if idx >= maxPlayerRows {
+			lines = append(lines, fmt.Sprintf("외 %d개 서버", len(summaries)-maxPlayerRows))
+			break
+		}
+		lines = append(lines, formatPlayerLine(s, summary))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "활성 플레이어가 없습니다.")
+	}
+
+	respondComponents(s, i, fmt.Sprintf("**활성 플레이어** (%d)", len(summaries)), strings.Join(lines, "\n"))
+}
+
+func formatPlayerLine(s *discordgo.Session, summary music.PlayerSummary) string {
+	name := summary.GuildID
+	if s != nil && s.State != nil {
+		if guild, err := s.State.Guild(summary.GuildID); err == nil && guild.Name != "" {
+			name = fmt.Sprintf("%s (`%s`)", guild.Name, summary.GuildID)
+		}
+	}
+
+	icon := "⏹️"
+	switch {
+	case summary.State.PausedAt != nil:
+		icon = "⏸️"
+	case summary.State.IsPlaying:
+		icon = "▶️"
+	}
+
+	line := fmt.Sprintf("%s %s", icon, name)
+	if summary.State.Track != nil {
+		line += fmt.Sprintf(" · %s", summary.State.Track.Title)
+	}
+	if !summary.Connected {
+		line += " · 음성 연결 없음"
+	}
+	return line
+}
+
+func respondComponents(s *discordgo.Session, i *discordgo.InteractionCreate, title string, body string) {
+	divider := true
+	spacing := discordgo.SeparatorSpacingSizeSmall
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Components: []discordgo.MessageComponent{
+				discordgo.Container{
+					AccentColor: &owner.AccentColor,
+					Components: []discordgo.MessageComponent{
+						discordgo.TextDisplay{Content: title},
+						discordgo.Separator{Divider: &divider, Spacing: &spacing},
+						discordgo.TextDisplay{Content: body},
+					},
+				},
+			},
+			Flags: discordgo.MessageFlagsIsComponentsV2 | discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		logging.ForInteraction(s, i).Error("owner command respond failed", "error", err)
+	}
+}
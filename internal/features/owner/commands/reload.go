@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/features/owner"
+	shared "github.com/hxnx/tunebot/internal/features/shared"
+	"github.com/hxnx/tunebot/internal/music"
+)
+
+var errReloadUnavailable = errors.New("config reload is not available")
+
+func ReloadConfig(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if !owner.RequireOwner(s, i) {
+		return
+	}
+
+	reload := owner.ConfigReloader()
+	if reload == nil {
+		owner.Audit(s, shared.GetInteractionUserID(i), i.GuildID, "config.reload", "", errReloadUnavailable)
+		shared.RespondEphemeral(s, i, "설정 다시 불러오기를 사용할 수 없습니다.")
+		return
+	}
+
+	changed, err := reload()
+	owner.Audit(s, shared.GetInteractionUserID(i), i.GuildID, "config.reload", strings.Join(changed, ","), err)
+	if err != nil {
+		shared.RespondEphemeral(s, i, fmt.Sprintf("설정을 다시 불러오지 못했습니다.\n```\n%v\n```", err))
+		return
+	}
+
+	if len(changed) == 0 {
+		shared.RespondEphemeral(s, i, "설정을 다시 불러왔습니다. 변경된 항목이 없습니다.")
+		return
+	}
+	shared.RespondEphemeral(s, i, fmt.Sprintf("설정을 다시 불러왔습니다.\n변경된 항목: `%s`", strings.Join(changed, "`, `")))
+}
+
+func ClearSearchCache(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if !owner.RequireOwner(s, i) {
+		return
+	}
+
+	cleared := music.ClearSearchCache()
+	owner.Audit(s, shared.GetInteractionUserID(i), i.GuildID, "search_cache.clear", fmt.Sprintf("%d", cleared), nil)
+	shared.RespondEphemeral(s, i, fmt.Sprintf("검색 캐시 %d개 항목을 비웠습니다.", cleared))
+}
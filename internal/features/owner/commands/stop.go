@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/cluster"
+	"github.com/hxnx/tunebot/internal/features/owner"
+	shared "github.com/hxnx/tunebot/internal/features/shared"
+)
+
+const forceStopTimeout = 10 * time.Second
+
+func ForceStop(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	if !owner.RequireOwner(s, i) {
+		return
+	}
+
+	guildID := strings.TrimSpace(shared.GetOptionString(options, "서버"))
+	if guildID == "" {
+		guildID = i.GuildID
+	}
+	if _, err := strconv.ParseUint(guildID, 10, 64); err != nil {
+		shared.RespondEphemeral(s, i, "올바른 서버 ID를 입력해 주세요.")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), forceStopTimeout)
+	defer cancel()
+
+	err := cluster.Stop(ctx, guildID, true)
+	owner.Audit(s, shared.GetInteractionUserID(i), i.GuildID, "player.force_stop", guildID, err)
+
+	switch {
+	case err == nil:
+		shared.RespondEphemeral(s, i, fmt.Sprintf("`%s` 서버의 재생을 강제로 정지하고 대기열을 비웠습니다.", guildID))
+	case errors.Is(err, cluster.ErrShardUnavailable), errors.Is(err, cluster.ErrDispatchTimeout):
+		shared.RespondEphemeral(s, i, "해당 서버를 담당하는 인스턴스에 연결할 수 없습니다.")
+	default:
+		shared.RespondEphemeral(s, i, fmt.Sprintf("강제 정지에 실패했습니다: %v", err))
+	}
+}
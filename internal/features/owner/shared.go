@@ -0,0 +1,63 @@
+package owner
+
+import (
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/database"
+	shared "github.com/hxnx/tunebot/internal/features/shared"
+	"github.com/hxnx/tunebot/internal/logging"
+)
+
+const NotOwnerMessage = "이 명령어는 봇 소유자만 사용할 수 있습니다."
+
+var AccentColor = 0xC9A0FF
+
+var configReloader = struct {
+	mu     sync.Mutex
+	reload func() ([]string, error)
+}{}
+
+func SetConfigReloader(reload func() ([]string, error)) {
+	configReloader.mu.Lock()
+	configReloader.reload = reload
+	configReloader.mu.Unlock()
+}
+
+func ConfigReloader() func() ([]string, error) {
+	configReloader.mu.Lock()
+	defer configReloader.mu.Unlock()
+	return configReloader.reload
+}
+
+func RequireOwner(s *discordgo.Session, i *discordgo.InteractionCreate) bool {
+	if shared.IsOwner(shared.GetInteractionUserID(i)) {
+		return true
+	}
+	shared.RespondEphemeral(s, i, NotOwnerMessage)
+	return false
+}
+
+func Audit(s *discordgo.Session, userID string, guildID string, action string, target string, actionErr error) {
+	entry := database.OwnerAuditEntry{
+		UserID:  userID,
+		GuildID: guildID,
+		Action:  action,
+		Target:  target,
+		Success: actionErr == nil,
+	}
+	if actionErr != nil {
+		entry.Detail = actionErr.Error()
+	}
+
+	logger := logging.ForGuild(s, guildID).With("user_id", userID, "action", action, "target", target)
+	if actionErr != nil {
+		logger.Warn("owner action failed", "error", actionErr)
+	} else {
+		logger.Info("owner action")
+	}
+
+	if err := database.NewAuditRepository().Record(entry); err != nil {
+		logger.Error("failed to record owner audit entry", "error", err)
+	}
+}
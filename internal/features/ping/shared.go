@@ -2,10 +2,10 @@ package ping
 
 import (
 	"fmt"
-	"log"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/logging"
 )
 
 func BuildPingComponentsV2(s *discordgo.Session) []discordgo.MessageComponent {
@@ -67,6 +67,6 @@ func RespondPing(s *discordgo.Session, i *discordgo.InteractionCreate, respType
 		},
 	})
 	if err != nil {
-		log.Printf("failed to respond to ping: %v", err)
+		logging.ForInteraction(s, i).Warn("failed to respond to ping", "error", err)
 	}
 }
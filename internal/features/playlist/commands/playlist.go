@@ -0,0 +1,383 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/cluster"
+	"github.com/hxnx/tunebot/internal/database"
+	queueview "github.com/hxnx/tunebot/internal/features/music/queueview"
+	"github.com/hxnx/tunebot/internal/features/playlist"
+	shared "github.com/hxnx/tunebot/internal/features/shared"
+	"github.com/hxnx/tunebot/internal/logging"
+	"github.com/hxnx/tunebot/internal/music"
+)
+
+func Create(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	userID, ok := requireGuildUser(s, i)
+	if !ok {
+		return
+	}
+
+	name := strings.TrimSpace(shared.GetOptionString(options, "이름"))
+	if name == "" || len([]rune(name)) > playlist.MaxNameLength {
+		shared.RespondEphemeral(s, i, fmt.Sprintf("플레이리스트 이름은 1~%d자로 입력해 주세요.", playlist.MaxNameLength))
+		return
+	}
+
+	scope := shared.GetOptionString(options, "범위")
+	if scope != database.PlaylistScopeGuild {
+		scope = database.PlaylistScopeUser
+	}
+
+	p, err := database.NewPlaylistRepository().Create(scope, playlist.OwnerIDForScope(scope, i.GuildID, userID), name, userID)
+	if err != nil {
+		respondRepoError(s, i, err)
+		return
+	}
+
+	shared.RespondEphemeral(s, i, fmt.Sprintf("%s 플레이리스트 **%s**을(를) 만들었습니다.", playlist.ScopeLabel(p.Scope), p.Name))
+}
+
+func AddCurrent(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	userID, ok := requireGuildUser(s, i)
+	if !ok {
+		return
+	}
+
+	p, ok := findEditablePlaylist(s, i, options, userID)
+	if !ok {
+		return
+	}
+
+	state := music.DefaultPlayerManager.Get(i.GuildID).State()
+	if state.Track == nil {
+		shared.RespondEphemeral(s, i, "현재 재생 중인 곡이 없습니다.")
+		return
+	}
+
+	added, err := database.NewPlaylistRepository().AddTracks(p.ID, []database.PlaylistTrack{playlist.EntryFromTrack(*state.Track, userID)}, playlist.MaxTracks)
+	if err != nil {
+		respondRepoError(s, i, err)
+		return
+	}
+	if added == 0 {
+		shared.RespondEphemeral(s, i, "추가된 곡이 없습니다.")
+		return
+	}
+
+	shared.RespondEphemeral(s, i, fmt.Sprintf("**%s**에 **%s**을(를) 추가했습니다.", p.Name, state.Track.Title))
+}
+
+func AddQueue(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	userID, ok := requireGuildUser(s, i)
+	if !ok {
+		return
+	}
+
+	p, ok := findEditablePlaylist(s, i, options, userID)
+	if !ok {
+		return
+	}
+
+	store := music.NewQueueStoreFromDefault()
+	if store == nil {
+		shared.RespondEphemeral(s, i, "대기열을 조회할 수 없습니다.")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	items, err := store.List(ctx, i.GuildID, 0)
+	if err != nil {
+		logging.ForInteraction(s, i).Error("playlist add queue: list failed", "error", err)
+		shared.RespondEphemeral(s, i, "대기열을 불러오지 못했습니다.")
+		return
+	}
+	if len(items) == 0 {
+		shared.RespondEphemeral(s, i, "대기열이 비어 있습니다.")
+		return
+	}
+
+	entries := make([]database.PlaylistTrack, 0, len(items))
+	for _, item := range items {
+		entries = append(entries, playlist.EntryFromTrack(item.Track, userID))
+	}
+
+	added, err := database.NewPlaylistRepository().AddTracks(p.ID, entries, playlist.MaxTracks)
+	if err != nil {
+		respondRepoError(s, i, err)
+		return
+	}
+
+	content := fmt.Sprintf("**%s**에 대기열의 %d곡을 추가했습니다.", p.Name, added)
+	if added < len(entries) {
+		content += fmt.Sprintf("\n최대 %d곡 제한으로 %d곡은 추가하지 못했습니다.", playlist.MaxTracks, len(entries)-added)
+	}
+	shared.RespondEphemeral(s, i, content)
+}
+
+func Remove(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	userID, ok := requireGuildUser(s, i)
+	if !ok {
+		return
+	}
+
+	position := shared.GetOptionInt(options, "번호")
+	if position <= 0 {
+		shared.RespondEphemeral(s, i, "삭제할 곡 번호를 입력해 주세요.")
+		return
+	}
+
+	p, ok := findEditablePlaylist(s, i, options, userID)
+	if !ok {
+		return
+	}
+
+	removed, err := database.NewPlaylistRepository().RemoveTrack(p.ID, position)
+	if err != nil {
+		if errors.Is(err, database.ErrPlaylistNotFound) {
+			shared.RespondEphemeral(s, i, fmt.Sprintf("%d번 곡을 찾을 수 없습니다.", position))
+			return
+		}
+		respondRepoError(s, i, err)
+		return
+	}
+
+	shared.RespondEphemeral(s, i, fmt.Sprintf("**%s**에서 **%s**을(를) 삭제했습니다.", p.Name, removed.Title))
+}
+
+func List(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	userID, ok := requireGuildUser(s, i)
+	if !ok {
+		return
+	}
+
+	playlists, err := database.NewPlaylistRepository().List(userID, i.GuildID)
+	if err != nil {
+		respondRepoError(s, i, err)
+		return
+	}
+	if len(playlists) == 0 {
+		shared.RespondEphemeral(s, i, "저장된 플레이리스트가 없습니다. `/플레이리스트 생성`으로 만들어 보세요.")
+		return
+	}
+
+	lines := make([]string, 0, len(playlists))
+	for _, p := range playlists {
+		lines = append(lines, fmt.Sprintf("%s · **%s** (%d곡)", playlist.ScopeLabel(p.Scope), p.Name, p.TrackCount))
+	}
+	shared.RespondEphemeral(s, i, strings.Join(lines, "\n"))
+}
+
+func Show(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	userID, ok := requireGuildUser(s, i)
+	if !ok {
+		return
+	}
+
+	p, ok := findPlaylist(s, i, options, userID)
+	if !ok {
+		return
+	}
+
+	entries, err := database.NewPlaylistRepository().Tracks(p.ID)
+	if err != nil {
+		respondRepoError(s, i, err)
+		return
+	}
+
+	components, _ := playlist.BuildPlaylistComponents(p, entries, 1, queueview.DefaultPerPage)
+
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Components: components,
+			Flags:      discordgo.MessageFlagsIsComponentsV2 | discordgo.MessageFlagsEphemeral,
+		},
+	}); err != nil {
+		logging.ForInteraction(s, i).Warn("playlist show respond failed", "error", err)
+	}
+}
+
+func Play(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	userID, ok := requireGuildUser(s, i)
+	if !ok {
+		return
+	}
+
+	p, ok := findPlaylist(s, i, options, userID)
+	if !ok {
+		return
+	}
+
+	entries, err := database.NewPlaylistRepository().Tracks(p.ID)
+	if err != nil {
+		respondRepoError(s, i, err)
+		return
+	}
+	if len(entries) == 0 {
+		shared.RespondEphemeral(s, i, "플레이리스트가 비어 있습니다.")
+		return
+	}
+
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	}); err != nil {
+		logging.ForInteraction(s, i).Warn("playlist play defer failed", "error", err)
+		return
+	}
+
+	tracks := make([]music.Track, 0, len(entries))
+	for _, entry := range entries {
+		tracks = append(tracks, playlist.TrackFromEntry(entry))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	items, err := cluster.EnqueueTracks(ctx, i.GuildID, userID, tracks)
+	if err != nil && len(items) == 0 {
+		switch {
+		case errors.Is(err, music.ErrNoVoiceChannel):
+			sendFollowup(s, i, "먼저 음성 채널에 입장해 주세요.")
+		case errors.Is(err, music.ErrQueueFull):
+			sendFollowup(s, i, "대기열이 가득 찼습니다.")
+		default:
+			logging.ForInteraction(s, i).Error("playlist play failed", "error", err)
+			sendFollowup(s, i, "플레이리스트를 재생하지 못했습니다.")
+		}
+		return
+	}
+	if err != nil && !errors.Is(err, music.ErrQueueFull) {
+		logging.ForInteraction(s, i).Warn("playlist play partially failed", "error", err)
+	}
+
+	message := fmt.Sprintf("**%s**의 %d곡을 대기열에 추가했습니다.", p.Name, len(items))
+	if errors.Is(err, music.ErrQueueFull) {
+		message += " 대기열이 가득 차 나머지 곡은 추가하지 못했습니다."
+	}
+	sendFollowup(s, i, message)
+}
+
+func Delete(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	userID, ok := requireGuildUser(s, i)
+	if !ok {
+		return
+	}
+
+	p, ok := findEditablePlaylist(s, i, options, userID)
+	if !ok {
+		return
+	}
+
+	if err := database.NewPlaylistRepository().Delete(p.ID); err != nil {
+		respondRepoError(s, i, err)
+		return
+	}
+
+	shared.RespondEphemeral(s, i, fmt.Sprintf("%s 플레이리스트 **%s**을(를) 삭제했습니다.", playlist.ScopeLabel(p.Scope), p.Name))
+}
+
+func requireGuildUser(s *discordgo.Session, i *discordgo.InteractionCreate) (string, bool) {
+	if i.GuildID == "" {
+		shared.RespondEphemeral(s, i, "이 명령어는 서버에서만 사용할 수 있습니다.")
+		return "", false
+	}
+
+	userID := shared.GetInteractionUserID(i)
+	if userID == "" {
+		shared.RespondEphemeral(s, i, "사용자 정보를 확인할 수 없습니다.")
+		return "", false
+	}
+	return userID, true
+}
+
+func findPlaylist(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption, userID string) (database.Playlist, bool) {
+	name := strings.TrimSpace(shared.GetOptionString(options, "이름"))
+	if name == "" {
+		shared.RespondEphemeral(s, i, "플레이리스트 이름을 입력해 주세요.")
+		return database.Playlist{}, false
+	}
+
+	scopes := []string{database.PlaylistScopeUser, database.PlaylistScopeGuild}
+	if scope := shared.GetOptionString(options, "범위"); scope != "" {
+		scopes = []string{scope}
+	}
+
+	repo := database.NewPlaylistRepository()
+	for _, scope := range scopes {
+		p, err := repo.Find(scope, playlist.OwnerIDForScope(scope, i.GuildID, userID), name)
+		if err == nil {
+			return p, true
+		}
+		if !errors.Is(err, database.ErrPlaylistNotFound) {
+			respondRepoError(s, i, err)
+			return database.Playlist{}, false
+		}
+	}
+
+	shared.RespondEphemeral(s, i, fmt.Sprintf("**%s** 플레이리스트를 찾을 수 없습니다.", name))
+	return database.Playlist{}, false
+}
+
+func findEditablePlaylist(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption, userID string) (database.Playlist, bool) {
+	p, ok := findPlaylist(s, i, options, userID)
+	if !ok {
+		return database.Playlist{}, false
+	}
+
+	if !playlist.CanEdit(p, i.GuildID, userID, shared.IsDJ(s, i)) {
+		shared.RespondEphemeral(s, i, "서버 플레이리스트는 만든 사람 또는 DJ만 수정할 수 있습니다.")
+		return database.Playlist{}, false
+	}
+	return p, true
+}
+
+func respondRepoError(s *discordgo.Session, i *discordgo.InteractionCreate, err error) {
+	switch {
+	case errors.Is(err, database.ErrPlaylistExists):
+		shared.RespondEphemeral(s, i, "같은 이름의 플레이리스트가 이미 있습니다.")
+	case errors.Is(err, database.ErrPlaylistNotFound):
+		shared.RespondEphemeral(s, i, "플레이리스트를 찾을 수 없습니다.")
+	case errors.Is(err, database.ErrPlaylistFull):
+		shared.RespondEphemeral(s, i, fmt.Sprintf("플레이리스트에는 최대 %d곡까지 저장할 수 있습니다.", playlist.MaxTracks))
+	case errors.Is(err, database.ErrPlaylistUnavailable):
+		shared.RespondEphemeral(s, i, "플레이리스트 저장소를 사용할 수 없습니다.")
+	default:
+		logging.ForInteraction(s, i).Error("playlist repository error", "error", err)
+		shared.RespondEphemeral(s, i, "플레이리스트 작업에 실패했습니다.")
+	}
+}
+
+func sendFollowup(s *discordgo.Session, i *discordgo.InteractionCreate, content string) {
+	divider := true
+	spacing := discordgo.SeparatorSpacingSizeSmall
+
+	components := []discordgo.MessageComponent{
+		discordgo.Container{
+			AccentColor: &playlist.AccentColor,
+			Components: []discordgo.MessageComponent{
+				discordgo.TextDisplay{Content: "알림"},
+				discordgo.Separator{Divider: &divider, Spacing: &spacing},
+				discordgo.TextDisplay{Content: content},
+			},
+		},
+	}
+
+	_, err := s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Components: components,
+		Flags:      discordgo.MessageFlagsEphemeral | discordgo.MessageFlagsIsComponentsV2,
+	})
+	if err != nil {
+		logging.ForInteraction(s, i).Warn("playlist followup failed", "error", err)
+	}
+}
@@ -0,0 +1,67 @@
+package listeners
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/database"
+	"github.com/hxnx/tunebot/internal/features/playlist"
+	shared "github.com/hxnx/tunebot/internal/features/shared"
+	"github.com/hxnx/tunebot/internal/logging"
+)
+
+func RoutePlaylistComponent(s *discordgo.Session, i *discordgo.InteractionCreate) bool {
+	if i.Type != discordgo.InteractionMessageComponent {
+		return false
+	}
+
+	customID := i.MessageComponentData().CustomID
+	if !strings.HasPrefix(customID, "playlist_") {
+		return false
+	}
+
+	HandlePlaylistComponent(s, i)
+	return true
+}
+
+func HandlePlaylistComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	id, page, perPage, ok := playlist.ParsePageCustomID(i.MessageComponentData().CustomID)
+	if !ok {
+		shared.RespondEphemeral(s, i, "유효하지 않은 페이지 요청입니다.")
+		return
+	}
+
+	repo := database.NewPlaylistRepository()
+	p, err := repo.Get(id)
+	if err != nil {
+		if !errors.Is(err, database.ErrPlaylistNotFound) {
+			logging.ForInteraction(s, i).Error("playlist page: load failed", "error", err)
+		}
+		shared.RespondEphemeral(s, i, "플레이리스트를 찾을 수 없습니다.")
+		return
+	}
+	if !playlist.CanView(p, i.GuildID, shared.GetInteractionUserID(i)) {
+		shared.RespondEphemeral(s, i, "이 플레이리스트를 볼 수 없습니다.")
+		return
+	}
+
+	entries, err := repo.Tracks(p.ID)
+	if err != nil {
+		logging.ForInteraction(s, i).Error("playlist page: tracks failed", "error", err)
+		shared.RespondEphemeral(s, i, "플레이리스트를 불러오지 못했습니다.")
+		return
+	}
+
+	components, _ := playlist.BuildPlaylistComponents(p, entries, page, perPage)
+
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Components: components,
+			Flags:      discordgo.MessageFlagsIsComponentsV2,
+		},
+	}); err != nil {
+		logging.ForInteraction(s, i).Warn("playlist page respond failed", "error", err)
+	}
+}
@@ -0,0 +1,128 @@
+package playlist
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/database"
+	queueview "github.com/hxnx/tunebot/internal/features/music/queueview"
+	"github.com/hxnx/tunebot/internal/music"
+)
+
+const (
+	PageCustomIDPrefix = "playlist_page"
+	MaxTracks          = 500
+	MaxNameLength      = 50
+)
+
+var AccentColor = 0xC9A0FF
+
+func ScopeLabel(scope string) string {
+	if scope == database.PlaylistScopeGuild {
+		return "🏠 서버"
+	}
+	return "👤 개인"
+}
+
+func OwnerIDForScope(scope string, guildID string, userID string) string {
+	if scope == database.PlaylistScopeGuild {
+		return guildID
+	}
+	return userID
+}
+
+func CanView(p database.Playlist, guildID string, userID string) bool {
+	if p.Scope == database.PlaylistScopeGuild {
+		return p.OwnerID == guildID
+	}
+	return p.OwnerID == userID
+}
+
+func CanEdit(p database.Playlist, guildID string, userID string, isDJ bool) bool {
+	if p.Scope == database.PlaylistScopeGuild {
+		return p.OwnerID == guildID && (p.CreatedBy == userID || isDJ)
+	}
+	return p.OwnerID == userID
+}
+
+func TrackFromEntry(entry database.PlaylistTrack) music.Track {
+	return music.Track{
+		Title:     entry.Title,
+		URL:       entry.URL,
+		Source:    music.TrackSource(entry.Source),
+		Duration:  time.Duration(entry.DurationMS) * time.Millisecond,
+		Thumbnail: entry.Thumbnail,
+		IsLive:    entry.IsLive,
+		ISRC:      entry.ISRC,
+	}
+}
+
+func EntryFromTrack(track music.Track, addedBy string) database.PlaylistTrack {
+	return database.PlaylistTrack{
+		Title:      track.Title,
+		URL:        track.URL,
+		Source:     string(track.Source),
+		DurationMS: track.Duration.Milliseconds(),
+		Thumbnail:  track.Thumbnail,
+		IsLive:     track.IsLive,
+		ISRC:       track.ISRC,
+		AddedBy:    addedBy,
+	}
+}
+
+func BuildPlaylistComponents(p database.Playlist, entries []database.PlaylistTrack, page int, perPage int) ([]discordgo.MessageComponent, queueview.PageInfo) {
+	tracks := make([]music.Track, 0, len(entries))
+	for _, entry := range entries {
+		tracks = append(tracks, TrackFromEntry(entry))
+	}
+
+	return queueview.BuildTrackListComponents(queueview.TrackList{
+		Heading:      fmt.Sprintf("🎶 **%s** · %s", p.Name, ScopeLabel(p.Scope)),
+		EmptyMessage: "플레이리스트가 비어 있습니다.",
+		Tracks:       tracks,
+		PageCustomID: func(page int, perPage int) string {
+			return MakePageCustomID(p.ID, page, perPage)
+		},
+	}, page, perPage)
+}
+
+func MakePageCustomID(id int64, page int, perPage int) string {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = queueview.DefaultPerPage
+	}
+	return fmt.Sprintf("%s:%d:%d:%d", PageCustomIDPrefix, id, page, perPage)
+}
+
+func ParsePageCustomID(customID string) (id int64, page int, perPage int, ok bool) {
+	if !strings.HasPrefix(customID, PageCustomIDPrefix+":") {
+		return 0, 0, 0, false
+	}
+
+	parts := strings.Split(customID, ":")
+	if len(parts) != 4 {
+		return 0, 0, 0, false
+	}
+
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, 0, 0, false
+	}
+
+	page, err = strconv.Atoi(parts[2])
+	if err != nil || page < 1 {
+		return 0, 0, 0, false
+	}
+
+	perPage, err = strconv.Atoi(parts[3])
+	if err != nil || perPage < 1 {
+		return 0, 0, 0, false
+	}
+
+	return id, page, perPage, true
+}
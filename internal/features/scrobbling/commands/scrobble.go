@@ -0,0 +1,203 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/database"
+	"github.com/hxnx/tunebot/internal/features/scrobbling"
+	shared "github.com/hxnx/tunebot/internal/features/shared"
+	"github.com/hxnx/tunebot/internal/logging"
+	"github.com/hxnx/tunebot/internal/scrobble"
+)
+
+const serviceRequestTimeout = 15 * time.Second
+
+func Link(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	if !scrobbling.RequireEnabled(s, i) {
+		return
+	}
+	if !database.NewScrobbleRepository().Available() {
+		shared.RespondEphemeral(s, i, scrobbling.StorageMissingMessage)
+		return
+	}
+
+	switch shared.GetOptionString(options, "서비스") {
+	case scrobble.ServiceListenBrainz:
+		linkListenBrainz(s, i, strings.TrimSpace(shared.GetOptionString(options, "토큰")))
+	case scrobble.ServiceLastFM:
+		linkLastFM(s, i)
+	default:
+		shared.RespondEphemeral(s, i, "지원하지 않는 서비스입니다.")
+	}
+}
+
+func linkListenBrainz(s *discordgo.Session, i *discordgo.InteractionCreate, token string) {
+	if token == "" {
+		shared.RespondEphemeral(s, i, "ListenBrainz 사용자 토큰을 `토큰` 항목에 입력해 주세요.\n-# https://listenbrainz.org/settings/ 에서 확인할 수 있습니다.")
+		return
+	}
+	if !deferEphemeral(s, i) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), serviceRequestTimeout)
+	defer cancel()
+
+	username, err := scrobble.ListenBrainzClient().ValidateToken(ctx, token)
+	if errors.Is(err, scrobble.ErrInvalidCredentials) {
+		editResponse(s, i, "🎧 **ListenBrainz 연결**", "토큰이 올바르지 않습니다. 설정 페이지의 사용자 토큰을 다시 확인해 주세요.")
+		return
+	}
+	if err != nil {
+		logging.ForInteraction(s, i).Warn("listenbrainz token validation failed", "error", err)
+		editResponse(s, i, "🎧 **ListenBrainz 연결**", "ListenBrainz에 연결하지 못했습니다. 잠시 후 다시 시도해 주세요.")
+		return
+	}
+
+	userID := shared.GetInteractionUserID(i)
+	if err := database.NewScrobbleRepository().Link(userID, scrobble.ServiceListenBrainz, token, username); err != nil {
+		logging.ForInteraction(s, i).Error("scrobbler link failed", "service", scrobble.ServiceListenBrainz, "error", err)
+		editResponse(s, i, "🎧 **ListenBrainz 연결**", "연결 정보를 저장하지 못했습니다.")
+		return
+	}
+	scrobbling.ForgetUser(userID)
+
+	logging.ForInteraction(s, i).Info("scrobbler linked", "service", scrobble.ServiceListenBrainz)
+	editResponse(s, i, "🎧 **ListenBrainz 연결**", fmt.Sprintf("**%s** 계정과 연결했습니다. 이제 봇과 같은 음성 채널에서 들은 곡이 기록됩니다.", username))
+}
+
+func linkLastFM(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	client := scrobble.LastFMClient()
+	if !client.Configured() {
+		shared.RespondEphemeral(s, i, "Last.fm API 키가 설정되어 있지 않습니다. 봇 관리자에게 문의해 주세요.")
+		return
+	}
+	if !deferEphemeral(s, i) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), serviceRequestTimeout)
+	defer cancel()
+
+	token, err := client.RequestToken(ctx)
+	if err != nil {
+		logging.ForInteraction(s, i).Warn("last.fm token request failed", "error", err)
+		editResponse(s, i, "🎧 **Last.fm 연결**", "Last.fm에 연결하지 못했습니다. 잠시 후 다시 시도해 주세요.")
+		return
+	}
+
+	components := scrobbling.BuildLastFMAuthComponents(token, "")
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Components: &components,
+		Flags:      discordgo.MessageFlagsIsComponentsV2,
+	}); err != nil {
+		logging.ForInteraction(s, i).Error("scrobble respond failed", "error", err)
+	}
+}
+
+func Unlink(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	service := shared.GetOptionString(options, "서비스")
+	userID := shared.GetInteractionUserID(i)
+
+	err := database.NewScrobbleRepository().Unlink(userID, service)
+	switch {
+	case errors.Is(err, database.ErrScrobbleUnavailable):
+		shared.RespondEphemeral(s, i, scrobbling.StorageMissingMessage)
+		return
+	case errors.Is(err, database.ErrScrobblerLinkNotFound):
+		shared.RespondEphemeral(s, i, fmt.Sprintf("%s 계정이 연결되어 있지 않습니다.", scrobbling.ServiceLabel(service)))
+		return
+	case err != nil:
+		logging.ForInteraction(s, i).Error("scrobbler unlink failed", "service", service, "error", err)
+		shared.RespondEphemeral(s, i, "연결을 해제하지 못했습니다.")
+		return
+	}
+	scrobbling.ForgetUser(userID)
+
+	logging.ForInteraction(s, i).Info("scrobbler unlinked", "service", service)
+	shared.RespondEphemeral(s, i, fmt.Sprintf("%s 연결을 해제했습니다. 전송 대기 중이던 기록도 함께 삭제되었습니다.", scrobbling.ServiceLabel(service)))
+}
+
+func Status(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	repo := database.NewScrobbleRepository()
+	if !repo.Available() {
+		shared.RespondEphemeral(s, i, scrobbling.StorageMissingMessage)
+		return
+	}
+
+	userID := shared.GetInteractionUserID(i)
+	links, err := repo.LinksForUsers([]string{userID})
+	if err != nil {
+		logging.ForInteraction(s, i).Error("scrobbler links load failed", "error", err)
+		shared.RespondEphemeral(s, i, "연결 정보를 불러오지 못했습니다.")
+		return
+	}
+	pending, err := repo.PendingCounts(userID)
+	if err != nil {
+		logging.ForInteraction(s, i).Error("scrobble pending count failed", "error", err)
+		shared.RespondEphemeral(s, i, "연결 정보를 불러오지 못했습니다.")
+		return
+	}
+
+	userLinks := links[userID]
+	sort.Slice(userLinks, func(a, b int) bool { return userLinks[a].Service < userLinks[b].Service })
+
+	lines := make([]string, 0, len(userLinks)+2)
+	for _, link := range userLinks {
+		status := []string{fmt.Sprintf("연결 <t:%d:R>", link.CreatedAt.Unix())}
+		if count := pending[link.Service]; count > 0 {
+			status = append(status, fmt.Sprintf("전송 대기 %d곡", count))
+		}
+		name := link.Username
+		if name == "" {
+			name = "알 수 없는 계정"
+		}
+		lines = append(lines, fmt.Sprintf("**%s** · %s\n-# %s", scrobbling.ServiceLabel(link.Service), name, strings.Join(status, " · ")))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "연결된 서비스가 없습니다. `/스크로블 연결`로 계정을 연결해 주세요.")
+	}
+	lines = append(lines, "-# 봇과 같은 음성 채널에서 곡 길이의 절반 또는 4분 이상 들으면 기록됩니다.")
+	if !scrobbling.Enabled() {
+		lines = append(lines, "-# 현재 스크로블 기능이 비활성화되어 있어 새 기록이 쌓이지 않습니다.")
+	}
+
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Components: scrobbling.BuildMessage("🎧 **스크로블**", strings.Join(lines, "\n")),
+			Flags:      discordgo.MessageFlagsIsComponentsV2 | discordgo.MessageFlagsEphemeral,
+		},
+	}); err != nil {
+		logging.ForInteraction(s, i).Error("scrobble respond failed", "error", err)
+	}
+}
+
+func deferEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate) bool {
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	}); err != nil {
+		logging.ForInteraction(s, i).Error("scrobble defer failed", "error", err)
+		return false
+	}
+	return true
+}
+
+func editResponse(s *discordgo.Session, i *discordgo.InteractionCreate, title string, body string) {
+	components := scrobbling.BuildMessage(title, body)
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Components: &components,
+		Flags:      discordgo.MessageFlagsIsComponentsV2,
+	}); err != nil {
+		logging.ForInteraction(s, i).Error("scrobble respond failed", "error", err)
+	}
+}
@@ -0,0 +1,95 @@
+package listeners
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/database"
+	"github.com/hxnx/tunebot/internal/features/scrobbling"
+	shared "github.com/hxnx/tunebot/internal/features/shared"
+	"github.com/hxnx/tunebot/internal/logging"
+	"github.com/hxnx/tunebot/internal/scrobble"
+)
+
+const sessionRequestTimeout = 15 * time.Second
+
+func RouteScrobbleComponent(s *discordgo.Session, i *discordgo.InteractionCreate) bool {
+	if i.Type != discordgo.InteractionMessageComponent {
+		return false
+	}
+
+	customID := i.MessageComponentData().CustomID
+	if !strings.HasPrefix(customID, scrobbling.ComponentPrefix) {
+		return false
+	}
+
+	if token, ok := strings.CutPrefix(customID, scrobbling.LastFMConfirmPrefix); ok && token != "" {
+		ConfirmLastFM(s, i, token)
+		return true
+	}
+	shared.RespondEphemeral(s, i, "유효하지 않은 요청입니다.")
+	return true
+}
+
+func ConfirmLastFM(s *discordgo.Session, i *discordgo.InteractionCreate, token string) {
+	if !scrobbling.RequireEnabled(s, i) {
+		return
+	}
+	client := scrobble.LastFMClient()
+	if !client.Configured() {
+		shared.RespondEphemeral(s, i, "Last.fm API 키가 설정되어 있지 않습니다. 봇 관리자에게 문의해 주세요.")
+		return
+	}
+
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	}); err != nil {
+		logging.ForInteraction(s, i).Error("scrobble defer failed", "error", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sessionRequestTimeout)
+	defer cancel()
+
+	var components []discordgo.MessageComponent
+	username, sessionKey, err := client.Session(ctx, token)
+	switch {
+	case errors.Is(err, scrobble.ErrLastFMTokenPending):
+		components = scrobbling.BuildLastFMAuthComponents(token, "⚠️ 아직 승인되지 않았습니다. Last.fm에서 승인한 뒤 다시 눌러 주세요.")
+	case err != nil && scrobble.IsPermanent(err):
+		logging.ForInteraction(s, i).Warn("last.fm session request rejected", "error", err)
+		components = scrobbling.BuildMessage("🎧 **Last.fm 연결**", "승인 요청이 만료되었거나 거절되었습니다. `/스크로블 연결`을 다시 실행해 주세요.")
+	case err != nil:
+		logging.ForInteraction(s, i).Warn("last.fm session request failed", "error", err)
+		components = scrobbling.BuildLastFMAuthComponents(token, "⚠️ Last.fm에 연결하지 못했습니다. 잠시 후 다시 눌러 주세요.")
+	default:
+		components = linkLastFM(s, i, username, sessionKey)
+	}
+
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Components: &components,
+		Flags:      discordgo.MessageFlagsIsComponentsV2,
+	}); err != nil {
+		logging.ForInteraction(s, i).Error("scrobble respond failed", "error", err)
+	}
+}
+
+func linkLastFM(s *discordgo.Session, i *discordgo.InteractionCreate, username string, sessionKey string) []discordgo.MessageComponent {
+	userID := shared.GetInteractionUserID(i)
+	err := database.NewScrobbleRepository().Link(userID, scrobble.ServiceLastFM, sessionKey, username)
+	if errors.Is(err, database.ErrScrobbleUnavailable) {
+		return scrobbling.BuildMessage("🎧 **Last.fm 연결**", scrobbling.StorageMissingMessage)
+	}
+	if err != nil {
+		logging.ForInteraction(s, i).Error("scrobbler link failed", "service", scrobble.ServiceLastFM, "error", err)
+		return scrobbling.BuildMessage("🎧 **Last.fm 연결**", "연결 정보를 저장하지 못했습니다.")
+	}
+	scrobbling.ForgetUser(userID)
+
+	logging.ForInteraction(s, i).Info("scrobbler linked", "service", scrobble.ServiceLastFM)
+	return scrobbling.BuildMessage("🎧 **Last.fm 연결**", fmt.Sprintf("**%s** 계정과 연결했습니다. 이제 봇과 같은 음성 채널에서 들은 곡이 기록됩니다.", username))
+}
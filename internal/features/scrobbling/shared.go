@@ -0,0 +1,105 @@
+package scrobbling
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/bwmarrin/discordgo"
+	shared "github.com/hxnx/tunebot/internal/features/shared"
+	"github.com/hxnx/tunebot/internal/music"
+	"github.com/hxnx/tunebot/internal/scrobble"
+)
+
+const (
+	ComponentPrefix       = "scrobble_"
+	LastFMConfirmPrefix   = ComponentPrefix + "lastfm:"
+	DisabledMessage       = "스크로블 기능이 비활성화되어 있습니다. 봇 관리자에게 문의해 주세요."
+	StorageMissingMessage = "데이터베이스가 설정되지 않아 스크로블 기능을 사용할 수 없습니다."
+)
+
+var AccentColor = 0xD51007
+
+var registerOnce sync.Once
+
+var enabled atomic.Bool
+
+func init() {
+	enabled.Store(true)
+}
+
+func SetEnabled(value bool) {
+	enabled.Store(value)
+}
+
+func Enabled() bool {
+	return enabled.Load()
+}
+
+func Register(manager *music.PlayerManager) {
+	if manager == nil {
+		return
+	}
+	registerOnce.Do(func() {
+		manager.Subscribe(handlePlayerEvent)
+		go sampleLoop()
+	})
+}
+
+func RequireEnabled(s *discordgo.Session, i *discordgo.InteractionCreate) bool {
+	if !Enabled() {
+		shared.RespondEphemeral(s, i, DisabledMessage)
+		return false
+	}
+	return true
+}
+
+func ServiceLabel(service string) string {
+	switch service {
+	case scrobble.ServiceListenBrainz:
+		return "ListenBrainz"
+	case scrobble.ServiceLastFM:
+		return "Last.fm"
+	default:
+		return service
+	}
+}
+
+func BuildMessage(title string, body string) []discordgo.MessageComponent {
+	divider := true
+	spacing := discordgo.SeparatorSpacingSizeSmall
+
+	return []discordgo.MessageComponent{
+		discordgo.Container{
+			AccentColor: &AccentColor,
+			Components: []discordgo.MessageComponent{
+				discordgo.TextDisplay{Content: title},
+				discordgo.Separator{Divider: &divider, Spacing: &spacing},
+				discordgo.TextDisplay{Content: body},
+			},
+		},
+	}
+}
+
+func BuildLastFMAuthComponents(token string, notice string) []discordgo.MessageComponent {
+	body := "1. 아래 **Last.fm에서 승인** 버튼을 눌러 TuneBot의 접근을 허용해 주세요.\n2. 승인한 뒤 **승인 완료** 버튼을 누르면 연결이 끝납니다.\n-# 승인 링크는 약 60분 동안 유효합니다."
+	if notice != "" {
+		body += "\n\n" + notice
+	}
+
+	components := BuildMessage("🎧 **Last.fm 연결**", body)
+	components = append(components, discordgo.ActionsRow{
+		Components: []discordgo.MessageComponent{
+			discordgo.Button{
+				Label: "Last.fm에서 승인",
+				Style: discordgo.LinkButton,
+				URL:   scrobble.LastFMClient().AuthURL(token),
+			},
+			discordgo.Button{
+				Label:    "승인 완료",
+				Style:    discordgo.PrimaryButton,
+				CustomID: LastFMConfirmPrefix + token,
+			},
+		},
+	})
+	return components
+}
@@ -0,0 +1,291 @@
+package scrobbling
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/database"
+	"github.com/hxnx/tunebot/internal/music"
+	"github.com/hxnx/tunebot/internal/scrobble"
+)
+
+const sampleInterval = 10 * time.Second
+
+type listeningSession struct {
+	mu         sync.Mutex
+	session    *discordgo.Session
+	listen     scrobble.Listen
+	threshold  time.Duration
+	paused     bool
+	lastSample time.Time
+	listened   map[string]time.Duration
+	links      map[string][]database.ScrobblerLink
+	loading    map[string]bool
+	pending    sync.WaitGroup
+	finished   bool
+}
+
+var sessions = struct {
+	mu      sync.Mutex
+	byGuild map[string]*listeningSession
+}{
+	byGuild: make(map[string]*listeningSession),
+}
+
+func handlePlayerEvent(event music.PlayerEvent) {
+	if event.GuildID == "" {
+		return
+	}
+	if !enabled.Load() {
+		takeSession(event.GuildID)
+		return
+	}
+
+	switch event.Type {
+	case music.PlayerEventTrackStarted:
+		startSession(event)
+	case music.PlayerEventPaused:
+		if ls := getSession(event.GuildID); ls != nil {
+			ls.mu.Lock()
+			ls.sample(event.GuildID, event.At)
+			ls.paused = true
+			ls.mu.Unlock()
+		}
+	case music.PlayerEventResumed:
+		if ls := getSession(event.GuildID); ls != nil {
+			ls.mu.Lock()
+			ls.paused = false
+			ls.lastSample = event.At
+			ls.mu.Unlock()
+		}
+	case music.PlayerEventTrackEnded:
+		if ls := takeSession(event.GuildID); ls != nil {
+			ls.mu.Lock()
+			ls.sample(event.GuildID, event.At)
+			ls.finished = true
+			ls.mu.Unlock()
+			go ls.finish(event.GuildID)
+		}
+	}
+}
+
+func startSession(event music.PlayerEvent) {
+	takeSession(event.GuildID)
+
+	listen, ok := scrobble.NewListen(event.Item.Track, event.At)
+	if !ok {
+		return
+	}
+
+	ls := &listeningSession{
+		session:    event.Session,
+		listen:     listen,
+		threshold:  scrobble.Threshold(listen.Duration),
+		lastSample: event.At,
+		listened:   make(map[string]time.Duration),
+		links:      make(map[string][]database.ScrobblerLink),
+		loading:    make(map[string]bool),
+	}
+
+	sessions.mu.Lock()
+	sessions.byGuild[event.GuildID] = ls
+	sessions.mu.Unlock()
+
+	ls.mu.Lock()
+	ls.sample(event.GuildID, event.At)
+	ls.mu.Unlock()
+}
+
+func getSession(guildID string) *listeningSession {
+	sessions.mu.Lock()
+	defer sessions.mu.Unlock()
+	return sessions.byGuild[guildID]
+}
+
+func takeSession(guildID string) *listeningSession {
+	sessions.mu.Lock()
+	defer sessions.mu.Unlock()
+	ls := sessions.byGuild[guildID]
+	delete(sessions.byGuild, guildID)
+	return ls
+}
+
+func sampleLoop() {
+	ticker := time.NewTicker(sampleInterval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		if !enabled.Load() {
+			continue
+		}
+
+		sessions.mu.Lock()
+		active := make(map[string]*listeningSession, len(sessions.byGuild))
+		for guildID, ls := range sessions.byGuild {
+			active[guildID] = ls
+		}
+		sessions.mu.Unlock()
+
+		for guildID, ls := range active {
+			ls.mu.Lock()
+			if !ls.paused && !ls.finished {
+				ls.sample(guildID, now.UTC())
+			}
+			ls.mu.Unlock()
+		}
+	}
+}
+
+func (ls *listeningSession) sample(guildID string, now time.Time) {
+	elapsed := now.Sub(ls.lastSample)
+	ls.lastSample = now
+	if ls.paused || elapsed < 0 {
+		return
+	}
+
+	present := presentListeners(ls.session, guildID)
+	for _, userID := range present {
+		ls.listened[userID] += elapsed
+	}
+	ls.announce(guildID, present)
+}
+
+func (ls *listeningSession) announce(guildID string, present []string) {
+	if ls.finished {
+		return
+	}
+
+	unknown := make([]string, 0, len(present))
+	for _, userID := range present {
+		if _, ok := ls.links[userID]; !ok && !ls.loading[userID] {
+			unknown = append(unknown, userID)
+			ls.loading[userID] = true
+		}
+	}
+	if len(unknown) == 0 {
+		return
+	}
+
+	ls.pending.Add(1)
+	go ls.loadLinks(guildID, unknown)
+}
+
+func (ls *listeningSession) loadLinks(guildID string, userIDs []string) {
+	defer ls.pending.Done()
+
+	links, err := database.NewScrobbleRepository().LinksForUsers(userIDs)
+	if errors.Is(err, database.ErrScrobbleUnavailable) {
+		links, err = map[string][]database.ScrobblerLink{}, nil
+	}
+
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	for _, userID := range userIDs {
+		delete(ls.loading, userID)
+	}
+	if err != nil {
+		slog.Warn("failed to load scrobbler links", "guild_id", guildID, "users", len(userIDs), "error", err)
+		return
+	}
+
+	for _, userID := range userIDs {
+		ls.links[userID] = links[userID]
+		if ls.finished {
+			continue
+		}
+		for _, link := range links[userID] {
+			go scrobble.SendNowPlaying(link, ls.listen)
+		}
+	}
+}
+
+func (ls *listeningSession) finish(guildID string) {
+	ls.pending.Wait()
+
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	repo := database.NewScrobbleRepository()
+	queued := 0
+	for userID, listened := range ls.listened {
+		if listened < ls.threshold {
+			continue
+		}
+		for _, link := range ls.links[userID] {
+			err := repo.Enqueue(database.QueuedScrobble{
+				UserID:     userID,
+				Service:    link.Service,
+				Artist:     ls.listen.Artist,
+				Track:      ls.listen.Track,
+				DurationMS: ls.listen.Duration.Milliseconds(),
+				OriginURL:  ls.listen.OriginURL,
+				ListenedAt: ls.listen.ListenedAt,
+			})
+			if err != nil {
+				slog.Error("failed to queue scrobble", "guild_id", guildID, "user_id", userID, "service", link.Service, "error", err)
+				continue
+			}
+			queued++
+		}
+	}
+	if queued > 0 {
+		scrobble.DefaultWorker.Notify()
+	}
+}
+
+func presentListeners(s *discordgo.Session, guildID string) []string {
+	if s == nil || s.State == nil || s.State.User == nil {
+		return nil
+	}
+	botID := s.State.User.ID
+
+	guild, err := s.State.Guild(guildID)
+	if err != nil {
+		return nil
+	}
+
+	s.State.RLock()
+	defer s.State.RUnlock()
+
+	channelID := ""
+	for _, state := range guild.VoiceStates {
+		if state.UserID == botID {
+			channelID = state.ChannelID
+			break
+		}
+	}
+	if channelID == "" {
+		return nil
+	}
+
+	listeners := []string{}
+	for _, state := range guild.VoiceStates {
+		if state.ChannelID != channelID || state.UserID == botID || state.Deaf || state.SelfDeaf {
+			continue
+		}
+		if state.Member != nil && state.Member.User != nil && state.Member.User.Bot {
+			continue
+		}
+		listeners = append(listeners, state.UserID)
+	}
+	return listeners
+}
+
+func ForgetUser(userID string) {
+	sessions.mu.Lock()
+	active := make([]*listeningSession, 0, len(sessions.byGuild))
+	for _, ls := range sessions.byGuild {
+		active = append(active, ls)
+	}
+	sessions.mu.Unlock()
+
+	for _, ls := range active {
+		ls.mu.Lock()
+		delete(ls.links, userID)
+		ls.mu.Unlock()
+	}
+}
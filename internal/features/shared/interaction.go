@@ -1,9 +1,8 @@
 package shared
 
 import (
-	"log"
-
 	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/logging"
 )
 
 var accentColor = 0xC9A0FF
@@ -35,7 +34,7 @@ func RespondEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, cont
 		},
 	})
 	if err != nil {
-		log.Printf("failed to respond: %v", err)
+		logging.ForInteraction(s, i).Warn("failed to respond", "error", err)
 	}
 }
 
@@ -66,6 +65,15 @@ func GetOptionInt64(options []*discordgo.ApplicationCommandInteractionDataOption
 	return 0
 }
 
+func GetOptionBool(options []*discordgo.ApplicationCommandInteractionDataOption, name string) bool {
+	for _, opt := range options {
+		if opt.Name == name {
+			return opt.BoolValue()
+		}
+	}
+	return false
+}
+
 func GetInteractionUserID(i *discordgo.InteractionCreate) string {
 	if i == nil {
 		return ""
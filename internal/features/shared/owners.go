@@ -0,0 +1,33 @@
+package shared
+
+import "sync"
+
+var owners = struct {
+	mu  sync.RWMutex
+	ids map[string]bool
+}{
+	ids: make(map[string]bool),
+}
+
+func SetOwnerIDs(ids []string) {
+	next := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if id != "" {
+			next[id] = true
+		}
+	}
+
+	owners.mu.Lock()
+	owners.ids = next
+	owners.mu.Unlock()
+}
+
+func IsOwner(userID string) bool {
+	if userID == "" {
+		return false
+	}
+
+	owners.mu.RLock()
+	defer owners.mu.RUnlock()
+	return owners.ids[userID]
+}
@@ -0,0 +1,33 @@
+package shared
+
+import (
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const DJRoleName = "DJ"
+
+func IsDJ(s *discordgo.Session, i *discordgo.InteractionCreate) bool {
+	if i == nil || i.Member == nil || i.GuildID == "" {
+		return false
+	}
+
+	if i.Member.Permissions&(discordgo.PermissionAdministrator|discordgo.PermissionManageGuild) != 0 {
+		return true
+	}
+
+	if s == nil {
+		return false
+	}
+	for _, roleID := range i.Member.Roles {
+		role, err := s.State.Role(i.GuildID, roleID)
+		if err != nil || role == nil {
+			continue
+		}
+		if strings.EqualFold(role.Name, DJRoleName) {
+			return true
+		}
+	}
+	return false
+}
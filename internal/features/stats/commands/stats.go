@@ -0,0 +1,27 @@
+package commands
+
+import (
+	"github.com/bwmarrin/discordgo"
+	shared "github.com/hxnx/tunebot/internal/features/shared"
+	"github.com/hxnx/tunebot/internal/features/stats"
+)
+
+func Guild(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	if i.GuildID == "" {
+		shared.RespondEphemeral(s, i, "이 명령어는 서버에서만 사용할 수 있습니다.")
+		return
+	}
+
+	window := stats.FindWindow(shared.GetOptionString(options, "기간"))
+	stats.RespondStats(s, i, "", window, discordgo.InteractionResponseDeferredChannelMessageWithSource)
+}
+
+func Me(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	if i.GuildID == "" {
+		shared.RespondEphemeral(s, i, "이 명령어는 서버에서만 사용할 수 있습니다.")
+		return
+	}
+
+	window := stats.FindWindow(shared.GetOptionString(options, "기간"))
+	stats.RespondStats(s, i, shared.GetInteractionUserID(i), window, discordgo.InteractionResponseDeferredChannelMessageWithSource)
+}
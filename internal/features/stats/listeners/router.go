@@ -0,0 +1,37 @@
+package listeners
+
+import (
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	shared "github.com/hxnx/tunebot/internal/features/shared"
+	"github.com/hxnx/tunebot/internal/features/stats"
+)
+
+func RouteStatsComponent(s *discordgo.Session, i *discordgo.InteractionCreate) bool {
+	if i.Type != discordgo.InteractionMessageComponent {
+		return false
+	}
+
+	customID := i.MessageComponentData().CustomID
+	if !strings.HasPrefix(customID, stats.ComponentPrefix) {
+		return false
+	}
+
+	HandleStatsComponent(s, i)
+	return true
+}
+
+func HandleStatsComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	scope, window, ok := stats.ParseComponentID(i.MessageComponentData().CustomID)
+	if !ok || i.GuildID == "" {
+		shared.RespondEphemeral(s, i, "유효하지 않은 통계 요청입니다.")
+		return
+	}
+
+	userID := ""
+	if scope == stats.ScopeMe {
+		userID = shared.GetInteractionUserID(i)
+	}
+	stats.RespondStats(s, i, userID, window, discordgo.InteractionResponseDeferredMessageUpdate)
+}
@@ -0,0 +1,360 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/database"
+	"github.com/hxnx/tunebot/internal/logging"
+	"github.com/hxnx/tunebot/internal/music"
+)
+
+const (
+	ComponentPrefix = "stats:"
+	ScopeGuild      = "guild"
+	ScopeMe         = "me"
+
+	leaderboardSize = 5
+	maxTitleRunes   = 60
+	busiestHours    = 3
+)
+
+var AccentColor = 0x5B8DEF
+
+type Window struct {
+	Key   string
+	Label string
+	Span  time.Duration
+}
+
+var Windows = []Window{
+	{Key: "7d", Label: "최근 7일", Span: 7 * 24 * time.Hour},
+	{Key: "30d", Label: "최근 30일", Span: 30 * 24 * time.Hour},
+	{Key: "all", Label: "전체 기간"},
+}
+
+var statsLocation = loadLocation()
+
+var linkTitleReplacer = strings.NewReplacer("[", "(", "]", ")")
+
+func loadLocation() *time.Location {
+	location, err := time.LoadLocation("Asia/Seoul")
+	if err != nil {
+		return time.FixedZone("KST", 9*60*60)
+	}
+	return location
+}
+
+func FindWindow(key string) Window {
+	for _, window := range Windows {
+		if window.Key == key {
+			return window
+		}
+	}
+	return Windows[0]
+}
+
+func (w Window) Since(now time.Time) time.Time {
+	if w.Span <= 0 {
+		return time.Time{}
+	}
+	return now.Add(-w.Span)
+}
+
+func ComponentID(scope string, window Window) string {
+	return ComponentPrefix + scope + ":" + window.Key
+}
+
+func ParseComponentID(customID string) (string, Window, bool) {
+	rest, ok := strings.CutPrefix(customID, ComponentPrefix)
+	if !ok {
+		return "", Window{}, false
+	}
+	scope, key, ok := strings.Cut(rest, ":")
+	if !ok || (scope != ScopeGuild && scope != ScopeMe) {
+		return "", Window{}, false
+	}
+	return scope, FindWindow(key), true
+}
+
+func Load(guildID string, userID string, window Window) (database.HistoryStats, error) {
+	return database.NewHistoryRepository().Stats(database.HistoryStatsFilter{
+		GuildID:  guildID,
+		UserID:   userID,
+		Since:    window.Since(time.Now()),
+		Location: statsLocation,
+		Limit:    leaderboardSize,
+	})
+}
+
+func BuildStatsComponents(s *discordgo.Session, guildID string, userID string, window Window, stats database.HistoryStats) []discordgo.MessageComponent {
+	divider := true
+	spacing := discordgo.SeparatorSpacingSizeSmall
+	scope := ScopeGuild
+
+	var components []discordgo.MessageComponent
+	if userID == "" {
+		components = append(components,
+			discordgo.TextDisplay{Content: fmt.Sprintf("**📊 %s 청취 통계**", guildName(s, guildID))},
+			discordgo.TextDisplay{Content: fmt.Sprintf("%s · 재생 %d곡 · 청취 %s · 요청자 %d명", window.Label, stats.Summary.Plays, formatListened(stats.Summary.ListenedMS), stats.Summary.Requesters)},
+		)
+	} else {
+		scope = ScopeMe
+		summary := fmt.Sprintf("%s · 요청 %d곡 · 청취 %s", window.Label, stats.Summary.Plays, formatListened(stats.Summary.ListenedMS))
+		if stats.Rank > 0 {
+			summary += fmt.Sprintf(" · 서버 순위 %d위", stats.Rank)
+		}
+		components = append(components,
+			discordgo.TextDisplay{Content: fmt.Sprintf("**📊 <@%s> 님의 청취 통계**", userID)},
+			discordgo.TextDisplay{Content: summary},
+		)
+	}
+
+	if stats.Summary.Plays == 0 {
+		components = append(components,
+			discordgo.Separator{Divider: &divider, Spacing: &spacing},
+			discordgo.TextDisplay{Content: "이 기간에 기록된 재생 기록이 없습니다."},
+		)
+	} else {
+		trackHeading := "**인기 곡**"
+		if userID != "" {
+			trackHeading = "**가장 많이 요청한 곡**"
+		}
+		components = append(components,
+			discordgo.Separator{Divider: &divider, Spacing: &spacing},
+			discordgo.TextDisplay{Content: trackHeading},
+			discordgo.TextDisplay{Content: buildTrackList(stats.TopTracks)},
+		)
+		if len(stats.Requesters) > 0 {
+			components = append(components,
+				discordgo.Separator{Divider: &divider, Spacing: &spacing},
+				discordgo.TextDisplay{Content: "**많이 요청한 사람**"},
+				discordgo.TextDisplay{Content: buildRequesterList(stats.Requesters)},
+			)
+		}
+		components = append(components,
+			discordgo.Separator{Divider: &divider, Spacing: &spacing},
+			discordgo.TextDisplay{Content: "**붐비는 시간대** (KST)"},
+			discordgo.TextDisplay{Content: buildHourChart(stats.Hours)},
+			discordgo.Separator{Divider: &divider, Spacing: &spacing},
+			discordgo.TextDisplay{Content: "**출처별 재생**"},
+			discordgo.TextDisplay{Content: buildSourceList(stats.Sources, stats.Summary.Plays)},
+		)
+	}
+
+	footer := fmt.Sprintf("갱신됨 <t:%d:R>", time.Now().Unix())
+	if userID != "" {
+		footer = "-# 내가 요청한 곡 기준입니다 · " + footer
+	}
+	components = append(components,
+		discordgo.TextDisplay{Content: footer},
+		buildWindowButtons(scope, window),
+	)
+
+	return []discordgo.MessageComponent{
+		discordgo.Container{
+			AccentColor: &AccentColor,
+			Components:  components,
+		},
+	}
+}
+
+func buildTrackList(tracks []database.TrackStat) string {
+	lines := make([]string, 0, len(tracks))
+	for idx, track := range tracks {
+		title := strings.TrimSpace(track.Title)
+		if title == "" {
+			title = "알 수 없는 제목"
+		}
+		title = truncateRunes(title, maxTitleRunes)
+		if link := (music.Track{URL: track.URL}).LinkURL(); link != "" {
+			title = fmt.Sprintf("[%s](%s)", linkTitleReplacer.Replace(title), link)
+		}
+		lines = append(lines, fmt.Sprintf("%d. %s · %d회", idx+1, title, track.Plays))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func buildRequesterList(requesters []database.RequesterStat) string {
+	medals := []string{"🥇", "🥈", "🥉"}
+	lines := make([]string, 0, len(requesters))
+	for idx, requester := range requesters {
+		prefix := fmt.Sprintf("%d.", idx+1)
+		if idx < len(medals) {
+			prefix = medals[idx]
+		}
+		lines = append(lines, fmt.Sprintf("%s <@%s> · %d곡 · %s", prefix, requester.UserID, requester.Plays, formatListened(requester.ListenedMS)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func buildHourChart(hours [24]int) string {
+	levels := []rune("▁▂▃▄▅▆▇█")
+	peak := slices.Max(hours[:])
+
+	var bars strings.Builder
+	for _, plays := range hours {
+		if plays == 0 || peak == 0 {
+			bars.WriteRune(' ')
+			continue
+		}
+		bars.WriteRune(levels[(plays*(len(levels)-1)+peak-1)/peak])
+	}
+
+	order := make([]int, 0, len(hours))
+	for hour, plays := range hours {
+		if plays > 0 {
+			order = append(order, hour)
+		}
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return hours[b] - hours[a] })
+
+	busiest := make([]string, 0, busiestHours)
+	for _, hour := range order[:min(len(order), busiestHours)] {
+		busiest = append(busiest, fmt.Sprintf("%d시 (%d곡)", hour, hours[hour]))
+	}
+
+	return fmt.Sprintf("```\n%s\n0     6     12    18  23\n```가장 붐비는 시간: %s", bars.String(), strings.Join(busiest, " · "))
+}
+
+func buildSourceList(sources []database.SourceStat, total int) string {
+	lines := make([]string, 0, len(sources))
+	for _, source := range sources {
+		share := 0
+		if total > 0 {
+			share = source.Plays * 100 / total
+		}
+		lines = append(lines, fmt.Sprintf("%s %d%% · %d곡 · %s", sourceLabel(source.Source), share, source.Plays, formatListened(source.ListenedMS)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func buildWindowButtons(scope string, current Window) discordgo.ActionsRow {
+	buttons := make([]discordgo.MessageComponent, 0, len(Windows))
+	for _, window := range Windows {
+		style := discordgo.SecondaryButton
+		if window.Key == current.Key {
+			style = discordgo.PrimaryButton
+		}
+		buttons = append(buttons, discordgo.Button{
+			Label:    window.Label,
+			Style:    style,
+			CustomID: ComponentID(scope, window),
+			Disabled: window.Key == current.Key,
+		})
+	}
+	return discordgo.ActionsRow{Components: buttons}
+}
+
+func sourceLabel(source string) string {
+	switch music.TrackSource(source) {
+	case music.TrackSourceYouTube:
+		return "YouTube"
+	case music.TrackSourceSpotify:
+		return "Spotify"
+	case music.TrackSourceSoundCloud:
+		return "SoundCloud"
+	case music.TrackSourceBandcamp:
+		return "Bandcamp"
+	case music.TrackSourceVimeo:
+		return "Vimeo"
+	case music.TrackSourceTwitch:
+		return "Twitch"
+	case music.TrackSourceMixcloud:
+		return "Mixcloud"
+	case music.TrackSourceAppleMusic:
+		return "Apple Music"
+	case music.TrackSourceDeezer:
+		return "Deezer"
+	case music.TrackSourceTidal:
+		return "TIDAL"
+	case music.TrackSourceHTTP:
+		return "직접 링크"
+	case music.TrackSourceLocal:
+		return "로컬 라이브러리"
+	default:
+		return "기타"
+	}
+}
+
+func formatListened(ms int64) string {
+	total := time.Duration(ms) * time.Millisecond
+	hours := int(total.Hours())
+	minutes := int(total.Minutes()) % 60
+	if hours > 0 {
+		return fmt.Sprintf("%d시간 %d분", hours, minutes)
+	}
+	return fmt.Sprintf("%d분", minutes)
+}
+
+func truncateRunes(value string, limit int) string {
+	if utf8.RuneCountInString(value) <= limit {
+		return value
+	}
+	runes := []rune(value)
+	return string(runes[:limit-1]) + "…"
+}
+
+func guildName(s *discordgo.Session, guildID string) string {
+	if s != nil && s.State != nil {
+		if guild, err := s.State.Guild(guildID); err == nil && guild.Name != "" {
+			return guild.Name
+		}
+	}
+	return "서버"
+}
+
+func RespondStats(s *discordgo.Session, i *discordgo.InteractionCreate, userID string, window Window, respType discordgo.InteractionResponseType) {
+	if s == nil || i == nil {
+		return
+	}
+
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: respType,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	}); err != nil {
+		logging.ForInteraction(s, i).Warn("failed to defer stats response", "error", err)
+		return
+	}
+
+	stats, err := Load(i.GuildID, userID, window)
+	var components []discordgo.MessageComponent
+	if err != nil {
+		if !errors.Is(err, database.ErrHistoryUnavailable) {
+			logging.ForInteraction(s, i).Error("failed to load play stats", "error", err)
+		}
+		components = buildErrorComponents(err)
+	} else {
+		components = BuildStatsComponents(s, i.GuildID, userID, window, stats)
+	}
+
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Components: &components,
+		Flags:      discordgo.MessageFlagsIsComponentsV2,
+	}); err != nil {
+		logging.ForInteraction(s, i).Warn("failed to respond to stats", "error", err)
+	}
+}
+
+func buildErrorComponents(err error) []discordgo.MessageComponent {
+	message := "통계를 불러오지 못했습니다. 잠시 후 다시 시도해 주세요."
+	if errors.Is(err, database.ErrHistoryUnavailable) {
+		message = "데이터베이스가 설정되지 않아 통계를 볼 수 없습니다."
+	}
+	return []discordgo.MessageComponent{
+		discordgo.Container{
+			AccentColor: &AccentColor,
+			Components: []discordgo.MessageComponent{
+				discordgo.TextDisplay{Content: "**📊 청취 통계**"},
+				discordgo.TextDisplay{Content: message},
+			},
+		},
+	}
+}
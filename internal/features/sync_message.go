@@ -1,13 +1,16 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/features/owner"
+	shared "github.com/hxnx/tunebot/internal/features/shared"
 )
 
-const syncOwnerID = "1447268601496338588"
+var errApplicationIDUnknown = errors.New("application ID is unknown")
 
 func HandleSyncMessage(s *discordgo.Session, m *discordgo.MessageCreate) bool {
 	if s == nil || m == nil || m.Author == nil {
@@ -20,29 +23,67 @@ func HandleSyncMessage(s *discordgo.Session, m *discordgo.MessageCreate) bool {
 		return false
 	}
 
-	if strings.TrimSpace(m.Content) != "!sync" {
+	fields := strings.Fields(m.Content)
+	if len(fields) == 0 || fields[0] != "!sync" {
 		return false
 	}
 
-	if m.Author.ID != syncOwnerID {
-		_, _ = s.ChannelMessageSend(m.ChannelID, "이 명령어는 봇 소유자만 사용할 수 있습니다.")
+	if !shared.IsOwner(m.Author.ID) {
+		_, _ = s.ChannelMessageSend(m.ChannelID, owner.NotOwnerMessage)
 		return true
 	}
 
+	global := len(fields) > 1 && strings.EqualFold(fields[1], "global")
+	_, _ = s.ChannelMessageSend(m.ChannelID, syncCommands(s, m.Author.ID, m.GuildID, global))
+	return true
+}
+
+func handleOwnerSync(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	if !owner.RequireOwner(s, i) {
+		return
+	}
+
+	global := shared.GetOptionString(options, "범위") == "global"
+	if !global && i.GuildID == "" {
+		shared.RespondEphemeral(s, i, "서버 동기화는 서버에서만 사용할 수 있습니다.")
+		return
+	}
+
+	shared.RespondEphemeral(s, i, syncCommands(s, shared.GetInteractionUserID(i), i.GuildID, global))
+}
+
+func syncCommands(s *discordgo.Session, userID string, guildID string, global bool) string {
 	appID := ""
 	if s.State != nil && s.State.User != nil {
 		appID = s.State.User.ID
 	}
+
+	target := guildID
+	if global {
+		target = ""
+	}
+
+	var err error
 	if appID == "" {
-		_, _ = s.ChannelMessageSend(m.ChannelID, "슬래시 커맨드 동기화 실패: 애플리케이션 ID를 확인할 수 없습니다.")
-		return true
+		err = errApplicationIDUnknown
+	} else {
+		_, err = RegisterCommands(s, appID, target)
 	}
 
-	if _, err := RegisterCommands(s, appID, m.GuildID); err != nil {
-		_, _ = s.ChannelMessageSend(m.ChannelID, fmt.Sprintf("슬래시 커맨드 동기화 실패: %v", err))
-		return true
+	action := "commands.sync_guild"
+	if global {
+		action = "commands.sync_global"
 	}
+	owner.Audit(s, userID, guildID, action, target, err)
 
-	_, _ = s.ChannelMessageSend(m.ChannelID, "슬래시 커맨드를 이 서버에 동기화했습니다.")
-	return true
+	switch {
+	case errors.Is(err, errApplicationIDUnknown):
+		return "슬래시 커맨드 동기화 실패: 애플리케이션 ID를 확인할 수 없습니다."
+	case err != nil:
+		return fmt.Sprintf("슬래시 커맨드 동기화 실패: %v", err)
+	case global:
+		return "슬래시 커맨드를 전역으로 동기화했습니다. 반영까지 시간이 걸릴 수 있습니다."
+	default:
+		return "슬래시 커맨드를 이 서버에 동기화했습니다."
+	}
 }
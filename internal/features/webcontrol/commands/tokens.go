@@ -0,0 +1,159 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/database"
+	shared "github.com/hxnx/tunebot/internal/features/shared"
+	"github.com/hxnx/tunebot/internal/features/webcontrol"
+	"github.com/hxnx/tunebot/internal/logging"
+	"github.com/hxnx/tunebot/internal/webapi"
+)
+
+func IssueToken(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	if !webcontrol.RequireManager(s, i) {
+		return
+	}
+
+	name := strings.TrimSpace(shared.GetOptionString(options, "이름"))
+	if name == "" {
+		shared.RespondEphemeral(s, i, "토큰 이름을 입력해 주세요.")
+		return
+	}
+	if utf8.RuneCountInString(name) > webcontrol.MaxNameLength {
+		shared.RespondEphemeral(s, i, fmt.Sprintf("토큰 이름은 최대 %d자까지 가능합니다.", webcontrol.MaxNameLength))
+		return
+	}
+
+	repo := database.NewAPITokenRepository()
+	existing, err := repo.ListByGuild(i.GuildID)
+	if err != nil {
+		respondStorageError(s, i, "api token list failed", err)
+		return
+	}
+	if len(existing) >= webcontrol.MaxTokensPerGuild {
+		shared.RespondEphemeral(s, i, fmt.Sprintf("서버당 최대 %d개의 토큰만 발급할 수 있습니다. 사용하지 않는 토큰을 폐기해 주세요.", webcontrol.MaxTokensPerGuild))
+		return
+	}
+
+	plain, hash, err := webapi.GenerateToken()
+	if err != nil {
+		logging.ForInteraction(s, i).Error("api token generation failed", "error", err)
+		shared.RespondEphemeral(s, i, "토큰을 생성하지 못했습니다.")
+		return
+	}
+	overlay, overlayHash, err := webapi.GenerateOverlayToken()
+	if err != nil {
+		logging.ForInteraction(s, i).Error("overlay token generation failed", "error", err)
+		shared.RespondEphemeral(s, i, "토큰을 생성하지 못했습니다.")
+		return
+	}
+
+	userID := shared.GetInteractionUserID(i)
+	token, err := repo.Create(i.GuildID, name, hash, overlayHash, userID)
+	if err != nil {
+		respondStorageError(s, i, "api token create failed", err)
+		return
+	}
+	logging.ForInteraction(s, i).Info("api token issued", "token_id", token.ID)
+
+	lines := []string{
+		fmt.Sprintf("**%s** (#%d) 토큰을 발급했습니다. 이 토큰은 다시 표시되지 않으니 안전한 곳에 보관해 주세요.", token.Name, token.ID),
+		fmt.Sprintf("```\n%s\n```", plain),
+		"요청 헤더에 `Authorization: Bearer <토큰>`을 추가해 사용합니다. 실시간 WebSocket(`/ws`)은 `?token=` 쿼리로도 인증할 수 있습니다.",
+		"곡 추가 시 봇이 음성 채널에 없으면 토큰 발급자가 있는 음성 채널로 입장합니다.",
+	}
+	if url := webcontrol.GuildAPIURL(i.GuildID); url != "" {
+		lines = append(lines, fmt.Sprintf("API 주소: `%s`", url))
+	}
+	if url := webcontrol.OverlayURL(i.GuildID, overlay); url != "" {
+		lines = append(lines, fmt.Sprintf("OBS 오버레이 (브라우저 소스): `%s`", url))
+		lines = append(lines, "오버레이 주소에는 실시간 상태 조회만 가능한 읽기 전용 토큰이 들어 있으며, API 토큰을 폐기하면 함께 폐기됩니다.")
+	}
+	respondComponents(s, i, "🔑 **API 토큰 발급**", strings.Join(lines, "\n"))
+}
+
+func ListTokens(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if !webcontrol.RequireManager(s, i) {
+		return
+	}
+
+	tokens, err := database.NewAPITokenRepository().ListByGuild(i.GuildID)
+	if err != nil {
+		respondStorageError(s, i, "api token list failed", err)
+		return
+	}
+
+	lines := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		lastUsed := "사용 기록 없음"
+		if token.LastUsedAt != nil {
+			lastUsed = fmt.Sprintf("마지막 사용 <t:%d:R>", token.LastUsedAt.Unix())
+		}
+		lines = append(lines, fmt.Sprintf("`#%d` **%s** · <@%s> · 발급 <t:%d:R> · %s",
+			token.ID, token.Name, token.CreatedBy, token.CreatedAt.Unix(), lastUsed))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "발급된 토큰이 없습니다.")
+	}
+
+	respondComponents(s, i, fmt.Sprintf("🔑 **API 토큰** (%d/%d)", len(tokens), webcontrol.MaxTokensPerGuild), strings.Join(lines, "\n"))
+}
+
+func RevokeToken(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	if !webcontrol.RequireManager(s, i) {
+		return
+	}
+
+	id := shared.GetOptionInt64(options, "번호")
+	err := database.NewAPITokenRepository().Revoke(i.GuildID, id)
+	if errors.Is(err, database.ErrAPITokenNotFound) {
+		shared.RespondEphemeral(s, i, "해당 번호의 토큰을 찾을 수 없습니다.")
+		return
+	}
+	if err != nil {
+		respondStorageError(s, i, "api token revoke failed", err)
+		return
+	}
+
+	logging.ForInteraction(s, i).Info("api token revoked", "token_id", id)
+	shared.RespondEphemeral(s, i, fmt.Sprintf("`#%d` 토큰을 폐기했습니다.", id))
+}
+
+func respondStorageError(s *discordgo.Session, i *discordgo.InteractionCreate, msg string, err error) {
+	if errors.Is(err, database.ErrAPITokenUnavailable) {
+		shared.RespondEphemeral(s, i, "토큰 저장소를 사용할 수 없습니다. 데이터베이스 설정을 확인해 주세요.")
+		return
+	}
+	logging.ForInteraction(s, i).Error(msg, "error", err)
+	shared.RespondEphemeral(s, i, "토큰 정보를 처리하지 못했습니다.")
+}
+
+func respondComponents(s *discordgo.Session, i *discordgo.InteractionCreate, title string, body string) {
+	divider := true
+	spacing := discordgo.SeparatorSpacingSizeSmall
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Components: []discordgo.MessageComponent{
+				discordgo.Container{
+					AccentColor: &webcontrol.AccentColor,
+					Components: []discordgo.MessageComponent{
+						discordgo.TextDisplay{Content: title},
+						discordgo.Separator{Divider: &divider, Spacing: &spacing},
+						discordgo.TextDisplay{Content: body},
+					},
+				},
+			},
+			Flags: discordgo.MessageFlagsIsComponentsV2 | discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		logging.ForInteraction(s, i).Error("web control respond failed", "error", err)
+	}
+}
@@ -0,0 +1,62 @@
+package webcontrol
+
+import (
+	"fmt"
+	"net/url"
+	"sync/atomic"
+
+	"github.com/bwmarrin/discordgo"
+	shared "github.com/hxnx/tunebot/internal/features/shared"
+	"github.com/hxnx/tunebot/internal/webapi"
+)
+
+const (
+	MaxTokensPerGuild = 10
+	MaxNameLength     = 32
+	DisabledMessage   = "웹 제어 API가 비활성화되어 있습니다. 봇 관리자에게 문의해 주세요."
+	NotDJMessage      = "API 토큰은 DJ 역할 또는 서버 관리 권한이 있는 사용자만 관리할 수 있습니다."
+)
+
+var AccentColor = 0x4FB3BF
+
+var enabled atomic.Bool
+
+func SetEnabled(value bool) {
+	enabled.Store(value)
+}
+
+func Enabled() bool {
+	return enabled.Load()
+}
+
+func RequireManager(s *discordgo.Session, i *discordgo.InteractionCreate) bool {
+	if i.GuildID == "" {
+		shared.RespondEphemeral(s, i, "이 명령어는 서버에서만 사용할 수 있습니다.")
+		return false
+	}
+	if !Enabled() {
+		shared.RespondEphemeral(s, i, DisabledMessage)
+		return false
+	}
+	if !shared.IsDJ(s, i) {
+		shared.RespondEphemeral(s, i, NotDJMessage)
+		return false
+	}
+	return true
+}
+
+func GuildAPIURL(guildID string) string {
+	base := webapi.PublicURL()
+	if base == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/api/v1/guilds/%s", base, guildID)
+}
+
+func OverlayURL(guildID string, token string) string {
+	base := webapi.PublicURL()
+	if base == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/overlay/%s?token=%s", base, guildID, url.QueryEscape(token))
+}
@@ -0,0 +1,182 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/database"
+	shared "github.com/hxnx/tunebot/internal/features/shared"
+	"github.com/hxnx/tunebot/internal/features/webhooks"
+	"github.com/hxnx/tunebot/internal/logging"
+	"github.com/hxnx/tunebot/internal/webhook"
+)
+
+func Add(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	if !webhooks.RequireAdmin(s, i) {
+		return
+	}
+
+	url, err := webhook.ValidateURL(shared.GetOptionString(options, "주소"))
+	if errors.Is(err, webhook.ErrPrivateAddress) {
+		shared.RespondEphemeral(s, i, "내부 네트워크 주소로는 웹훅을 보낼 수 없습니다.")
+		return
+	}
+	if err != nil {
+		shared.RespondEphemeral(s, i, "올바른 http(s) 주소를 입력해 주세요.")
+		return
+	}
+
+	repo := database.NewWebhookRepository()
+	existing, err := repo.ListByGuild(i.GuildID)
+	if err != nil {
+		respondStorageError(s, i, "webhook list failed", err)
+		return
+	}
+	if len(existing) >= webhooks.MaxWebhooksPerGuild {
+		shared.RespondEphemeral(s, i, fmt.Sprintf("서버당 최대 %d개의 웹훅만 등록할 수 있습니다.", webhooks.MaxWebhooksPerGuild))
+		return
+	}
+
+	secret, err := webhook.GenerateSecret()
+	if err != nil {
+		logging.ForInteraction(s, i).Error("webhook secret generation failed", "error", err)
+		shared.RespondEphemeral(s, i, "서명 키를 생성하지 못했습니다.")
+		return
+	}
+
+	hook, err := repo.Create(i.GuildID, url, secret, shared.GetInteractionUserID(i))
+	if err != nil {
+		respondStorageError(s, i, "webhook create failed", err)
+		return
+	}
+	logging.ForInteraction(s, i).Info("webhook registered", "webhook_id", hook.ID)
+
+	lines := []string{
+		fmt.Sprintf("`#%d` 웹훅을 등록했습니다: %s", hook.ID, hook.URL),
+		"서명 키는 다시 표시되지 않으니 안전한 곳에 보관해 주세요.",
+		fmt.Sprintf("```\n%s\n```", secret),
+		fmt.Sprintf("요청마다 `%s`(유닉스 시각)와 `%s` 헤더가 포함됩니다. 서명은 `<시각>.<본문>`을 서명 키로 HMAC-SHA256 한 값입니다.", webhook.TimestampHeader, webhook.SignatureHeader),
+		fmt.Sprintf("이벤트: `%s`, `%s`, `%s`, `%s`", webhook.EventTrackStarted, webhook.EventTrackEnded, webhook.EventQueueAdded, webhook.EventPlaybackStopped),
+	}
+	respondComponents(s, i, "🪝 **웹훅 등록**", strings.Join(lines, "\n"))
+}
+
+func List(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if !webhooks.RequireAdmin(s, i) {
+		return
+	}
+
+	hooks, err := database.NewWebhookRepository().ListByGuild(i.GuildID)
+	if err != nil {
+		respondStorageError(s, i, "webhook list failed", err)
+		return
+	}
+
+	lines := make([]string, 0, len(hooks))
+	for _, hook := range hooks {
+		status := []string{fmt.Sprintf("등록 <t:%d:R>", hook.CreatedAt.Unix())}
+		if hook.LastSuccessAt != nil {
+			status = append(status, fmt.Sprintf("마지막 성공 <t:%d:R>", hook.LastSuccessAt.Unix()))
+		}
+		if hook.LastFailureAt != nil {
+			status = append(status, fmt.Sprintf("마지막 실패 <t:%d:R>", hook.LastFailureAt.Unix()))
+		}
+		if hook.Pending > 0 {
+			status = append(status, fmt.Sprintf("대기 %d건", hook.Pending))
+		}
+		if hook.DeadLetters > 0 {
+			status = append(status, fmt.Sprintf("전송 포기 %d건", hook.DeadLetters))
+		}
+		lines = append(lines, fmt.Sprintf("`#%d` %s\n-# %s", hook.ID, hook.URL, strings.Join(status, " · ")))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "등록된 웹훅이 없습니다.")
+	}
+
+	respondComponents(s, i, fmt.Sprintf("🪝 **웹훅** (%d/%d)", len(hooks), webhooks.MaxWebhooksPerGuild), strings.Join(lines, "\n"))
+}
+
+func Delete(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	if !webhooks.RequireAdmin(s, i) {
+		return
+	}
+
+	id := shared.GetOptionInt64(options, "번호")
+	err := database.NewWebhookRepository().Delete(i.GuildID, id)
+	if errors.Is(err, database.ErrWebhookNotFound) {
+		shared.RespondEphemeral(s, i, "해당 번호의 웹훅을 찾을 수 없습니다.")
+		return
+	}
+	if err != nil {
+		respondStorageError(s, i, "webhook delete failed", err)
+		return
+	}
+
+	logging.ForInteraction(s, i).Info("webhook deleted", "webhook_id", id)
+	shared.RespondEphemeral(s, i, fmt.Sprintf("`#%d` 웹훅을 삭제했습니다. 대기 중인 전송도 함께 취소되었습니다.", id))
+}
+
+func Test(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	if !webhooks.RequireAdmin(s, i) {
+		return
+	}
+
+	id := shared.GetOptionInt64(options, "번호")
+	userID := shared.GetInteractionUserID(i)
+	payload, err := webhook.Encode(webhook.EventPing, i.GuildID, time.Now(), webhook.PingData{WebhookID: id, RequestedBy: userID})
+	if err != nil {
+		logging.ForInteraction(s, i).Error("webhook ping encode failed", "error", err)
+		shared.RespondEphemeral(s, i, "테스트 이벤트를 만들지 못했습니다.")
+		return
+	}
+
+	err = database.NewWebhookRepository().EnqueueForWebhook(i.GuildID, id, webhook.EventPing, string(payload))
+	if errors.Is(err, database.ErrWebhookNotFound) {
+		shared.RespondEphemeral(s, i, "해당 번호의 웹훅을 찾을 수 없습니다.")
+		return
+	}
+	if err != nil {
+		respondStorageError(s, i, "webhook ping enqueue failed", err)
+		return
+	}
+	webhook.DefaultWorker.Notify()
+
+	shared.RespondEphemeral(s, i, fmt.Sprintf("`#%d` 웹훅으로 `%s` 이벤트를 보냈습니다. 결과는 `/웹훅 목록`에서 확인할 수 있습니다.", id, webhook.EventPing))
+}
+
+func respondStorageError(s *discordgo.Session, i *discordgo.InteractionCreate, msg string, err error) {
+	if errors.Is(err, database.ErrWebhookUnavailable) {
+		shared.RespondEphemeral(s, i, "웹훅 저장소를 사용할 수 없습니다. 데이터베이스 설정을 확인해 주세요.")
+		return
+	}
+	logging.ForInteraction(s, i).Error(msg, "error", err)
+	shared.RespondEphemeral(s, i, "웹훅 정보를 처리하지 못했습니다.")
+}
+
+func respondComponents(s *discordgo.Session, i *discordgo.InteractionCreate, title string, body string) {
+	divider := true
+	spacing := discordgo.SeparatorSpacingSizeSmall
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Components: []discordgo.MessageComponent{
+				discordgo.Container{
+					AccentColor: &webhooks.AccentColor,
+					Components: []discordgo.MessageComponent{
+						discordgo.TextDisplay{Content: title},
+						discordgo.Separator{Divider: &divider, Spacing: &spacing},
+						discordgo.TextDisplay{Content: body},
+					},
+				},
+			},
+			Flags: discordgo.MessageFlagsIsComponentsV2 | discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		logging.ForInteraction(s, i).Error("webhook respond failed", "error", err)
+	}
+}
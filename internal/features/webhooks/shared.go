@@ -0,0 +1,86 @@
+package webhooks
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/database"
+	shared "github.com/hxnx/tunebot/internal/features/shared"
+	"github.com/hxnx/tunebot/internal/music"
+	"github.com/hxnx/tunebot/internal/webhook"
+)
+
+const (
+	MaxWebhooksPerGuild = 5
+	DisabledMessage     = "웹훅 기능이 비활성화되어 있습니다. 봇 관리자에게 문의해 주세요."
+	NotAdminMessage     = "웹훅은 서버 관리 권한이 있는 사용자만 관리할 수 있습니다."
+)
+
+var AccentColor = 0xE0A458
+
+var registerOnce sync.Once
+
+var enabled atomic.Bool
+
+func SetEnabled(value bool) {
+	enabled.Store(value)
+}
+
+func Enabled() bool {
+	return enabled.Load()
+}
+
+func Register(manager *music.PlayerManager) {
+	if manager == nil {
+		return
+	}
+	registerOnce.Do(func() {
+		manager.Subscribe(handlePlayerEvent)
+	})
+}
+
+func RequireAdmin(s *discordgo.Session, i *discordgo.InteractionCreate) bool {
+	if i.GuildID == "" {
+		shared.RespondEphemeral(s, i, "이 명령어는 서버에서만 사용할 수 있습니다.")
+		return false
+	}
+	if !Enabled() {
+		shared.RespondEphemeral(s, i, DisabledMessage)
+		return false
+	}
+	if i.Member == nil || i.Member.Permissions&(discordgo.PermissionAdministrator|discordgo.PermissionManageGuild) == 0 {
+		shared.RespondEphemeral(s, i, NotAdminMessage)
+		return false
+	}
+	return true
+}
+
+func handlePlayerEvent(event music.PlayerEvent) {
+	if !enabled.Load() || event.GuildID == "" {
+		return
+	}
+
+	name, payload, ok, err := webhook.FromPlayerEvent(event)
+	if err != nil {
+		slog.Error("failed to encode webhook payload", "guild_id", event.GuildID, "event", event.Type, "error", err)
+		return
+	}
+	if !ok {
+		return
+	}
+
+	queued, err := database.NewWebhookRepository().EnqueueForGuild(event.GuildID, name, string(payload))
+	if errors.Is(err, database.ErrWebhookUnavailable) {
+		return
+	}
+	if err != nil {
+		slog.Error("failed to queue webhook deliveries", "guild_id", event.GuildID, "event", name, "error", err)
+		return
+	}
+	if queued > 0 {
+		webhook.DefaultWorker.Notify()
+	}
+}
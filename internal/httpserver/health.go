@@ -0,0 +1,39 @@
+package httpserver
+
+import (
+	"net/http"
+
+	"github.com/hxnx/tunebot/internal/shard"
+)
+
+type shardHealthResponse struct {
+	Instance       string         `json:"instance"`
+	ShardCount     int            `json:"shard_count"`
+	MaxConcurrency int            `json:"max_concurrency"`
+	Shards         []shard.Status `json:"shards"`
+}
+
+func ShardHealthHandler(instanceID string, manager *shard.Manager) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		resp := shardHealthResponse{
+			Instance:       instanceID,
+			ShardCount:     manager.ShardCount(),
+			MaxConcurrency: manager.MaxConcurrency(),
+			Shards:         manager.Statuses(),
+		}
+
+		status := http.StatusOK
+		for _, st := range resp.Shards {
+			if st.State != shard.StateReady {
+				status = http.StatusServiceUnavailable
+				break
+			}
+		}
+		WriteJSON(w, status, resp)
+	})
+}
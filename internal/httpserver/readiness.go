@@ -0,0 +1,142 @@
+package httpserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hxnx/tunebot/internal/shard"
+)
+
+const readinessCheckTimeout = 3 * time.Second
+
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+type checkResult struct {
+	Name       string `json:"name"`
+	OK         bool   `json:"ok"`
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+type readinessResponse struct {
+	Instance string        `json:"instance"`
+	Ready    bool          `json:"ready"`
+	Checks   []checkResult `json:"checks"`
+}
+
+type livenessResponse struct {
+	Instance string `json:"instance"`
+	Alive    bool   `json:"alive"`
+	Uptime   string `json:"uptime"`
+}
+
+func LivenessHandler(instanceID string) http.Handler {
+	started := time.Now()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		WriteJSON(w, http.StatusOK, livenessResponse{
+			Instance: instanceID,
+			Alive:    true,
+			Uptime:   time.Since(started).Truncate(time.Second).String(),
+		})
+	})
+}
+
+func ReadinessHandler(instanceID string, checks ...Check) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), readinessCheckTimeout)
+		defer cancel()
+
+		results := make([]checkResult, len(checks))
+		var wg sync.WaitGroup
+		for idx, check := range checks {
+			wg.Add(1)
+			go func(idx int, check Check) {
+				defer wg.Done()
+				results[idx] = runCheck(ctx, check)
+			}(idx, check)
+		}
+		wg.Wait()
+
+		resp := readinessResponse{
+			Instance: instanceID,
+			Ready:    true,
+			Checks:   results,
+		}
+		for _, result := range results {
+			if !result.OK {
+				resp.Ready = false
+				break
+			}
+		}
+
+		status := http.StatusOK
+		if !resp.Ready {
+			status = http.StatusServiceUnavailable
+		}
+		WriteJSON(w, status, resp)
+	})
+}
+
+func runCheck(ctx context.Context, check Check) checkResult {
+	started := time.Now()
+	err := check.Run(ctx)
+	result := checkResult{
+		Name:       check.Name,
+		OK:         err == nil,
+		DurationMS: time.Since(started).Milliseconds(),
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func ShardCheck(manager *shard.Manager) Check {
+	return Check{
+		Name: "shards",
+		Run: func(ctx context.Context) error {
+			if manager == nil {
+				return fmt.Errorf("shard manager is not configured")
+			}
+
+			var pending []string
+			for _, st := range manager.Statuses() {
+				if st.State != shard.StateReady {
+					pending = append(pending, fmt.Sprintf("%d=%s", st.ID, st.State))
+				}
+			}
+			if len(pending) > 0 {
+				return fmt.Errorf("shards not ready: %s", strings.Join(pending, ", "))
+			}
+			return nil
+		},
+	}
+}
+
+func BinaryCheck(name string) Check {
+	return Check{
+		Name: name,
+		Run: func(ctx context.Context) error {
+			_, err := exec.LookPath(name)
+			return err
+		},
+	}
+}
@@ -0,0 +1,95 @@
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+var level = new(slog.LevelVar)
+
+func Setup(levelName, format string) error {
+	if err := SetLevel(levelName); err != nil {
+		return err
+	}
+
+	opts := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", FormatText:
+		handler = slog.NewTextHandler(os.Stderr, opts)
+	case FormatJSON:
+		handler = slog.NewJSONHandler(os.Stderr, opts)
+	default:
+		return fmt.Errorf("unknown log format %q", format)
+	}
+
+	slog.SetDefault(slog.New(handler))
+	return nil
+}
+
+func SetLevel(levelName string) error {
+	parsed, err := ParseLevel(levelName)
+	if err != nil {
+		return err
+	}
+	level.Set(parsed)
+	return nil
+}
+
+func ParseLevel(levelName string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(levelName)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return slog.LevelInfo, fmt.Errorf("unknown log level %q", levelName)
+	}
+}
+
+func ForGuild(s *discordgo.Session, guildID string) *slog.Logger {
+	logger := slog.Default()
+	if guildID != "" {
+		logger = logger.With("guild_id", guildID)
+	}
+	if s != nil {
+		logger = logger.With("shard", s.ShardID)
+	}
+	return logger
+}
+
+func ForInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) *slog.Logger {
+	if i == nil || i.Interaction == nil {
+		return ForGuild(s, "")
+	}
+
+	logger := ForGuild(s, i.GuildID)
+	if userID := interactionUserID(i); userID != "" {
+		logger = logger.With("user_id", userID)
+	}
+	return logger
+}
+
+func interactionUserID(i *discordgo.InteractionCreate) string {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User.ID
+	}
+	if i.User != nil {
+		return i.User.ID
+	}
+	return ""
+}
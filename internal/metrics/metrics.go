@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "tunebot"
+
+var (
+	QueueLength = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "queue_length",
+		Help:      "Number of tracks waiting in a guild queue.",
+	}, []string{"guild_id"})
+
+	ResolveDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "ytdlp_resolve_duration_seconds",
+		Help:      "Latency of yt-dlp invocations by source.",
+		Buckets:   []float64{0.25, 0.5, 1, 2, 4, 8, 15, 30, 60},
+	}, []string{"source", "operation"})
+
+	ResolveFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "ytdlp_resolve_failures_total",
+		Help:      "Failed yt-dlp invocations by source.",
+	}, []string{"source", "operation"})
+
+	FFmpegRestarts = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "ffmpeg_restarts_total",
+		Help:      "Number of times an ffmpeg stream was restarted.",
+	})
+
+	OpusSendTimeouts = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "opus_send_timeouts_total",
+		Help:      "Opus frames that could not be handed to the voice connection in time.",
+	})
+
+	SpotifyTokenRefreshes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "spotify_token_refreshes_total",
+		Help:      "Spotify access token refreshes by result.",
+	}, []string{"result"})
+
+	CommandInvocations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "command_invocations_total",
+		Help:      "Application command invocations by name.",
+	}, []string{"command"})
+
+	DashboardRateLimits = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "dashboard_rate_limit_hits_total",
+		Help:      "Dashboard message edits that hit a Discord rate limit.",
+	})
+)
+
+func RegisterPlayerStats(activePlayers func() int, voiceConnections func() int) {
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "active_players",
+		Help:      "Players that are currently playing a track.",
+	}, func() float64 {
+		return float64(activePlayers())
+	})
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "voice_connections",
+		Help:      "Open voice connections.",
+	}, func() float64 {
+		return float64(voiceConnections())
+	})
+}
+
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
@@ -0,0 +1,135 @@
+package music
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const appleMusicAPIURL = "https://itunes.apple.com"
+
+func NewAppleMusicProvider(playback Provider) Provider {
+	return &linkProvider{
+		source:     TrackSourceAppleMusic,
+		baseURL:    appleMusicAPIURL,
+		hosts:      []string{"music.apple.com", "itunes.apple.com"},
+		fetch:      fetchAppleMusicTracks,
+		album:      isAppleMusicAlbum,
+		playback:   playback,
+		HTTPClient: &http.Client{Timeout: linkMetadataTimeout},
+	}
+}
+
+func isAppleMusicAlbum(input string) bool {
+	_, _, isAlbum := parseAppleMusicURL(input)
+	return isAlbum
+}
+
+func fetchAppleMusicTracks(ctx context.Context, client *http.Client, baseURL string, input string, limit int) ([]Track, error) {
+	id, country, isAlbum := parseAppleMusicURL(input)
+	if id == "" {
+		return nil, fmt.Errorf("%w: unsupported apple music url", ErrLinkMetadataFailed)
+	}
+
+	params := url.Values{}
+	params.Set("id", id)
+	params.Set("entity", "song")
+	if country != "" {
+		params.Set("country", country)
+	}
+	if isAlbum {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+
+	resp, err := getLinkMetadata(ctx, client, baseURL+"/lookup?"+params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLinkMetadataFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%w: itunes lookup status %d", ErrLinkMetadataFailed, resp.StatusCode)
+	}
+
+	var payload appleLookupResponse
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLinkMetadataFailed, err)
+	}
+
+	tracks := make([]Track, 0, len(payload.Results))
+	for _, item := range payload.Results {
+		if item.WrapperType != "track" || item.TrackName == "" {
+			continue
+		}
+		tracks = append(tracks, Track{
+			ID:        strconv.FormatInt(item.TrackID, 10),
+			Title:     linkTrackTitle(item.TrackName, item.ArtistName),
+			ISRC:      item.ISRC,
+			URL:       item.TrackViewURL,
+			Source:    TrackSourceAppleMusic,
+			Duration:  time.Duration(item.TrackTimeMillis) * time.Millisecond,
+			Thumbnail: strings.Replace(item.ArtworkURL100, "100x100", "600x600", 1),
+		})
+		if len(tracks) >= limit {
+			break
+		}
+	}
+	return tracks, nil
+}
+
+func parseAppleMusicURL(input string) (string, string, bool) {
+	u, err := url.Parse(strings.TrimSpace(input))
+	if err != nil {
+		return "", "", false
+	}
+
+	if trackID := u.Query().Get("i"); trackID != "" {
+		return trackID, appleCountry(u.Path), false
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 {
+		return "", "", false
+	}
+
+	id := strings.TrimPrefix(parts[len(parts)-1], "id")
+	if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+		return "", "", false
+	}
+
+	for _, part := range parts {
+		switch part {
+		case "song":
+			return id, appleCountry(u.Path), false
+		case "album":
+			return id, appleCountry(u.Path), true
+		}
+	}
+	return "", "", false
+}
+
+func appleCountry(path string) string {
+	first, _, _ := strings.Cut(strings.Trim(path, "/"), "/")
+	if len(first) == 2 {
+		return strings.ToLower(first)
+	}
+	return ""
+}
+
+type appleLookupResponse struct {
+	Results []struct {
+		WrapperType     string `json:"wrapperType"`
+		TrackID         int64  `json:"trackId"`
+		TrackName       string `json:"trackName"`
+		ArtistName      string `json:"artistName"`
+		ISRC            string `json:"isrc"`
+		TrackViewURL    string `json:"trackViewUrl"`
+		TrackTimeMillis int64  `json:"trackTimeMillis"`
+		ArtworkURL100   string `json:"artworkUrl100"`
+	} `json:"results"`
+}
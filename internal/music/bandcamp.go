@@ -0,0 +1,9 @@
+package music
+
+func NewBandcampProvider(resolver *YTDLPResolver) Provider {
+	return &ytdlpProvider{
+		source:   TrackSourceBandcamp,
+		hosts:    []string{"bandcamp.com"},
+		resolver: resolver,
+	}
+}
@@ -0,0 +1,92 @@
+package music
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const MaxVolume = 200
+
+var (
+	ErrNotPlaying      = errors.New("nothing is playing")
+	ErrSeekUnsupported = errors.New("track does not support seeking")
+	ErrSeekOutOfRange  = errors.New("seek position is out of range")
+	ErrInvalidVolume   = errors.New("volume is out of range")
+)
+
+func (p *Player) Seek(position time.Duration) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	track := p.state.Track
+	if track == nil || !p.state.IsPlaying {
+		return ErrNotPlaying
+	}
+	if track.IsLive || track.Duration <= 0 {
+		return ErrSeekUnsupported
+	}
+	if position < 0 || position >= track.Duration {
+		return ErrSeekOutOfRange
+	}
+
+	p.restartAtLocked(position)
+	p.publishLocked(PlayerEvent{Type: PlayerEventSeeked, Item: QueueItem{Track: *track}, Position: position})
+	return nil
+}
+
+func (p *Player) SetVolume(ctx context.Context, volume int) error {
+	if volume < 0 || volume > MaxVolume {
+		return ErrInvalidVolume
+	}
+	if p.service == nil {
+		return ErrQueueStoreNil
+	}
+
+	settings, err := p.service.GetSettings(ctx, p.guildID)
+	if err != nil {
+		return err
+	}
+	settings.Volume = volume
+	if err := p.service.SetSettings(ctx, p.guildID, settings); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.volume = volume
+	p.state.Volume = volume
+	if track := p.state.Track; track != nil && p.state.IsPlaying {
+		position := p.state.Position
+		if track.IsLive {
+			position = 0
+		}
+		p.restartAtLocked(position)
+	}
+	p.publishLocked(PlayerEvent{Type: PlayerEventSettingsChanged, Settings: settings})
+	return nil
+}
+
+func (p *Player) MoveQueueItem(ctx context.Context, from int, to int) error {
+	if p.service == nil {
+		return ErrQueueStoreNil
+	}
+	if err := p.service.Move(ctx, p.guildID, from, to); err != nil {
+		return err
+	}
+
+	p.publish(PlayerEvent{Type: PlayerEventQueueChanged})
+	return nil
+}
+
+func (p *Player) restartAtLocked(position time.Duration) {
+	p.seekOffset = position
+	p.frameCount = 0
+	p.state.Position = position
+	p.seeking = true
+	select {
+	case p.restartCh <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,157 @@
+package music
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const deezerAPIURL = "https://api.deezer.com"
+
+func NewDeezerProvider(playback Provider) Provider {
+	return &linkProvider{
+		source:     TrackSourceDeezer,
+		baseURL:    deezerAPIURL,
+		hosts:      []string{"deezer.com", "deezer.page.link", "link.deezer.com"},
+		fetch:      fetchDeezerTracks,
+		album:      isDeezerAlbum,
+		playback:   playback,
+		HTTPClient: &http.Client{Timeout: linkMetadataTimeout},
+	}
+}
+
+func isDeezerAlbum(input string) bool {
+	kind, _ := parseDeezerURL(input)
+	return kind == "album"
+}
+
+func fetchDeezerTracks(ctx context.Context, client *http.Client, baseURL string, input string, limit int) ([]Track, error) {
+	kind, id := parseDeezerURL(input)
+	if id == "" && matchURLHost(input, "deezer.page.link", "link.deezer.com") {
+		_, finalURL, err := fetchLinkPage(ctx, client, input)
+		if err != nil {
+			return nil, err
+		}
+		kind, id = parseDeezerURL(finalURL)
+	}
+	if id == "" {
+		return nil, fmt.Errorf("%w: unsupported deezer url", ErrLinkMetadataFailed)
+	}
+
+	switch kind {
+	case "track":
+		var item deezerTrack
+		if err := getDeezerJSON(ctx, client, baseURL+"/track/"+id, &item); err != nil {
+			return nil, err
+		}
+		return []Track{item.track("")}, nil
+	case "album":
+		var album deezerAlbum
+		if err := getDeezerJSON(ctx, client, baseURL+"/album/"+id, &album); err != nil {
+			return nil, err
+		}
+		tracks := make([]Track, 0, min(limit, len(album.Tracks.Data)))
+		for _, item := range album.Tracks.Data {
+			tracks = append(tracks, item.track(album.CoverXL))
+			if len(tracks) >= limit {
+				break
+			}
+		}
+		return tracks, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported deezer link type %q", ErrLinkMetadataFailed, kind)
+	}
+}
+
+func getDeezerJSON(ctx context.Context, client *http.Client, target string, out any) error {
+	resp, err := getLinkMetadata(ctx, client, target)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrLinkMetadataFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%w: deezer api status %d", ErrLinkMetadataFailed, resp.StatusCode)
+	}
+
+	var envelope struct {
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	decoder := json.NewDecoder(resp.Body)
+	var raw json.RawMessage
+	if err := decoder.Decode(&raw); err != nil {
+		return fmt.Errorf("%w: %v", ErrLinkMetadataFailed, err)
+	}
+	if err := json.Unmarshal(raw, &envelope); err == nil && envelope.Error != nil {
+		return fmt.Errorf("%w: deezer api: %s", ErrLinkMetadataFailed, envelope.Error.Message)
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("%w: %v", ErrLinkMetadataFailed, err)
+	}
+	return nil
+}
+
+func parseDeezerURL(input string) (string, string) {
+	u, err := url.Parse(strings.TrimSpace(input))
+	if err != nil || !strings.HasSuffix(strings.ToLower(u.Hostname()), "deezer.com") {
+		return "", ""
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 0; i+1 < len(parts); i++ {
+		if parts[i] != "track" && parts[i] != "album" {
+			continue
+		}
+		if _, err := strconv.ParseInt(parts[i+1], 10, 64); err == nil {
+			return parts[i], parts[i+1]
+		}
+	}
+	return "", ""
+}
+
+type deezerTrack struct {
+	ID       int64  `json:"id"`
+	Title    string `json:"title"`
+	Link     string `json:"link"`
+	ISRC     string `json:"isrc"`
+	Duration int64  `json:"duration"`
+	Artist   struct {
+		Name string `json:"name"`
+	} `json:"artist"`
+	Album struct {
+		CoverXL string `json:"cover_xl"`
+	} `json:"album"`
+}
+
+type deezerAlbum struct {
+	CoverXL string `json:"cover_xl"`
+	Tracks  struct {
+		Data []deezerTrack `json:"data"`
+	} `json:"tracks"`
+}
+
+func (t deezerTrack) track(cover string) Track {
+	if t.Album.CoverXL != "" {
+		cover = t.Album.CoverXL
+	}
+	link := t.Link
+	if link == "" {
+		link = fmt.Sprintf("https://www.deezer.com/track/%d", t.ID)
+	}
+	return Track{
+		ID:        strconv.FormatInt(t.ID, 10),
+		Title:     linkTrackTitle(t.Title, t.Artist.Name),
+		ISRC:      t.ISRC,
+		URL:       link,
+		Source:    TrackSourceDeezer,
+		Duration:  time.Duration(t.Duration) * time.Second,
+		Thumbnail: cover,
+	}
+}
@@ -0,0 +1,177 @@
+package music
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/logging"
+)
+
+const playerEventBuffer = 256
+
+type PlayerEventType string
+
+const (
+	PlayerEventTrackStarted    PlayerEventType = "track_started"
+	PlayerEventTrackEnded      PlayerEventType = "track_ended"
+	PlayerEventPaused          PlayerEventType = "paused"
+	PlayerEventResumed         PlayerEventType = "resumed"
+	PlayerEventQueueChanged    PlayerEventType = "queue_changed"
+	PlayerEventSettingsChanged PlayerEventType = "settings_changed"
+	PlayerEventVoiceJoined     PlayerEventType = "voice_joined"
+	PlayerEventVoiceLeft       PlayerEventType = "voice_left"
+	PlayerEventSeeked          PlayerEventType = "seeked"
+	PlayerEventTrackQueued     PlayerEventType = "track_queued"
+	PlayerEventStopped         PlayerEventType = "stopped"
+)
+
+type TrackEndReason string
+
+const (
+	TrackEndFinished TrackEndReason = "finished"
+	TrackEndSkipped  TrackEndReason = "skipped"
+	TrackEndStopped  TrackEndReason = "stopped"
+	TrackEndFailed   TrackEndReason = "failed"
+)
+
+type PlayerEvent struct {
+	Type      PlayerEventType
+	GuildID   string
+	Session   *discordgo.Session
+	Item      QueueItem
+	Items     []QueueItem
+	Reason    TrackEndReason
+	Err       error
+	Settings  QueueSettings
+	ChannelID string
+	Position  time.Duration
+	Cleared   bool
+	At        time.Time
+}
+
+type PlayerEventHandler func(PlayerEvent)
+
+type eventSubscriber struct {
+	handler PlayerEventHandler
+	wake    chan struct{}
+
+	mu    sync.Mutex
+	queue []PlayerEvent
+}
+
+func (m *PlayerManager) Subscribe(handler PlayerEventHandler) {
+	if handler == nil {
+		return
+	}
+
+	sub := &eventSubscriber{
+		handler: handler,
+		wake:    make(chan struct{}, 1),
+	}
+
+	m.mu.Lock()
+	m.subscribers = append(m.subscribers, sub)
+	m.mu.Unlock()
+
+	go sub.run()
+}
+
+func (m *PlayerManager) emit(event PlayerEvent) {
+	m.mu.Lock()
+	subscribers := m.subscribers
+	m.mu.Unlock()
+
+	for _, sub := range subscribers {
+		sub.push(event)
+	}
+}
+
+func (s *eventSubscriber) push(event PlayerEvent) {
+	s.mu.Lock()
+	if len(s.queue) >= playerEventBuffer && coalescible(event.Type) {
+		for idx := len(s.queue) - 1; idx >= 0; idx-- {
+			queued := s.queue[idx]
+			if queued.Type == event.Type && queued.GuildID == event.GuildID {
+				s.queue[idx] = event
+				s.mu.Unlock()
+				logging.ForGuild(event.Session, event.GuildID).Debug("player event coalesced", "type", event.Type, "backlog", len(s.queue))
+				return
+			}
+		}
+	}
+	s.queue = append(s.queue, event)
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *eventSubscriber) pop() (PlayerEvent, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.queue) == 0 {
+		return PlayerEvent{}, false
+	}
+	event := s.queue[0]
+	s.queue[0] = PlayerEvent{}
+	s.queue = s.queue[1:]
+	return event, true
+}
+
+func (s *eventSubscriber) run() {
+	for range s.wake {
+		for {
+			event, ok := s.pop()
+			if !ok {
+				break
+			}
+			s.handler(event)
+		}
+	}
+}
+
+func coalescible(eventType PlayerEventType) bool {
+	switch eventType {
+	case PlayerEventQueueChanged, PlayerEventSettingsChanged, PlayerEventSeeked:
+		return true
+	default:
+		return false
+	}
+}
+
+func trackEndReason(err error) TrackEndReason {
+	switch {
+	case err == nil:
+		return TrackEndFinished
+	case errors.Is(err, ErrPlaybackSkipped):
+		return TrackEndSkipped
+	case errors.Is(err, ErrPlaybackStopped):
+		return TrackEndStopped
+	default:
+		return TrackEndFailed
+	}
+}
+
+func (p *Player) publish(event PlayerEvent) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.publishLocked(event)
+}
+
+func (p *Player) publishLocked(event PlayerEvent) {
+	if p.manager == nil {
+		return
+	}
+
+	event.GuildID = p.guildID
+	event.Session = p.session
+	if event.At.IsZero() {
+		event.At = time.Now().UTC()
+	}
+	p.manager.emit(event)
+}
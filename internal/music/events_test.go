@@ -0,0 +1,77 @@
+package music
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSlowSubscriberDoesNotStallOthers(t *testing.T) {
+	manager := &PlayerManager{players: make(map[string]*Player)}
+
+	release := make(chan struct{})
+	manager.Subscribe(func(PlayerEvent) { <-release })
+
+	received := make(chan PlayerEvent, 1)
+	manager.Subscribe(func(event PlayerEvent) { received <- event })
+
+	manager.emit(PlayerEvent{Type: PlayerEventTrackStarted, GuildID: "1"})
+	select {
+	case event := <-received:
+		if event.Type != PlayerEventTrackStarted {
+			t.Errorf("received %s, want %s", event.Type, PlayerEventTrackStarted)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("fast subscriber was blocked by a slow one")
+	}
+	close(release)
+}
+
+func TestBackloggedSubscriberKeepsLifecycleEvents(t *testing.T) {
+	manager := &PlayerManager{players: make(map[string]*Player)}
+
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var got []PlayerEvent
+	done := make(chan struct{})
+	manager.Subscribe(func(event PlayerEvent) {
+		<-release
+		mu.Lock()
+		got = append(got, event)
+		if event.Type == PlayerEventTrackEnded && event.GuildID == "last" {
+			close(done)
+		}
+		mu.Unlock()
+	})
+
+	for range playerEventBuffer {
+		manager.emit(PlayerEvent{Type: PlayerEventTrackStarted, GuildID: "1"})
+	}
+	for range 50 {
+		manager.emit(PlayerEvent{Type: PlayerEventQueueChanged, GuildID: "1"})
+	}
+	manager.emit(PlayerEvent{Type: PlayerEventTrackEnded, GuildID: "last"})
+	close(release)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("track ended event was not delivered")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	counts := map[PlayerEventType]int{}
+	for _, event := range got {
+		counts[event.Type]++
+	}
+	if counts[PlayerEventTrackStarted] != playerEventBuffer {
+		t.Errorf("delivered %d track started events, want %d", counts[PlayerEventTrackStarted], playerEventBuffer)
+	}
+	if counts[PlayerEventQueueChanged] != 1 {
+		t.Errorf("delivered %d queue changed events, want them coalesced into 1", counts[PlayerEventQueueChanged])
+	}
+	if got[len(got)-1].Type != PlayerEventTrackEnded {
+		t.Errorf("last event = %s, want %s", got[len(got)-1].Type, PlayerEventTrackEnded)
+	}
+}
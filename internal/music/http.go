@@ -0,0 +1,462 @@
+package music
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hxnx/tunebot/internal/netguard"
+)
+
+var ErrHTTPProbeFailed = errors.New("failed to probe http audio")
+
+const (
+	httpProbeTimeout    = 15 * time.Second
+	httpProbeMaxSize    = 8 * 1024 * 1024
+	httpPlaylistMaxSize = 64 * 1024
+	httpMaxRedirects    = 5
+	httpProtocols       = "http,https,tcp,tls"
+	pipeProtocols       = "pipe"
+	localProtocols      = "file"
+)
+
+var (
+	httpAudioExtensions    = []string{".mp3", ".ogg", ".oga", ".opus", ".flac", ".aac", ".m4a", ".wav"}
+	httpPlaylistExtensions = []string{".m3u", ".pls"}
+)
+
+type HTTPProvider struct {
+	FFProbeBinary string
+	HTTPClient    *http.Client
+}
+
+func NewHTTPProvider() *HTTPProvider {
+	return &HTTPProvider{
+		FFProbeBinary: "ffprobe",
+		HTTPClient:    newGuardedHTTPClient(10 * time.Second),
+	}
+}
+
+func newGuardedHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: netguard.Control(nil),
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= httpMaxRedirects {
+				return fmt.Errorf("%w: too many redirects", ErrHTTPProbeFailed)
+			}
+			if _, ok := parseHTTPURL(req.URL.String()); !ok {
+				return fmt.Errorf("%w: redirect to a non-http url", ErrHTTPProbeFailed)
+			}
+			if netguard.IsPrivateLiteral(req.URL.Hostname()) {
+				return fmt.Errorf("%w: %w", ErrHTTPProbeFailed, netguard.ErrPrivateAddress)
+			}
+			return nil
+		},
+	}
+}
+
+func (p *HTTPProvider) Source() TrackSource {
+	return TrackSourceHTTP
+}
+
+func (p *HTTPProvider) Match(input string) bool {
+	u, ok := parseHTTPURL(input)
+	if !ok {
+		return false
+	}
+	ext := strings.ToLower(path.Ext(u.Path))
+	return slices.Contains(httpAudioExtensions, ext) || slices.Contains(httpPlaylistExtensions, ext)
+}
+
+func (p *HTTPProvider) Probe(ctx context.Context, input string) bool {
+	if _, ok := parseHTTPURL(input); !ok {
+		return false
+	}
+	_, err := p.Resolve(ctx, input)
+	return err == nil
+}
+
+func (p *HTTPProvider) Search(ctx context.Context, query string, limit int) ([]Track, error) {
+	track, err := p.Resolve(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return []Track{track}, nil
+}
+
+func (p *HTTPProvider) Resolve(ctx context.Context, input string) (Track, error) {
+	input = strings.TrimSpace(input)
+	u, ok := parseHTTPURL(input)
+	if !ok {
+		return Track{}, fmt.Errorf("%w: not an http url", ErrHTTPProbeFailed)
+	}
+
+	mediaURL, err := p.resolveMediaURL(ctx, input)
+	if err != nil {
+		return Track{}, err
+	}
+
+	probe, size, err := p.ffprobe(ctx, mediaURL)
+	if err != nil {
+		return Track{}, err
+	}
+	if !probe.hasAudio() {
+		return Track{}, fmt.Errorf("%w: no audio stream", ErrHTTPProbeFailed)
+	}
+
+	title := probe.title()
+	if title == "" {
+		title = path.Base(u.Path)
+	}
+	if title == "" || title == "/" || title == "." {
+		title = u.Hostname()
+	}
+
+	duration := probe.duration(size)
+	sum := sha1.Sum([]byte(input))
+
+	return Track{
+		ID:       hex.EncodeToString(sum[:8]),
+		Title:    title,
+		URL:      input,
+		Source:   TrackSourceHTTP,
+		Duration: duration,
+		IsLive:   duration <= 0,
+	}, nil
+}
+
+func (p *HTTPProvider) StreamURL(ctx context.Context, track Track) (string, error) {
+	return p.resolveMediaURL(ctx, track.URL)
+}
+
+func (p *HTTPProvider) OpenStream(ctx context.Context, mediaURL string) (io.ReadCloser, error) {
+	resp, err := p.openMedia(ctx, mediaURL, false)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (p *HTTPProvider) WatchMetadata(ctx context.Context, track Track, update func(title string)) {
+	if !track.IsLive {
+		return
+	}
+
+	mediaURL, err := p.resolveMediaURL(ctx, track.URL)
+	if err != nil {
+		return
+	}
+
+	resp, err := p.openMedia(ctx, mediaURL, true)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	station := track.Title
+	_ = readICYMetadata(resp, func(streamTitle string) {
+		if station != "" && station != streamTitle {
+			update(fmt.Sprintf("%s · %s", streamTitle, station))
+			return
+		}
+		update(streamTitle)
+	})
+}
+
+func (p *HTTPProvider) resolveMediaURL(ctx context.Context, input string) (string, error) {
+	u, ok := parseHTTPURL(input)
+	if !ok {
+		return "", fmt.Errorf("%w: not an http url", ErrHTTPProbeFailed)
+	}
+
+	if err := checkMediaHost(ctx, u); err != nil {
+		return "", err
+	}
+
+	ext := strings.ToLower(path.Ext(u.Path))
+	if !slices.Contains(httpPlaylistExtensions, ext) {
+		return input, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, input, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("%w: playlist status %d", ErrHTTPProbeFailed, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, httpPlaylistMaxSize))
+	if err != nil {
+		return "", err
+	}
+
+	entry := ""
+	if ext == ".pls" {
+		entry = firstPLSEntry(string(body))
+	} else {
+		entry = firstM3UEntry(string(body))
+	}
+	if entry == "" {
+		return "", fmt.Errorf("%w: empty playlist", ErrHTTPProbeFailed)
+	}
+
+	ref, err := url.Parse(entry)
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid playlist entry", ErrHTTPProbeFailed)
+	}
+	media, ok := parseHTTPURL(u.ResolveReference(ref).String())
+	if !ok {
+		return "", fmt.Errorf("%w: playlist entry is not an http url", ErrHTTPProbeFailed)
+	}
+	if err := checkMediaHost(ctx, media); err != nil {
+		return "", err
+	}
+	return media.String(), nil
+}
+
+func (p *HTTPProvider) openMedia(ctx context.Context, mediaURL string, icy bool) (*http.Response, error) {
+	u, ok := parseHTTPURL(mediaURL)
+	if !ok {
+		return nil, fmt.Errorf("%w: not an http url", ErrHTTPProbeFailed)
+	}
+	if err := checkMediaHost(ctx, u); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if icy {
+		req.Header.Set("Icy-MetaData", "1")
+	}
+
+	client := *p.HTTPClient
+	client.Timeout = 0
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrHTTPProbeFailed, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: media status %d", ErrHTTPProbeFailed, resp.StatusCode)
+	}
+	return resp, nil
+}
+
+func checkMediaHost(ctx context.Context, u *url.URL) error {
+	if err := netguard.CheckHost(ctx, u.Hostname()); err != nil {
+		return fmt.Errorf("%w: %w", ErrHTTPProbeFailed, err)
+	}
+	return nil
+}
+
+func (p *HTTPProvider) ffprobe(ctx context.Context, mediaURL string) (ffprobeResult, int64, error) {
+	probeCtx, cancel := context.WithTimeout(ctx, httpProbeTimeout)
+	defer cancel()
+
+	resp, err := p.openMedia(probeCtx, mediaURL, false)
+	if err != nil {
+		return ffprobeResult{}, 0, err
+	}
+	defer resp.Body.Close()
+
+	result, err := runFFProbe(probeCtx, p.FFProbeBinary, "pipe:0", pipeProtocols, io.LimitReader(resp.Body, httpProbeMaxSize))
+	if err != nil {
+		return ffprobeResult{}, 0, fmt.Errorf("%w: %v", ErrHTTPProbeFailed, err)
+	}
+	return result, resp.ContentLength, nil
+}
+
+func runFFProbe(ctx context.Context, binary string, target string, protocols string, stdin io.Reader) (ffprobeResult, error) {
+	cmd := exec.CommandContext(ctx, binary,
+		"-v", "error",
+		"-protocol_whitelist", protocols,
+		"-show_format",
+		"-show_streams",
+		"-of", "json",
+		"-i", target,
+	)
+	cmd.Stdin = stdin
+	cmd.WaitDelay = time.Second
+	output, err := cmd.Output()
+	if err != nil {
+		return ffprobeResult{}, fmt.Errorf("ffprobe failed: %w", err)
+	}
+
+	var result ffprobeResult
+	if err := json.Unmarshal(output, &result); err != nil {
+		return ffprobeResult{}, fmt.Errorf("invalid ffprobe json: %w", err)
+	}
+	return result, nil
+}
+
+type ffprobeResult struct {
+	Streams []struct {
+		CodecType string `json:"codec_type"`
+	} `json:"streams"`
+	Format struct {
+		Duration string            `json:"duration"`
+		BitRate  string            `json:"bit_rate"`
+		Tags     map[string]string `json:"tags"`
+	} `json:"format"`
+}
+
+func (r ffprobeResult) hasAudio() bool {
+	for _, stream := range r.Streams {
+		if stream.CodecType == "audio" {
+			return true
+		}
+	}
+	return false
+}
+
+func (r ffprobeResult) tag(names ...string) string {
+	for _, name := range names {
+		for key, value := range r.Format.Tags {
+			if strings.EqualFold(key, name) && strings.TrimSpace(value) != "" {
+				return strings.TrimSpace(value)
+			}
+		}
+	}
+	return ""
+}
+
+func (r ffprobeResult) title() string {
+	if title := r.tag("title"); title != "" {
+		if artist := r.tag("artist"); artist != "" {
+			return fmt.Sprintf("%s — %s", title, artist)
+		}
+		return title
+	}
+	return r.tag("icy-name", "StreamTitle")
+}
+
+func (r ffprobeResult) duration(size int64) time.Duration {
+	if seconds, err := strconv.ParseFloat(r.Format.Duration, 64); err == nil && seconds > 0 {
+		return time.Duration(seconds * float64(time.Second))
+	}
+
+	bitRate, err := strconv.ParseInt(r.Format.BitRate, 10, 64)
+	if err != nil || bitRate <= 0 || size <= 0 {
+		return 0
+	}
+	return time.Duration(float64(size*8) / float64(bitRate) * float64(time.Second))
+}
+
+func readICYMetadata(resp *http.Response, onTitle func(string)) error {
+	metaInt, err := strconv.Atoi(resp.Header.Get("icy-metaint"))
+	if err != nil || metaInt <= 0 {
+		return nil
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	last := ""
+	for {
+		if _, err := io.CopyN(io.Discard, reader, int64(metaInt)); err != nil {
+			return err
+		}
+
+		size, err := reader.ReadByte()
+		if err != nil {
+			return err
+		}
+		if size == 0 {
+			continue
+		}
+
+		block := make([]byte, int(size)*16)
+		if _, err := io.ReadFull(reader, block); err != nil {
+			return err
+		}
+
+		title := parseICYStreamTitle(string(block))
+		if title != "" && title != last {
+			last = title
+			onTitle(title)
+		}
+	}
+}
+
+func parseICYStreamTitle(block string) string {
+	const marker = "StreamTitle='"
+	start := strings.Index(block, marker)
+	if start < 0 {
+		return ""
+	}
+	rest := block[start+len(marker):]
+	end := strings.Index(rest, "';")
+	if end < 0 {
+		end = strings.IndexByte(rest, '\'')
+	}
+	if end < 0 {
+		return ""
+	}
+	return strings.TrimSpace(rest[:end])
+}
+
+func firstM3UEntry(body string) string {
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		return line
+	}
+	return ""
+}
+
+func firstPLSEntry(body string) string {
+	for _, line := range strings.Split(body, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(key), "file") && strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+func parseHTTPURL(input string) (*url.URL, bool) {
+	u, err := url.Parse(strings.TrimSpace(input))
+	if err != nil || u.Host == "" {
+		return nil, false
+	}
+	scheme := strings.ToLower(u.Scheme)
+	return u, scheme == "http" || scheme == "https"
+}
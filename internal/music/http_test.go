@@ -0,0 +1,78 @@
+package music
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hxnx/tunebot/internal/netguard"
+)
+
+func TestGuardedHTTPClientRefusesPrivateTargets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("audio"))
+	}))
+	t.Cleanup(server.Close)
+
+	provider := NewHTTPProvider()
+
+	if _, err := provider.OpenStream(context.Background(), server.URL+"/stream.mp3"); !errors.Is(err, netguard.ErrPrivateAddress) {
+		t.Errorf("OpenStream on loopback: err = %v, want ErrPrivateAddress", err)
+	}
+
+	resp, err := provider.HTTPClient.Get(server.URL + "/stream.mp3")
+	if err == nil {
+		resp.Body.Close()
+	}
+	if !errors.Is(err, netguard.ErrPrivateAddress) {
+		t.Errorf("dial to loopback after DNS: err = %v, want ErrPrivateAddress", err)
+	}
+}
+
+func TestGuardedHTTPClientRedirects(t *testing.T) {
+	client := newGuardedHTTPClient(0)
+
+	tests := []struct {
+		name   string
+		target string
+		via    int
+		ok     bool
+	}{
+		{"public http", "https://example.com/next.mp3", 1, true},
+		{"metadata address", "http://169.254.169.254/latest/meta-data", 1, false},
+		{"loopback", "http://127.0.0.1:8080/", 1, false},
+		{"non-http scheme", "file:///etc/passwd", 1, false},
+		{"too many redirects", "https://example.com/next.mp3", httpMaxRedirects, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.target, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = client.CheckRedirect(req, make([]*http.Request, tt.via))
+			if (err == nil) != tt.ok {
+				t.Errorf("CheckRedirect(%s) = %v, want ok=%v", tt.target, err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestFFProbeDurationFallsBackToBitRate(t *testing.T) {
+	var probe ffprobeResult
+	probe.Format.BitRate = "128000"
+
+	if got := probe.duration(0); got != 0 {
+		t.Errorf("duration without size = %s, want 0", got)
+	}
+	if got := probe.duration(1_600_000); got.Seconds() != 100 {
+		t.Errorf("duration = %s, want 100s", got)
+	}
+
+	probe.Format.Duration = "12.5"
+	if got := probe.duration(1_600_000); got.Seconds() != 12.5 {
+		t.Errorf("duration = %s, want 12.5s", got)
+	}
+}
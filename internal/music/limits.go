@@ -0,0 +1,56 @@
+package music
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrQueueFull = errors.New("queue is full")
+
+type Limits struct {
+	DefaultVolume    int
+	MaxQueueSize     int
+	AutoLeaveTimeout time.Duration
+}
+
+var defaultLimits = Limits{
+	DefaultVolume:    100,
+	MaxQueueSize:     500,
+	AutoLeaveTimeout: 5 * time.Minute,
+}
+
+func (m *PlayerManager) SetLimits(limits Limits) {
+	m.mu.Lock()
+	m.limits = &limits
+	m.mu.Unlock()
+}
+
+func (m *PlayerManager) Limits() Limits {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.limits == nil {
+		return defaultLimits
+	}
+	return *m.limits
+}
+
+func (p *Player) queueCapacity(ctx context.Context) (int, error) {
+	limits := defaultLimits
+	if p.manager != nil {
+		limits = p.manager.Limits()
+	}
+	if limits.MaxQueueSize <= 0 || p.service == nil || p.service.queue == nil {
+		return -1, nil
+	}
+
+	size, err := p.service.queue.QueueSize(ctx, p.guildID)
+	if err != nil {
+		return 0, err
+	}
+	remaining := limits.MaxQueueSize - int(size)
+	if remaining <= 0 {
+		return 0, ErrQueueFull
+	}
+	return remaining, nil
+}
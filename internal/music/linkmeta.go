@@ -0,0 +1,238 @@
+package music
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var ErrLinkMetadataFailed = errors.New("failed to fetch link metadata")
+
+const (
+	linkMetadataTimeout   = 10 * time.Second
+	linkPageMaxSize       = 2 * 1024 * 1024
+	linkConvertCandidates = 5
+	linkAlbumMaxTracks    = 10
+)
+
+var (
+	htmlMetaTagPattern = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	htmlAttrPattern    = regexp.MustCompile(`(?is)([a-z][a-z0-9:_-]*)\s*=\s*"([^"]*)"`)
+	htmlISRCPattern    = regexp.MustCompile(`"isrc"\s*:\s*"([A-Z]{2}[A-Z0-9]{3}\d{7})"`)
+)
+
+type linkMetadataFunc func(ctx context.Context, client *http.Client, baseURL string, input string, limit int) ([]Track, error)
+
+type linkProvider struct {
+	source     TrackSource
+	baseURL    string
+	hosts      []string
+	fetch      linkMetadataFunc
+	album      func(input string) bool
+	playback   Provider
+	HTTPClient *http.Client
+}
+
+func (p *linkProvider) Source() TrackSource {
+	return p.source
+}
+
+func (p *linkProvider) Match(input string) bool {
+	return matchURLHost(input, p.hosts...)
+}
+
+func (p *linkProvider) Search(ctx context.Context, query string, limit int) ([]Track, error) {
+	query = strings.TrimSpace(query)
+	if !p.Match(query) {
+		return nil, fmt.Errorf("%w: %s", ErrSearchUnsupported, p.source)
+	}
+	if limit <= 0 {
+		limit = 1
+	}
+	return p.metadata(ctx, query, limit)
+}
+
+func (p *linkProvider) Resolve(ctx context.Context, input string) (Track, error) {
+	input = strings.TrimSpace(input)
+	if !p.Match(input) {
+		return Track{}, fmt.Errorf("%w: %s", ErrSearchUnsupported, p.source)
+	}
+	if p.playback == nil {
+		return Track{}, ErrResolverNil
+	}
+
+	tracks, err := p.metadata(ctx, input, 1)
+	if err != nil {
+		return Track{}, err
+	}
+	return resolvePlayable(ctx, p.playback, tracks[0])
+}
+
+func (p *linkProvider) ResolveCollection(ctx context.Context, input string) ([]Track, bool, error) {
+	input = strings.TrimSpace(input)
+	if !p.Match(input) || p.album == nil || !p.album(input) {
+		return nil, false, nil
+	}
+
+	tracks, err := p.metadata(ctx, input, linkAlbumMaxTracks)
+	if err != nil {
+		return nil, true, err
+	}
+	return tracks, true, nil
+}
+
+func (p *linkProvider) StreamURL(ctx context.Context, track Track) (string, error) {
+	if p.playback == nil {
+		return "", ErrResolverNil
+	}
+
+	if p.Match(track.URL) {
+		playable, err := p.Resolve(ctx, track.URL)
+		if err != nil {
+			return "", err
+		}
+		track = playable
+	}
+
+	return p.playback.StreamURL(ctx, track)
+}
+
+func (p *linkProvider) metadata(ctx context.Context, input string, limit int) ([]Track, error) {
+	fetchCtx, cancel := context.WithTimeout(ctx, linkMetadataTimeout)
+	defer cancel()
+
+	client := p.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	tracks, err := p.fetch(fetchCtx, client, p.baseURL, input, min(limit, linkAlbumMaxTracks))
+	if err != nil {
+		return nil, err
+	}
+	if len(tracks) == 0 {
+		return nil, fmt.Errorf("%w: no tracks in %s link", ErrLinkMetadataFailed, p.source)
+	}
+	return tracks, nil
+}
+
+func resolvePlayable(ctx context.Context, playback Provider, meta Track) (Track, error) {
+	playable, err := pickPlayableCandidate(ctx, playback, meta.Title, meta.Duration)
+	if err != nil {
+		return Track{}, err
+	}
+
+	playable.Title = meta.Title
+	playable.ISRC = meta.ISRC
+	if meta.Thumbnail != "" {
+		playable.Thumbnail = meta.Thumbnail
+	}
+	return playable, nil
+}
+
+func pickPlayableCandidate(ctx context.Context, playback Provider, query string, duration time.Duration) (Track, error) {
+	if duration <= 0 {
+		return playback.Resolve(ctx, query)
+	}
+
+	candidates, err := playback.Search(ctx, query, linkConvertCandidates)
+	if err != nil || len(candidates) == 0 {
+		return playback.Resolve(ctx, query)
+	}
+
+	best := candidates[0]
+	bestDiff := durationDiff(best.Duration, duration)
+	for _, candidate := range candidates[1:] {
+		if diff := durationDiff(candidate.Duration, duration); diff < bestDiff {
+			best, bestDiff = candidate, diff
+		}
+	}
+	return best, nil
+}
+
+func durationDiff(a, b time.Duration) time.Duration {
+	if a <= 0 {
+		return time.Duration(1<<62 - 1)
+	}
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+func getLinkMetadata(ctx context.Context, client *http.Client, target string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; TuneBot)")
+	return client.Do(req)
+}
+
+func fetchLinkPage(ctx context.Context, client *http.Client, target string) (string, string, error) {
+	resp, err := getLinkMetadata(ctx, client, target)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %v", ErrLinkMetadataFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", "", fmt.Errorf("%w: status %d", ErrLinkMetadataFailed, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, linkPageMaxSize))
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %v", ErrLinkMetadataFailed, err)
+	}
+	return string(body), resp.Request.URL.String(), nil
+}
+
+func parseHTMLMeta(page string) map[string][]string {
+	meta := make(map[string][]string)
+	for _, tag := range htmlMetaTagPattern.FindAllString(page, -1) {
+		var key, content string
+		for _, attr := range htmlAttrPattern.FindAllStringSubmatch(tag, -1) {
+			switch strings.ToLower(attr[1]) {
+			case "property", "name":
+				key = strings.ToLower(attr[2])
+			case "content":
+				content = strings.TrimSpace(html.UnescapeString(attr[2]))
+			}
+		}
+		if key != "" && content != "" {
+			meta[key] = append(meta[key], content)
+		}
+	}
+	return meta
+}
+
+func firstMeta(meta map[string][]string, keys ...string) string {
+	for _, key := range keys {
+		if values := meta[key]; len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+func findISRC(page string) string {
+	if match := htmlISRCPattern.FindStringSubmatch(page); len(match) == 2 {
+		return match[1]
+	}
+	return ""
+}
+
+func linkTrackTitle(title string, artist string) string {
+	title = strings.TrimSpace(title)
+	artist = strings.TrimSpace(artist)
+	if artist == "" {
+		return title
+	}
+	return fmt.Sprintf("%s — %s", title, artist)
+}
@@ -0,0 +1,331 @@
+package music
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func tidalTrackPage(title string, artist string) string {
+	return fmt.Sprintf(`<html><head>
+<meta property="og:title" content="%s by %s on TIDAL">
+<meta property="og:image" content="https://example.com/%s.jpg">
+<meta property="music:duration" content="180">
+</head><body><script>{"isrc":"USRC11700001"}</script></body></html>`, title, artist, strings.ToLower(title))
+}
+
+func TestLinkProviders(t *testing.T) {
+	tests := []struct {
+		name        string
+		newProvider func(playback Provider) Provider
+		routes      map[string]string
+		query       url.Values
+		input       string
+		collection  bool
+		want        []Track
+		wantErr     bool
+	}{
+		{
+			name:        "apple music track",
+			newProvider: NewAppleMusicProvider,
+			routes: map[string]string{"/lookup": `{"results":[
+				{"wrapperType":"collection","collectionId":1440857777},
+				{"wrapperType":"track","trackId":1440857781,"trackName":"Song","artistName":"Artist","isrc":"USAT21812345",
+				 "trackViewUrl":"https://music.apple.com/us/album/x/1440857777?i=1440857781","trackTimeMillis":215000,
+				 "artworkUrl100":"https://example.com/100x100bb.jpg"}
+			]}`},
+			query: url.Values{"id": {"1440857781"}, "country": {"us"}},
+			input: "https://music.apple.com/us/album/x/1440857777?i=1440857781",
+			want: []Track{{
+				Title:     "Song — Artist",
+				ISRC:      "USAT21812345",
+				Duration:  215 * time.Second,
+				Thumbnail: "https://example.com/600x600bb.jpg",
+				Source:    TrackSourceAppleMusic,
+			}},
+		},
+		{
+			name:        "apple music album",
+			newProvider: NewAppleMusicProvider,
+			routes: map[string]string{"/lookup": `{"results":[
+				{"wrapperType":"collection","collectionId":1440857777},
+				{"wrapperType":"track","trackId":1,"trackName":"One","artistName":"Artist","trackViewUrl":"https://music.apple.com/us/album/x/1440857777?i=1"},
+				{"wrapperType":"track","trackId":2,"trackName":"Two","artistName":"Artist","trackViewUrl":"https://music.apple.com/us/album/x/1440857777?i=2"},
+				{"wrapperType":"track","trackId":3,"trackName":"Three","artistName":"Artist","trackViewUrl":"https://music.apple.com/us/album/x/1440857777?i=3"}
+			]}`},
+			query:      url.Values{"limit": {"10"}},
+			input:      "https://music.apple.com/us/album/x/1440857777",
+			collection: true,
+			want: []Track{
+				{Title: "One — Artist", Source: TrackSourceAppleMusic},
+				{Title: "Two — Artist", Source: TrackSourceAppleMusic},
+				{Title: "Three — Artist", Source: TrackSourceAppleMusic},
+			},
+		},
+		{
+			name:        "deezer track",
+			newProvider: NewDeezerProvider,
+			routes: map[string]string{"/track/3135556": `{"id":3135556,"title":"Harder, Better, Faster, Stronger","link":"https://www.deezer.com/track/3135556",
+				"isrc":"GBDUW0000059","duration":224,"artist":{"name":"Daft Punk"},"album":{"cover_xl":"https://example.com/cover.jpg"}}`},
+			input: "https://www.deezer.com/en/track/3135556",
+			want: []Track{{
+				Title:     "Harder, Better, Faster, Stronger — Daft Punk",
+				URL:       "https://www.deezer.com/track/3135556",
+				ISRC:      "GBDUW0000059",
+				Duration:  224 * time.Second,
+				Thumbnail: "https://example.com/cover.jpg",
+				Source:    TrackSourceDeezer,
+			}},
+		},
+		{
+			name:        "deezer api error",
+			newProvider: NewDeezerProvider,
+			routes:      map[string]string{"/track/1": `{"error":{"type":"DataException","message":"no data","code":800}}`},
+			input:       "https://www.deezer.com/track/1",
+			wantErr:     true,
+		},
+		{
+			name:        "deezer album",
+			newProvider: NewDeezerProvider,
+			routes: map[string]string{"/album/302127": `{"id":302127,"cover_xl":"https://example.com/album.jpg","tracks":{"data":[
+				{"id":1,"title":"One","artist":{"name":"Daft Punk"},"duration":100},
+				{"id":2,"title":"Two","artist":{"name":"Daft Punk"},"duration":200}
+			]}}`},
+			input:      "https://www.deezer.com/album/302127",
+			collection: true,
+			want: []Track{
+				{Title: "One — Daft Punk", URL: "https://www.deezer.com/track/1", Duration: 100 * time.Second, Thumbnail: "https://example.com/album.jpg", Source: TrackSourceDeezer},
+				{Title: "Two — Daft Punk", URL: "https://www.deezer.com/track/2", Duration: 200 * time.Second, Thumbnail: "https://example.com/album.jpg", Source: TrackSourceDeezer},
+			},
+		},
+		{
+			name:        "tidal track",
+			newProvider: NewTidalProvider,
+			routes:      map[string]string{"/track/77640617": tidalTrackPage("Song", "Artist")},
+			input:       "https://tidal.com/browse/track/77640617",
+			want: []Track{{
+				Title:     "Song — Artist",
+				URL:       "https://tidal.com/browse/track/77640617",
+				ISRC:      "USRC11700001",
+				Duration:  180 * time.Second,
+				Thumbnail: "https://example.com/song.jpg",
+				Source:    TrackSourceTidal,
+			}},
+		},
+		{
+			name:        "tidal album",
+			newProvider: NewTidalProvider,
+			routes: map[string]string{
+				"/album/77640616": `<html><head>
+<meta property="music:song" content="https://tidal.com/browse/track/1">
+<meta property="music:song" content="https://tidal.com/browse/track/2">
+</head></html>`,
+				"/track/1": tidalTrackPage("One", "Artist"),
+				"/track/2": tidalTrackPage("Two", "Artist"),
+			},
+			input:      "https://tidal.com/browse/album/77640616",
+			collection: true,
+			want: []Track{
+				{Title: "One — Artist", URL: "https://tidal.com/browse/track/1", Source: TrackSourceTidal},
+				{Title: "Two — Artist", URL: "https://tidal.com/browse/track/2", Source: TrackSourceTidal},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for key := range tt.query {
+					if got := r.URL.Query().Get(key); got != tt.query.Get(key) {
+						t.Errorf("query %s = %q, want %q", key, got, tt.query.Get(key))
+					}
+				}
+				body, ok := tt.routes[r.URL.Path]
+				if !ok {
+					t.Errorf("unexpected request path %q", r.URL.Path)
+					http.NotFound(w, r)
+					return
+				}
+				w.Write([]byte(body))
+			}))
+			defer server.Close()
+
+			provider := tt.newProvider(nil).(*linkProvider)
+			provider.baseURL = server.URL
+			provider.HTTPClient = server.Client()
+
+			tracks, isCollection, err := provider.ResolveCollection(context.Background(), tt.input)
+			if isCollection != tt.collection {
+				t.Fatalf("collection = %v, want %v", isCollection, tt.collection)
+			}
+			if !isCollection {
+				tracks, err = provider.metadata(context.Background(), tt.input, 1)
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolve %s: %v", tt.input, err)
+			}
+			if len(tracks) != len(tt.want) {
+				t.Fatalf("got %d tracks, want %d", len(tracks), len(tt.want))
+			}
+
+			for idx, want := range tt.want {
+				got := tracks[idx]
+				if got.Title != want.Title {
+					t.Errorf("track %d title = %q, want %q", idx, got.Title, want.Title)
+				}
+				if got.Source != want.Source {
+					t.Errorf("track %d source = %q, want %q", idx, got.Source, want.Source)
+				}
+				if want.URL != "" && got.URL != want.URL {
+					t.Errorf("track %d url = %q, want %q", idx, got.URL, want.URL)
+				}
+				if want.ISRC != "" && got.ISRC != want.ISRC {
+					t.Errorf("track %d isrc = %q, want %q", idx, got.ISRC, want.ISRC)
+				}
+				if want.Duration != 0 && got.Duration != want.Duration {
+					t.Errorf("track %d duration = %s, want %s", idx, got.Duration, want.Duration)
+				}
+				if want.Thumbnail != "" && got.Thumbnail != want.Thumbnail {
+					t.Errorf("track %d thumbnail = %q, want %q", idx, got.Thumbnail, want.Thumbnail)
+				}
+			}
+		})
+	}
+}
+
+type stubPlaybackProvider struct {
+	candidates []Track
+	searchErr  error
+	resolved   Track
+	resolveErr error
+	searches   int
+	resolves   int
+}
+
+func (p *stubPlaybackProvider) Source() TrackSource {
+	return TrackSourceYouTube
+}
+
+func (p *stubPlaybackProvider) Match(input string) bool {
+	return false
+}
+
+func (p *stubPlaybackProvider) Search(ctx context.Context, query string, limit int) ([]Track, error) {
+	p.searches++
+	return p.candidates, p.searchErr
+}
+
+func (p *stubPlaybackProvider) Resolve(ctx context.Context, input string) (Track, error) {
+	p.resolves++
+	return p.resolved, p.resolveErr
+}
+
+func (p *stubPlaybackProvider) StreamURL(ctx context.Context, track Track) (string, error) {
+	return "", nil
+}
+
+func TestPickPlayableCandidate(t *testing.T) {
+	fallback := Track{Title: "fallback", URL: "https://youtu.be/fallback"}
+	candidates := []Track{
+		{URL: "https://youtu.be/live"},
+		{URL: "https://youtu.be/short", Duration: 180 * time.Second},
+		{URL: "https://youtu.be/close", Duration: 215 * time.Second},
+		{URL: "https://youtu.be/long", Duration: 240 * time.Second},
+	}
+
+	tests := []struct {
+		name         string
+		playback     *stubPlaybackProvider
+		duration     time.Duration
+		want         string
+		wantSearches int
+		wantResolves int
+	}{
+		{
+			name:         "unknown duration resolves directly",
+			playback:     &stubPlaybackProvider{candidates: candidates, resolved: fallback},
+			want:         fallback.URL,
+			wantResolves: 1,
+		},
+		{
+			name:         "closest duration wins",
+			playback:     &stubPlaybackProvider{candidates: candidates, resolved: fallback},
+			duration:     213 * time.Second,
+			want:         "https://youtu.be/close",
+			wantSearches: 1,
+		},
+		{
+			name:         "search error falls back to resolve",
+			playback:     &stubPlaybackProvider{searchErr: errors.New("search failed"), resolved: fallback},
+			duration:     213 * time.Second,
+			want:         fallback.URL,
+			wantSearches: 1,
+			wantResolves: 1,
+		},
+		{
+			name:         "no candidates falls back to resolve",
+			playback:     &stubPlaybackProvider{resolved: fallback},
+			duration:     213 * time.Second,
+			want:         fallback.URL,
+			wantSearches: 1,
+			wantResolves: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pickPlayableCandidate(context.Background(), tt.playback, "Song — Artist", tt.duration)
+			if err != nil {
+				t.Fatalf("pickPlayableCandidate: %v", err)
+			}
+			if got.URL != tt.want {
+				t.Errorf("url = %q, want %q", got.URL, tt.want)
+			}
+			if tt.playback.searches != tt.wantSearches {
+				t.Errorf("searches = %d, want %d", tt.playback.searches, tt.wantSearches)
+			}
+			if tt.playback.resolves != tt.wantResolves {
+				t.Errorf("resolves = %d, want %d", tt.playback.resolves, tt.wantResolves)
+			}
+		})
+	}
+}
+
+func TestResolvePlayable(t *testing.T) {
+	playback := &stubPlaybackProvider{
+		candidates: []Track{{Title: "Song (Official Video)", URL: "https://youtu.be/song", Duration: 215 * time.Second, Thumbnail: "https://example.com/video.jpg"}},
+	}
+
+	got, err := resolvePlayable(context.Background(), playback, Track{Title: "Song — Artist", ISRC: "USAT21812345", Duration: 215 * time.Second, Thumbnail: "https://example.com/cover.jpg"})
+	if err != nil {
+		t.Fatalf("resolvePlayable: %v", err)
+	}
+	if got.URL != "https://youtu.be/song" || got.Title != "Song — Artist" || got.ISRC != "USAT21812345" || got.Thumbnail != "https://example.com/cover.jpg" {
+		t.Errorf("playable = %+v, want the video with the link's title, isrc and cover", got)
+	}
+
+	got, err = resolvePlayable(context.Background(), playback, Track{Title: "Song — Artist", Duration: 215 * time.Second})
+	if err != nil {
+		t.Fatalf("resolvePlayable: %v", err)
+	}
+	if got.Thumbnail != "https://example.com/video.jpg" {
+		t.Errorf("thumbnail = %q, want the video thumbnail when the link has none", got.Thumbnail)
+	}
+
+	failing := &stubPlaybackProvider{resolveErr: ErrLinkMetadataFailed}
+	if _, err := resolvePlayable(context.Background(), failing, Track{Title: "Song — Artist"}); !errors.Is(err, ErrLinkMetadataFailed) {
+		t.Errorf("err = %v, want ErrLinkMetadataFailed", err)
+	}
+}
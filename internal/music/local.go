@@ -0,0 +1,315 @@
+package music
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hxnx/tunebot/internal/database"
+)
+
+var (
+	ErrLocalLibraryUnavailable = errors.New("local library is not available")
+	ErrLocalScanInProgress     = errors.New("local library scan already in progress")
+)
+
+const (
+	localURLPrefix       = "local:"
+	localProbeTimeout    = 10 * time.Second
+	localSearchCandidate = 200
+)
+
+var localAudioExtensions = []string{".mp3", ".flac", ".ogg", ".oga", ".opus", ".m4a", ".aac", ".wav"}
+
+type LibraryScanResult struct {
+	Scanned int
+	Updated int
+	Removed int64
+	Failed  int
+}
+
+type LocalProvider struct {
+	Root          string
+	FFProbeBinary string
+
+	mu       sync.Mutex
+	scanning bool
+}
+
+func NewLocalProvider(root string) *LocalProvider {
+	return &LocalProvider{
+		Root:          root,
+		FFProbeBinary: "ffprobe",
+	}
+}
+
+func (p *LocalProvider) Source() TrackSource {
+	return TrackSourceLocal
+}
+
+func (p *LocalProvider) Match(input string) bool {
+	return strings.HasPrefix(strings.TrimSpace(input), localURLPrefix)
+}
+
+func (p *LocalProvider) Search(ctx context.Context, query string, limit int) ([]Track, error) {
+	query = strings.TrimSpace(query)
+	if p.Match(query) {
+		track, err := p.Resolve(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		return []Track{track}, nil
+	}
+
+	repo := database.NewLibraryRepository()
+	if !repo.Available() {
+		return nil, ErrLocalLibraryUnavailable
+	}
+
+	terms := strings.Fields(strings.ToLower(query))
+	candidates, err := repo.Search(terms, localSearchCandidate)
+	if err != nil {
+		return nil, err
+	}
+
+	type scored struct {
+		entry database.LibraryTrack
+		score int
+	}
+	ranked := make([]scored, 0, len(candidates))
+	for _, entry := range candidates {
+		if score := scoreLibraryEntry(entry, query, terms); score > 0 {
+			ranked = append(ranked, scored{entry: entry, score: score})
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].score > ranked[j].score
+	})
+
+	if limit <= 0 {
+		limit = 1
+	}
+	results := make([]Track, 0, min(limit, len(ranked)))
+	for _, r := range ranked {
+		results = append(results, trackFromLibraryEntry(r.entry))
+		if len(results) >= limit {
+			break
+		}
+	}
+
+	if len(results) == 0 {
+		return nil, fmt.Errorf("%w: no library matches", ErrResolveFailed)
+	}
+	return results, nil
+}
+
+func (p *LocalProvider) Resolve(ctx context.Context, input string) (Track, error) {
+	input = strings.TrimSpace(input)
+	if !p.Match(input) {
+		results, err := p.Search(ctx, input, 1)
+		if err != nil {
+			return Track{}, err
+		}
+		return results[0], nil
+	}
+
+	rel := strings.TrimPrefix(input, localURLPrefix)
+	entry, ok, err := database.NewLibraryRepository().Get(rel)
+	if err != nil {
+		return Track{}, err
+	}
+	if !ok {
+		return Track{}, fmt.Errorf("%w: %s not indexed", ErrResolveFailed, rel)
+	}
+	return trackFromLibraryEntry(entry), nil
+}
+
+func (p *LocalProvider) StreamURL(ctx context.Context, track Track) (string, error) {
+	return p.absolutePath(strings.TrimPrefix(track.URL, localURLPrefix))
+}
+
+func (p *LocalProvider) Scan(ctx context.Context) (LibraryScanResult, error) {
+	p.mu.Lock()
+	if p.scanning {
+		p.mu.Unlock()
+		return LibraryScanResult{}, ErrLocalScanInProgress
+	}
+	p.scanning = true
+	p.mu.Unlock()
+
+	defer func() {
+		p.mu.Lock()
+		p.scanning = false
+		p.mu.Unlock()
+	}()
+
+	repo := database.NewLibraryRepository()
+	if !repo.Available() || p.Root == "" {
+		return LibraryScanResult{}, ErrLocalLibraryUnavailable
+	}
+
+	existing, err := repo.List()
+	if err != nil {
+		return LibraryScanResult{}, err
+	}
+	indexed := make(map[string]database.LibraryTrack, len(existing))
+	for _, entry := range existing {
+		indexed[entry.Path] = entry
+	}
+
+	var result LibraryScanResult
+	seen := make([]string, 0, len(existing))
+
+	err = filepath.WalkDir(p.Root, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() || !slices.Contains(localAudioExtensions, strings.ToLower(filepath.Ext(path))) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			result.Failed++
+			return nil
+		}
+
+		rel, err := filepath.Rel(p.Root, path)
+		if err != nil {
+			result.Failed++
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		seen = append(seen, rel)
+		result.Scanned++
+
+		if prev, ok := indexed[rel]; ok && prev.Size == info.Size() && prev.ModifiedAt.Equal(info.ModTime().UTC().Truncate(time.Microsecond)) {
+			return nil
+		}
+
+		entry, err := p.readTags(ctx, path, rel)
+		if err != nil {
+			result.Failed++
+			return nil
+		}
+		entry.Size = info.Size()
+		entry.ModifiedAt = info.ModTime().UTC().Truncate(time.Microsecond)
+
+		if err := repo.Upsert(entry); err != nil {
+			result.Failed++
+			return nil
+		}
+		result.Updated++
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	removed, err := repo.DeleteMissing(seen)
+	if err != nil {
+		return result, err
+	}
+	result.Removed = removed
+
+	return result, nil
+}
+
+func (p *LocalProvider) readTags(ctx context.Context, path string, rel string) (database.LibraryTrack, error) {
+	probeCtx, cancel := context.WithTimeout(ctx, localProbeTimeout)
+	defer cancel()
+
+	probe, err := runFFProbe(probeCtx, p.FFProbeBinary, path, localProtocols, nil)
+	if err != nil {
+		return database.LibraryTrack{}, err
+	}
+	if !probe.hasAudio() {
+		return database.LibraryTrack{}, fmt.Errorf("%w: no audio stream in %s", ErrResolveFailed, rel)
+	}
+
+	title := probe.tag("title")
+	if title == "" {
+		title = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	return database.LibraryTrack{
+		Path:       rel,
+		Title:      title,
+		Artist:     probe.tag("artist", "album_artist"),
+		Album:      probe.tag("album"),
+		DurationMS: probe.duration(0).Milliseconds(),
+	}, nil
+}
+
+func (p *LocalProvider) absolutePath(rel string) (string, error) {
+	if p.Root == "" {
+		return "", ErrLocalLibraryUnavailable
+	}
+
+	root, err := filepath.Abs(p.Root)
+	if err != nil {
+		return "", err
+	}
+	full := filepath.Join(root, filepath.FromSlash(rel))
+
+	check, err := filepath.Rel(root, full)
+	if err != nil || check == ".." || strings.HasPrefix(check, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: path escapes library root", ErrResolveFailed)
+	}
+	return full, nil
+}
+
+func trackFromLibraryEntry(entry database.LibraryTrack) Track {
+	title := entry.Title
+	if entry.Artist != "" {
+		title = fmt.Sprintf("%s — %s", entry.Title, entry.Artist)
+	}
+
+	sum := sha1.Sum([]byte(entry.Path))
+	return Track{
+		ID:       hex.EncodeToString(sum[:8]),
+		Title:    title,
+		URL:      localURLPrefix + entry.Path,
+		Source:   TrackSourceLocal,
+		Duration: time.Duration(entry.DurationMS) * time.Millisecond,
+	}
+}
+
+func scoreLibraryEntry(entry database.LibraryTrack, query string, terms []string) int {
+	title := strings.ToLower(entry.Title)
+	artist := strings.ToLower(entry.Artist)
+	album := strings.ToLower(entry.Album)
+	path := strings.ToLower(entry.Path)
+
+	score := 0
+	for _, term := range terms {
+		switch {
+		case strings.Contains(title, term):
+			score += 3
+		case strings.Contains(artist, term):
+			score += 2
+		case strings.Contains(album, term), strings.Contains(path, term):
+			score++
+		}
+	}
+	if score == 0 {
+		return 0
+	}
+
+	if strings.Contains(title, strings.ToLower(query)) {
+		score += 5
+	}
+	return score
+}
@@ -0,0 +1,9 @@
+package music
+
+func NewMixcloudProvider(resolver *YTDLPResolver) Provider {
+	return &ytdlpProvider{
+		source:   TrackSourceMixcloud,
+		hosts:    []string{"mixcloud.com"},
+		resolver: resolver,
+	}
+}
@@ -6,12 +6,16 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"os/exec"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/hxnx/tunebot/internal/logging"
+	"github.com/hxnx/tunebot/internal/metrics"
 )
 
 var (
@@ -21,15 +25,19 @@ var (
 	ErrPlaybackStopped    = errors.New("playback stopped")
 	ErrPlaybackSkipped    = errors.New("playback skipped")
 	ErrPlaybackRestarted  = errors.New("playback restarted")
+	ErrGuildNotOwned      = errors.New("guild is owned by another instance")
 )
 
 var DefaultPlayerManager = NewPlayerManager(nil)
 
 type PlayerManager struct {
-	mu       sync.Mutex
-	players  map[string]*Player
-	service  *Service
-	resolver *YTDLPResolver
+	mu      sync.Mutex
+	players map[string]*Player
+	service *Service
+	owns    func(guildID string) bool
+	limits  *Limits
+
+	subscribers []*eventSubscriber
 }
 
 func NewPlayerManager(service *Service) *PlayerManager {
@@ -37,15 +45,88 @@ func NewPlayerManager(service *Service) *PlayerManager {
 		service = NewDefaultService()
 	}
 	return &PlayerManager{
-		players:  make(map[string]*Player),
-		service:  service,
-		resolver: NewYTDLPResolver(),
+		players: make(map[string]*Player),
+		service: service,
+	}
+}
+
+func (m *PlayerManager) Service() *Service {
+	return m.service
+}
+
+func (m *PlayerManager) Providers() *ProviderRegistry {
+	return m.service.Providers()
+}
+
+func (m *PlayerManager) SetOwnershipCheck(owns func(guildID string) bool) {
+	m.mu.Lock()
+	m.owns = owns
+	m.mu.Unlock()
+}
+
+func (m *PlayerManager) ownsGuild(guildID string) bool {
+	m.mu.Lock()
+	owns := m.owns
+	m.mu.Unlock()
+	return owns == nil || owns(guildID)
+}
+
+func (m *PlayerManager) ActivePlayers() int {
+	count := 0
+	for _, p := range m.snapshot() {
+		if p.State().IsPlaying {
+			count++
+		}
+	}
+	return count
+}
+
+func (m *PlayerManager) VoiceConnections() int {
+	count := 0
+	for _, p := range m.snapshot() {
+		if p.HasVoiceConnection() {
+			count++
+		}
 	}
+	return count
 }
 
-func (m *PlayerManager) WithSpotify(client *SpotifyClient) *PlayerManager {
-	m.service.WithSpotify(client)
-	return m
+type PlayerSummary struct {
+	GuildID   string
+	State     PlaybackState
+	Connected bool
+}
+
+func (m *PlayerManager) Summaries() []PlayerSummary {
+	players := m.snapshot()
+	summaries := make([]PlayerSummary, 0, len(players))
+	for _, p := range players {
+		connected := p.HasVoiceConnection()
+		state := p.State()
+		if !connected && state.Track == nil {
+			continue
+		}
+		summaries = append(summaries, PlayerSummary{
+			GuildID:   p.guildID,
+			State:     state,
+			Connected: connected,
+		})
+	}
+	sort.Slice(summaries, func(a, b int) bool {
+		return summaries[a].GuildID < summaries[b].GuildID
+	})
+	return summaries
+}
+
+func (m *PlayerManager) snapshot() []*Player {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	players := make([]*Player, 0, len(m.players))
+	for _, p := range m.players {
+		players = append(players, p)
+	}
+	return players
 }
 
 func (m *PlayerManager) Get(guildID string) *Player {
@@ -56,11 +137,16 @@ func (m *PlayerManager) Get(guildID string) *Player {
 		return p
 	}
 
+	volume := defaultLimits.DefaultVolume
+	if m.limits != nil {
+		volume = m.limits.DefaultVolume
+	}
+
 	p := &Player{
 		guildID:   guildID,
 		service:   m.service,
-		resolver:  m.resolver,
-		volume:    100,
+		manager:   m,
+		volume:    volume,
 		stopCh:    make(chan struct{}, 1),
 		skipCh:    make(chan struct{}, 1),
 		pauseCh:   make(chan struct{}, 1),
@@ -72,10 +158,10 @@ func (m *PlayerManager) Get(guildID string) *Player {
 }
 
 type Player struct {
-	guildID  string
-	service  *Service
-	resolver *YTDLPResolver
-	volume   int
+	guildID string
+	service *Service
+	manager *PlayerManager
+	volume  int
 
 	mu      sync.Mutex
 	session *discordgo.Session
@@ -96,6 +182,8 @@ type Player struct {
 	ffmpegCancel context.CancelFunc
 
 	frameCount int64
+	seekOffset time.Duration
+	seeking    bool
 	paused     bool
 
 	wakeCh  chan struct{}
@@ -103,6 +191,17 @@ type Player struct {
 	running bool
 }
 
+func (p *Player) logger() *slog.Logger {
+	p.mu.Lock()
+	session := p.session
+	p.mu.Unlock()
+	return logging.ForGuild(session, p.guildID)
+}
+
+func (p *Player) trackLogger(item QueueItem) *slog.Logger {
+	return p.logger().With("track_id", item.Track.ID, "user_id", item.Track.RequestedBy)
+}
+
 func safeSpeaking(vc *discordgo.VoiceConnection, speaking bool) {
 	if vc == nil || !vc.Ready {
 		return
@@ -123,6 +222,9 @@ func (p *Player) JoinVoice(s *discordgo.Session, channelID string) error {
 	if channelID == "" {
 		return fmt.Errorf("channel ID is empty")
 	}
+	if p.manager != nil && !p.manager.ownsGuild(p.guildID) {
+		return ErrGuildNotOwned
+	}
 
 	vc, err := s.ChannelVoiceJoin(p.guildID, channelID, false, true)
 	if err != nil {
@@ -132,6 +234,7 @@ func (p *Player) JoinVoice(s *discordgo.Session, channelID string) error {
 	p.mu.Lock()
 	p.session = s
 	p.vc = vc
+	p.publishLocked(PlayerEvent{Type: PlayerEventVoiceJoined, ChannelID: channelID})
 	p.mu.Unlock()
 	return nil
 }
@@ -143,12 +246,36 @@ func (p *Player) EnqueueAndPlay(ctx context.Context, s *discordgo.Session, userI
 	if s == nil {
 		return QueueItem{}, fmt.Errorf("discord session is nil")
 	}
+	if p.manager != nil && !p.manager.ownsGuild(p.guildID) {
+		return QueueItem{}, ErrGuildNotOwned
+	}
 	p.session = s
 
+	if _, err := p.queueCapacity(ctx); err != nil {
+		return QueueItem{}, err
+	}
+
+	tracks, isCollection, err := p.service.ResolveCollection(ctx, input, sourceHint, userID)
+	if err != nil {
+		return QueueItem{}, err
+	}
+	if isCollection {
+		items, err := p.EnqueueTracksAndPlay(ctx, s, userID, tracks)
+		if len(items) == 0 {
+			if err == nil {
+				err = ErrQueueFull
+			}
+			return QueueItem{}, err
+		}
+		return items[0], nil
+	}
+
 	item, err := p.service.ResolveAndEnqueue(ctx, p.guildID, input, sourceHint, userID, priority)
 	if err != nil {
 		return QueueItem{}, err
 	}
+	p.publish(PlayerEvent{Type: PlayerEventQueueChanged, Item: item})
+	p.publish(PlayerEvent{Type: PlayerEventTrackQueued, Item: item, Items: []QueueItem{item}})
 
 	if err := p.ensureVoiceConnection(userID); err != nil {
 		return QueueItem{}, err
@@ -159,6 +286,71 @@ func (p *Player) EnqueueAndPlay(ctx context.Context, s *discordgo.Session, userI
 	return item, nil
 }
 
+func (p *Player) EnqueueTracksAndPlay(ctx context.Context, s *discordgo.Session, userID string, tracks []Track) ([]QueueItem, error) {
+	if p.service == nil {
+		return nil, ErrQueueStoreNil
+	}
+	if s == nil {
+		return nil, fmt.Errorf("discord session is nil")
+	}
+	if p.manager != nil && !p.manager.ownsGuild(p.guildID) {
+		return nil, ErrGuildNotOwned
+	}
+	p.session = s
+
+	capacity, err := p.queueCapacity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.ensureVoiceConnection(userID); err != nil {
+		return nil, err
+	}
+
+	var truncated bool
+	if capacity >= 0 && len(tracks) > capacity {
+		tracks = tracks[:capacity]
+		truncated = true
+	}
+
+	items, err := p.service.EnqueueTracks(ctx, p.guildID, tracks, userID)
+	if err == nil && truncated {
+		err = ErrQueueFull
+	}
+	if len(items) > 0 {
+		p.publish(PlayerEvent{Type: PlayerEventQueueChanged})
+		p.publish(PlayerEvent{Type: PlayerEventTrackQueued, Item: items[0], Items: items})
+		p.ensureWorker()
+		p.signalWake()
+	}
+	return items, err
+}
+
+func (p *Player) ImportAndPlay(ctx context.Context, s *discordgo.Session, userID string, entries []QueueImportEntry) ([]QueueItem, int, error) {
+	if p.service == nil {
+		return nil, 0, ErrQueueStoreNil
+	}
+	if s == nil {
+		return nil, 0, fmt.Errorf("discord session is nil")
+	}
+	if p.manager != nil && !p.manager.ownsGuild(p.guildID) {
+		return nil, 0, ErrGuildNotOwned
+	}
+	p.session = s
+
+	if err := p.ensureVoiceConnection(userID); err != nil {
+		return nil, 0, err
+	}
+
+	tracks, failed := p.service.ResolveImport(ctx, entries, userID)
+	if len(tracks) == 0 {
+		return nil, failed, fmt.Errorf("%w: no entries could be resolved", ErrResolveFailed)
+	}
+
+	items, err := p.EnqueueTracksAndPlay(ctx, s, userID, tracks)
+	return items, failed, err
+}
+
 func (p *Player) Skip() error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -187,7 +379,9 @@ func (p *Player) Stop(clearQueue bool) error {
 
 	if clearQueue && p.service != nil {
 		_ = p.service.Clear(context.Background(), p.guildID)
+		p.publishLocked(PlayerEvent{Type: PlayerEventQueueChanged})
 	}
+	p.publishLocked(PlayerEvent{Type: PlayerEventStopped, Cleared: clearQueue})
 
 	p.cleanupVoiceLocked()
 	return nil
@@ -204,6 +398,7 @@ func (p *Player) TogglePause() error {
 		case p.resumeCh <- struct{}{}:
 		default:
 		}
+		p.publishLocked(PlayerEvent{Type: PlayerEventResumed})
 		return nil
 	}
 
@@ -214,6 +409,19 @@ func (p *Player) TogglePause() error {
 	case p.pauseCh <- struct{}{}:
 	default:
 	}
+	p.publishLocked(PlayerEvent{Type: PlayerEventPaused})
+	return nil
+}
+
+func (p *Player) UpdateSettings(ctx context.Context, settings QueueSettings) error {
+	if p.service == nil {
+		return ErrQueueStoreNil
+	}
+	if err := p.service.SetSettings(ctx, p.guildID, settings); err != nil {
+		return err
+	}
+
+	p.publish(PlayerEvent{Type: PlayerEventSettingsChanged, Settings: settings})
 	return nil
 }
 
@@ -223,6 +431,18 @@ func (p *Player) State() PlaybackState {
 	return p.state
 }
 
+func (p *Player) updateTrackTitle(trackURL string, title string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.state.Track == nil || p.state.Track.URL != trackURL {
+		return
+	}
+	track := *p.state.Track
+	track.Title = title
+	p.state.Track = &track
+}
+
 func (p *Player) ensureWorker() {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -267,7 +487,7 @@ func (p *Player) workerLoop(ctx context.Context) {
 					continue
 				}
 			}
-			log.Printf("music worker error: %v", err)
+			p.logger().Error("music worker error", "error", err)
 			select {
 			case <-ctx.Done():
 				return
@@ -301,7 +521,7 @@ func (p *Player) workerLoop(ctx context.Context) {
 					if errors.Is(err, ErrPlaybackStopped) {
 						return
 					}
-					log.Printf("music playback error: %v", err)
+					p.trackLogger(*item).Error("music playback error", "error", err)
 					break
 				}
 
@@ -316,14 +536,16 @@ func (p *Player) workerLoop(ctx context.Context) {
 			continue
 		}
 
+		skipped := false
 		if err := p.playItem(ctx, *item); err != nil {
-			if errors.Is(err, ErrPlaybackSkipped) {
-				continue
-			}
-			if errors.Is(err, ErrPlaybackStopped) {
+			switch {
+			case errors.Is(err, ErrPlaybackSkipped):
+				skipped = true
+			case errors.Is(err, ErrPlaybackStopped):
 				return
+			default:
+				p.trackLogger(*item).Error("music playback error", "error", err)
 			}
-			log.Printf("music playback error: %v", err)
 		}
 
 		if p.service != nil {
@@ -333,7 +555,7 @@ func (p *Player) workerLoop(ctx context.Context) {
 			}
 		}
 
-		if settings.RepeatMode == RepeatModeTrack {
+		if !skipped && settings.RepeatMode == RepeatModeTrack {
 			for {
 				if err := p.playItem(ctx, *item); err != nil {
 					if errors.Is(err, ErrPlaybackSkipped) {
@@ -342,7 +564,7 @@ func (p *Player) workerLoop(ctx context.Context) {
 					if errors.Is(err, ErrPlaybackStopped) {
 						return
 					}
-					log.Printf("music playback error: %v", err)
+					p.trackLogger(*item).Error("music playback error", "error", err)
 					break
 				}
 
@@ -364,7 +586,9 @@ func (p *Player) workerLoop(ctx context.Context) {
 		}
 
 		if settings.RepeatMode == RepeatModeQueue && p.service != nil {
-			_, _ = p.service.ResolveAndEnqueue(ctx, p.guildID, item.Track.URL, item.Track.Source, item.Track.RequestedBy, item.Priority)
+			if requeued, err := p.service.ResolveAndEnqueue(ctx, p.guildID, item.Track.URL, item.Track.Source, item.Track.RequestedBy, item.Priority); err == nil {
+				p.publish(PlayerEvent{Type: PlayerEventQueueChanged, Item: requeued})
+			}
 		}
 	}
 }
@@ -374,14 +598,21 @@ func (p *Player) nextQueueItem(ctx context.Context) (*QueueItem, error) {
 		return nil, ErrQueueStoreNil
 	}
 
+	var (
+		item *QueueItem
+		err  error
+	)
 	settings, _ := p.service.GetSettings(ctx, p.guildID)
-	if settings.Shuffle {
-		if store := p.service.queue; store != nil {
-			return store.DequeueRandom(ctx, p.guildID)
-		}
+	if store := p.service.queue; settings.Shuffle && store != nil {
+		item, err = store.DequeueRandom(ctx, p.guildID)
+	} else {
+		item, err = p.service.Dequeue(ctx, p.guildID)
 	}
 
-	return p.service.Dequeue(ctx, p.guildID)
+	if err == nil && item != nil {
+		p.publish(PlayerEvent{Type: PlayerEventQueueChanged, Item: *item})
+	}
+	return item, err
 }
 
 func (p *Player) playItem(ctx context.Context, item QueueItem) error {
@@ -390,6 +621,23 @@ func (p *Player) playItem(ctx context.Context, item QueueItem) error {
 		p.mu.Unlock()
 		return ErrVoiceNotConnected
 	}
+	p.mu.Unlock()
+
+	providers := p.service.Providers()
+	if providers == nil {
+		return ErrResolverNil
+	}
+	provider, err := providers.Lookup(item.Track.URL, item.Track.Source)
+	if err != nil {
+		return err
+	}
+
+	streamURL, err := provider.StreamURL(ctx, item.Track)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
 	p.state = PlaybackState{
 		Track:     &item.Track,
 		StartedAt: time.Now().UTC(),
@@ -399,14 +647,9 @@ func (p *Player) playItem(ctx context.Context, item QueueItem) error {
 	}
 	p.mu.Unlock()
 
-	streamURL, err := p.resolver.ResolveStreamURL(ctx, item.Track.URL, item.Track.Source)
-	if err != nil {
-		return err
-	}
-
 	p.frameCount = 0
+	p.seekOffset = 0
 	p.paused = false
-	p.state.PausedAt = nil
 
 	playCtx, cancel := context.WithCancel(context.Background())
 	p.mu.Lock()
@@ -415,16 +658,49 @@ func (p *Player) playItem(ctx context.Context, item QueueItem) error {
 	p.mu.Unlock()
 	defer cancel()
 
+	var open func(ctx context.Context) (io.ReadCloser, error)
+	if opener, ok := provider.(StreamOpener); ok {
+		open = func(ctx context.Context) (io.ReadCloser, error) {
+			return opener.OpenStream(ctx, streamURL)
+		}
+	}
+
+	if watcher, ok := provider.(MetadataWatcher); ok {
+		go watcher.WatchMetadata(playCtx, item.Track, func(title string) {
+			p.updateTrackTitle(item.Track.URL, title)
+		})
+	}
+
+	p.publish(PlayerEvent{Type: PlayerEventTrackStarted, Item: item})
+
+	logger := p.trackLogger(item)
+	logger.Debug("track started", "source", item.Track.Source, "title", item.Track.Title)
+
 	for {
-		err := p.streamAudio(playCtx, streamURL)
+		err := p.streamAudio(playCtx, logger, streamURL, open)
 		if errors.Is(err, ErrPlaybackRestarted) {
+			p.mu.Lock()
+			seeking := p.seeking
+			p.seeking = false
+			p.mu.Unlock()
+			if !seeking {
+				metrics.FFmpegRestarts.Inc()
+			}
 			continue
 		}
+		if err == nil && playCtx.Err() != nil {
+			err = ErrPlaybackStopped
+		}
+
+		p.mu.Lock()
+		p.state = PlaybackState{Volume: p.volume}
+		p.publishLocked(PlayerEvent{Type: PlayerEventTrackEnded, Item: item, Reason: trackEndReason(err), Err: err})
+		p.mu.Unlock()
 		return err
 	}
 }
 
-func (p *Player) streamAudio(ctx context.Context, url string) error {
+func (p *Player) streamAudio(ctx context.Context, logger *slog.Logger, url string, open func(ctx context.Context) (io.ReadCloser, error)) error {
 	p.mu.Lock()
 	vc := p.vc
 	p.mu.Unlock()
@@ -435,11 +711,35 @@ func (p *Player) streamAudio(ctx context.Context, url string) error {
 	ffmpegCtx, ffmpegCancel := context.WithCancel(ctx)
 	defer ffmpegCancel()
 
-	volume := 1.0
-	args := []string{
-		"-reconnect", "1",
-		"-reconnect_streamed", "1",
-		"-reconnect_delay_max", "5",
+	p.mu.Lock()
+	volume := float64(p.volume) / 100
+	offset := p.seekOffset
+	p.mu.Unlock()
+
+	var input io.ReadCloser
+	args := []string{}
+	if open != nil {
+		body, err := open(ffmpegCtx)
+		if err != nil {
+			return err
+		}
+		input = body
+		url = "pipe:0"
+		args = append(args, "-protocol_whitelist", pipeProtocols)
+	} else if _, ok := parseHTTPURL(url); ok {
+		args = append(args,
+			"-protocol_whitelist", httpProtocols,
+			"-reconnect", "1",
+			"-reconnect_streamed", "1",
+			"-reconnect_delay_max", "5",
+		)
+	} else {
+		args = append(args, "-protocol_whitelist", localProtocols)
+	}
+	if offset > 0 {
+		args = append(args, "-ss", fmt.Sprintf("%.3f", offset.Seconds()))
+	}
+	args = append(args,
 		"-i", url,
 		"-af", fmt.Sprintf("volume=%.2f", volume),
 		"-c:a", "libopus",
@@ -452,9 +752,17 @@ func (p *Player) streamAudio(ctx context.Context, url string) error {
 		"-f", "ogg",
 		"-loglevel", "warning",
 		"pipe:1",
+	)
+
+	if input != nil {
+		defer input.Close()
 	}
 
 	cmd := exec.CommandContext(ffmpegCtx, "ffmpeg", args...)
+	if input != nil {
+		cmd.Stdin = input
+		cmd.WaitDelay = time.Second
+	}
 
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
@@ -471,13 +779,10 @@ func (p *Player) streamAudio(ctx context.Context, url string) error {
 	}
 
 	go func() {
-		reader := bufio.NewReader(stderr)
-		for {
-			line, err := reader.ReadString('\n')
-			if err != nil {
-				return
-			}
-			if line != "" {
+		scanner := bufio.NewScanner(stderr)
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				logger.Debug("ffmpeg", "stderr", line)
 			}
 		}
 	}()
@@ -489,6 +794,7 @@ func (p *Player) streamAudio(ctx context.Context, url string) error {
 	p.mu.Unlock()
 
 	defer func() {
+		ffmpegCancel()
 		p.mu.Lock()
 		if p.ffmpegCmd != nil && p.ffmpegCmd.Process != nil {
 			_ = p.ffmpegCmd.Process.Kill()
@@ -503,10 +809,10 @@ func (p *Player) streamAudio(ctx context.Context, url string) error {
 	safeSpeaking(vc, true)
 	defer safeSpeaking(vc, false)
 
-	return p.streamOpusFromOgg(ctx, stdout, vc)
+	return p.streamOpusFromOgg(ctx, logger, stdout, vc)
 }
 
-func (p *Player) streamOpusFromOgg(ctx context.Context, r io.Reader, vc *discordgo.VoiceConnection) error {
+func (p *Player) streamOpusFromOgg(ctx context.Context, logger *slog.Logger, r io.Reader, vc *discordgo.VoiceConnection) error {
 	reader := bufio.NewReaderSize(r, 65536)
 	ticker := time.NewTicker(20 * time.Millisecond)
 	defer ticker.Stop()
@@ -516,16 +822,16 @@ func (p *Player) streamOpusFromOgg(ctx context.Context, r io.Reader, vc *discord
 	for {
 		select {
 		case <-ctx.Done():
-			log.Printf("Context done, stopping stream after %d frames", framesSent)
+			logger.Debug("context done, stopping stream", "frames", framesSent)
 			return nil
 		case <-p.stopCh:
-			log.Printf("Stop signal received, stopping stream after %d frames", framesSent)
+			logger.Debug("stop signal received, stopping stream", "frames", framesSent)
 			return nil
 		case <-p.skipCh:
-			log.Printf("Skip signal received, stopping stream after %d frames", framesSent)
-			return nil
+			logger.Debug("skip signal received, stopping stream", "frames", framesSent)
+			return ErrPlaybackSkipped
 		case <-p.restartCh:
-			log.Printf("Restart signal received, restarting stream after %d frames", framesSent)
+			logger.Info("restart signal received, restarting stream", "frames", framesSent)
 			return ErrPlaybackRestarted
 		default:
 		}
@@ -543,10 +849,10 @@ func (p *Player) streamOpusFromOgg(ctx context.Context, r io.Reader, vc *discord
 		header, err := p.readOggPageHeader(reader)
 		if err != nil {
 			if err == io.EOF {
-				log.Printf("Audio stream ended (EOF) after %d frames", framesSent)
+				logger.Debug("audio stream ended", "frames", framesSent)
 				return nil
 			}
-			log.Printf("Error reading OGG header: %v (after %d frames)", err, framesSent)
+			logger.Error("failed to read ogg page header", "error", err, "frames", framesSent)
 			return err
 		}
 
@@ -567,7 +873,7 @@ func (p *Player) streamOpusFromOgg(ctx context.Context, r io.Reader, vc *discord
 			case <-p.stopCh:
 				return nil
 			case <-p.skipCh:
-				return nil
+				return ErrPlaybackSkipped
 			case <-p.restartCh:
 				return ErrPlaybackRestarted
 			default:
@@ -587,7 +893,9 @@ func (p *Player) streamOpusFromOgg(ctx context.Context, r io.Reader, vc *discord
 					case <-p.stopCh:
 						return nil
 					case <-p.skipCh:
-						return nil
+						return ErrPlaybackSkipped
+					case <-p.restartCh:
+						return ErrPlaybackRestarted
 					default:
 					}
 					p.mu.Lock()
@@ -603,12 +911,13 @@ func (p *Player) streamOpusFromOgg(ctx context.Context, r io.Reader, vc *discord
 			case vc.OpusSend <- packet:
 				p.frameCount++
 				framesSent++
-				position := time.Duration(p.frameCount) * 20 * time.Millisecond
+				position := p.seekOffset + time.Duration(p.frameCount)*20*time.Millisecond
 				p.state.Position = position
 			case <-ctx.Done():
 				return nil
 			case <-time.After(time.Second):
-				log.Printf("Timeout sending opus frame %d", framesSent)
+				metrics.OpusSendTimeouts.Inc()
+				logger.Warn("timeout sending opus frame", "frame", framesSent)
 			}
 		}
 	}
@@ -729,6 +1038,9 @@ func (p *Player) ensureVoiceConnection(userID string) error {
 	if p.session == nil {
 		return fmt.Errorf("discord session is nil")
 	}
+	if p.manager != nil && !p.manager.ownsGuild(p.guildID) {
+		return ErrGuildNotOwned
+	}
 
 	channelID, err := findUserVoiceChannel(p.session, p.guildID, userID)
 	if err != nil {
@@ -742,6 +1054,7 @@ func (p *Player) ensureVoiceConnection(userID string) error {
 
 	p.mu.Lock()
 	p.vc = vc
+	p.publishLocked(PlayerEvent{Type: PlayerEventVoiceJoined, ChannelID: channelID})
 	p.mu.Unlock()
 	return nil
 }
@@ -761,8 +1074,10 @@ func (p *Player) cleanupVoiceLocked() {
 		p.ffmpegStdout = nil
 	}
 	if p.vc != nil {
+		channelID := p.vc.ChannelID
 		_ = p.vc.Disconnect()
 		p.vc = nil
+		p.publishLocked(PlayerEvent{Type: PlayerEventVoiceLeft, ChannelID: channelID})
 	}
 	if p.cancel != nil {
 		p.cancel()
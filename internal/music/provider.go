@@ -0,0 +1,147 @@
+package music
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+var ErrProviderNotFound = errors.New("no provider registered for source")
+
+type Provider interface {
+	Source() TrackSource
+	Match(input string) bool
+	Search(ctx context.Context, query string, limit int) ([]Track, error)
+	Resolve(ctx context.Context, input string) (Track, error)
+	StreamURL(ctx context.Context, track Track) (string, error)
+}
+
+type MetadataWatcher interface {
+	WatchMetadata(ctx context.Context, track Track, update func(title string))
+}
+
+type StreamOpener interface {
+	OpenStream(ctx context.Context, streamURL string) (io.ReadCloser, error)
+}
+
+type URLProber interface {
+	Probe(ctx context.Context, input string) bool
+}
+
+type CollectionProvider interface {
+	ResolveCollection(ctx context.Context, input string) ([]Track, bool, error)
+}
+
+type ProviderRegistry struct {
+	mu        sync.RWMutex
+	providers []Provider
+	fallback  TrackSource
+}
+
+func NewProviderRegistry(fallback TrackSource) *ProviderRegistry {
+	return &ProviderRegistry{fallback: fallback}
+}
+
+func NewDefaultProviderRegistry(spotify *SpotifyClient) *ProviderRegistry {
+	resolver := NewYTDLPResolver()
+	youtube := NewYouTubeProvider(resolver)
+
+	r := NewProviderRegistry(TrackSourceYouTube)
+	r.Register(youtube)
+	r.Register(NewSoundCloudProvider(resolver))
+	r.Register(NewBandcampProvider(resolver))
+	r.Register(NewVimeoProvider(resolver))
+	r.Register(NewTwitchProvider(resolver))
+	r.Register(NewMixcloudProvider(resolver))
+	r.Register(NewSpotifyProvider(spotify, youtube))
+	r.Register(NewAppleMusicProvider(youtube))
+	r.Register(NewDeezerProvider(youtube))
+	r.Register(NewTidalProvider(youtube))
+	r.Register(NewHTTPProvider())
+	return r
+}
+
+func (r *ProviderRegistry) Register(p Provider) {
+	if p == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for idx, existing := range r.providers {
+		if existing.Source() == p.Source() {
+			r.providers[idx] = p
+			return
+		}
+	}
+	r.providers = append(r.providers, p)
+}
+
+func (r *ProviderRegistry) Get(source TrackSource) (Provider, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, p := range r.providers {
+		if p.Source() == source {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+func (r *ProviderRegistry) Providers() []Provider {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]Provider, len(r.providers))
+	copy(out, r.providers)
+	return out
+}
+
+func (r *ProviderRegistry) Detect(input string) TrackSource {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, p := range r.providers {
+		if p.Match(input) {
+			return p.Source()
+		}
+	}
+	return TrackSourceUnknown
+}
+
+func (r *ProviderRegistry) Lookup(input string, hint TrackSource) (Provider, error) {
+	if hint != "" && hint != TrackSourceUnknown {
+		if p, ok := r.Get(hint); ok {
+			return p, nil
+		}
+		return nil, fmt.Errorf("%w: %s", ErrProviderNotFound, hint)
+	}
+
+	if source := r.Detect(input); source != TrackSourceUnknown {
+		if p, ok := r.Get(source); ok {
+			return p, nil
+		}
+	}
+
+	if p, ok := r.Get(r.fallback); ok {
+		return p, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrProviderNotFound, r.fallback)
+}
+
+func (r *ProviderRegistry) Probe(ctx context.Context, input string) (Provider, bool) {
+	for _, p := range r.Providers() {
+		prober, ok := p.(URLProber)
+		if !ok {
+			continue
+		}
+		if prober.Probe(ctx, input) {
+			return p, true
+		}
+	}
+	return nil, false
+}
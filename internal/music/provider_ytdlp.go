@@ -0,0 +1,113 @@
+package music
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hxnx/tunebot/internal/metrics"
+)
+
+var ErrSearchUnsupported = errors.New("source does not support search")
+
+type ytdlpProvider struct {
+	source       TrackSource
+	hosts        []string
+	searchPrefix string
+	resolver     *YTDLPResolver
+}
+
+func (p *ytdlpProvider) Source() TrackSource {
+	return p.source
+}
+
+func (p *ytdlpProvider) Match(input string) bool {
+	return matchURLHost(input, p.hosts...)
+}
+
+func (p *ytdlpProvider) Search(ctx context.Context, query string, limit int) ([]Track, error) {
+	query = strings.TrimSpace(query)
+	if looksLikeURL(query) {
+		track, err := p.Resolve(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		return []Track{track}, nil
+	}
+
+	if p.searchPrefix == "" {
+		return nil, fmt.Errorf("%w: %s", ErrSearchUnsupported, p.source)
+	}
+	if limit <= 0 {
+		limit = 1
+	}
+	started := time.Now()
+	tracks, err := p.resolver.ResolveSearch(ctx, p.searchTarget(query, limit), p.source, limit)
+	p.observe("search", started, err)
+	return tracks, err
+}
+
+func (p *ytdlpProvider) Resolve(ctx context.Context, input string) (Track, error) {
+	input = strings.TrimSpace(input)
+	if looksLikeURL(input) {
+		source := p.source
+		if !p.Match(input) {
+			source = TrackSourceUnknown
+		}
+		started := time.Now()
+		track, err := p.resolver.Resolve(ctx, input, source)
+		p.observe("resolve", started, err)
+		return track, err
+	}
+	if p.searchPrefix == "" {
+		return Track{}, fmt.Errorf("%w: %s", ErrSearchUnsupported, p.source)
+	}
+	started := time.Now()
+	track, err := p.resolver.Resolve(ctx, p.searchTarget(input, 1), p.source)
+	p.observe("resolve", started, err)
+	return track, err
+}
+
+func (p *ytdlpProvider) StreamURL(ctx context.Context, track Track) (string, error) {
+	target := strings.TrimSpace(track.URL)
+	if !looksLikeURL(target) {
+		target = p.searchTarget(target, 1)
+	}
+	started := time.Now()
+	streamURL, err := p.resolver.ResolveStreamURL(ctx, target)
+	p.observe("stream", started, err)
+	return streamURL, err
+}
+
+func (p *ytdlpProvider) observe(operation string, started time.Time, err error) {
+	source := string(p.source)
+	metrics.ResolveDuration.WithLabelValues(source, operation).Observe(time.Since(started).Seconds())
+	if err != nil && !errors.Is(err, context.Canceled) {
+		metrics.ResolveFailures.WithLabelValues(source, operation).Inc()
+	}
+}
+
+func (p *ytdlpProvider) searchTarget(query string, limit int) string {
+	if p.searchPrefix == "" {
+		return query
+	}
+	return fmt.Sprintf("%s%d:%s", p.searchPrefix, limit, query)
+}
+
+func matchURLHost(input string, hosts ...string) bool {
+	u, err := url.Parse(strings.TrimSpace(input))
+	if err != nil || u.Host == "" {
+		return false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	for _, h := range hosts {
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return true
+		}
+	}
+	return false
+}
@@ -13,7 +13,10 @@ import (
 	redislib "github.com/redis/go-redis/v9"
 )
 
-var ErrQueueEmpty = errors.New("queue is empty")
+var (
+	ErrQueueEmpty           = errors.New("queue is empty")
+	ErrQueueIndexOutOfRange = errors.New("queue index is out of range")
+)
 
 const (
 	queueKeyPrefix    = "music:queue:"
@@ -210,6 +213,46 @@ func (q *QueueStore) Clear(ctx context.Context, guildID string) error {
 	return q.client.Del(ctx, queueKey(guildID)).Err()
 }
 
+func (q *QueueStore) Move(ctx context.Context, guildID string, from int, to int) error {
+	if err := q.ensureClient(); err != nil {
+		return err
+	}
+	if guildID == "" {
+		return fmt.Errorf("guild id is required")
+	}
+
+	key := queueKey(guildID)
+	return q.client.Watch(ctx, func(tx *redislib.Tx) error {
+		entries, err := tx.ZRangeWithScores(ctx, key, 0, -1).Result()
+		if err != nil {
+			return err
+		}
+		if from < 0 || from >= len(entries) || to < 0 || to >= len(entries) {
+			return ErrQueueIndexOutOfRange
+		}
+		if from == to {
+			return nil
+		}
+
+		scores := make([]float64, len(entries))
+		for idx, entry := range entries {
+			scores[idx] = entry.Score
+		}
+
+		moved := entries[from]
+		entries = append(entries[:from], entries[from+1:]...)
+		entries = append(entries[:to], append([]redislib.Z{moved}, entries[to:]...)...)
+
+		_, err = tx.TxPipelined(ctx, func(pipe redislib.Pipeliner) error {
+			for idx, entry := range entries {
+				pipe.ZAdd(ctx, key, redislib.Z{Score: scores[idx], Member: entry.Member})
+			}
+			return nil
+		})
+		return err
+	}, key)
+}
+
 func (q *QueueStore) GetSettings(ctx context.Context, guildID string) (QueueSettings, error) {
 	if err := q.ensureClient(); err != nil {
 		return QueueSettings{}, err
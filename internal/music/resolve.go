@@ -1,10 +1,12 @@
 package music
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/url"
 	"os"
 	"os/exec"
@@ -24,22 +26,13 @@ func NewYTDLPResolver() *YTDLPResolver {
 	}
 }
 
-func (r *YTDLPResolver) Resolve(ctx context.Context, input string, sourceHint TrackSource) (Track, error) {
-	if strings.TrimSpace(input) == "" {
+func (r *YTDLPResolver) Resolve(ctx context.Context, target string, source TrackSource) (Track, error) {
+	target = strings.TrimSpace(target)
+	if target == "" {
 		return Track{}, fmt.Errorf("%w: empty input", ErrResolveFailed)
 	}
 
-	target := strings.TrimSpace(input)
-	if !looksLikeURL(target) {
-		switch sourceHint {
-		case TrackSourceSoundCloud:
-			target = "scsearch1:" + target
-		default:
-			target = "ytsearch1:" + target
-		}
-	}
-
-	args := []string{
+	output, err := r.run(ctx,
 		"--no-warnings",
 		"--dump-single-json",
 		"--skip-download",
@@ -47,13 +40,9 @@ func (r *YTDLPResolver) Resolve(ctx context.Context, input string, sourceHint Tr
 		"--paths",
 		"/app/tmp",
 		target,
-	}
-
-	cmd := exec.CommandContext(ctx, r.Binary, args...)
-	cmd.Env = append(os.Environ(), "TMPDIR=/app/tmp", "TEMP=/app/tmp", "TMP=/app/tmp")
-	output, err := cmd.CombinedOutput()
+	)
 	if err != nil {
-		return Track{}, fmt.Errorf("%w: yt-dlp failed: %v: %s", ErrResolveFailed, err, strings.TrimSpace(string(output)))
+		return Track{}, err
 	}
 
 	var root ytDLPItem
@@ -66,42 +55,17 @@ func (r *YTDLPResolver) Resolve(ctx context.Context, input string, sourceHint Tr
 		return Track{}, err
 	}
 
-	link := item.WebpageURL
-	if link == "" {
-		link = item.URL
-	}
-	if link == "" {
+	track, ok := trackFromYTDLPItem(item, source)
+	if !ok {
 		return Track{}, fmt.Errorf("%w: missing track url", ErrResolveFailed)
 	}
 
-	title := strings.TrimSpace(item.Title)
-	if title == "" {
-		title = "Unknown Title"
-	}
-
-	source := sourceHint
-	if source == TrackSourceUnknown || source == "" {
-		source = detectSourceFromURL(link)
-	}
-
-	duration := time.Duration(item.Duration * float64(time.Second))
-	if duration < 0 {
-		duration = 0
-	}
-
-	return Track{
-		ID:          item.ID,
-		Title:       title,
-		URL:         link,
-		Source:      source,
-		Duration:    duration,
-		Thumbnail:   item.Thumbnail,
-		RequestedBy: "",
-	}, nil
+	return track, nil
 }
 
-func (r *YTDLPResolver) ResolveSearch(ctx context.Context, input string, sourceHint TrackSource, limit int) ([]Track, error) {
-	if strings.TrimSpace(input) == "" {
+func (r *YTDLPResolver) ResolveSearch(ctx context.Context, target string, source TrackSource, limit int) ([]Track, error) {
+	target = strings.TrimSpace(target)
+	if target == "" {
 		return nil, fmt.Errorf("%w: empty input", ErrResolveFailed)
 	}
 
@@ -112,23 +76,7 @@ func (r *YTDLPResolver) ResolveSearch(ctx context.Context, input string, sourceH
 		limit = 10
 	}
 
-	target := strings.TrimSpace(input)
-	if looksLikeURL(target) {
-		track, err := r.Resolve(ctx, target, sourceHint)
-		if err != nil {
-			return nil, err
-		}
-		return []Track{track}, nil
-	}
-
-	switch sourceHint {
-	case TrackSourceSoundCloud:
-		target = fmt.Sprintf("scsearch%d:%s", limit, target)
-	default:
-		target = fmt.Sprintf("ytsearch%d:%s", limit, target)
-	}
-
-	args := []string{
+	output, err := r.run(ctx,
 		"--no-warnings",
 		"--dump-single-json",
 		"--skip-download",
@@ -137,13 +85,9 @@ func (r *YTDLPResolver) ResolveSearch(ctx context.Context, input string, sourceH
 		"--paths",
 		"/app/tmp",
 		target,
-	}
-
-	cmd := exec.CommandContext(ctx, r.Binary, args...)
-	cmd.Env = append(os.Environ(), "TMPDIR=/app/tmp", "TEMP=/app/tmp", "TMP=/app/tmp")
-	output, err := cmd.CombinedOutput()
+	)
 	if err != nil {
-		return nil, fmt.Errorf("%w: yt-dlp failed: %v: %s", ErrResolveFailed, err, strings.TrimSpace(string(output)))
+		return nil, err
 	}
 
 	var root ytDLPItem
@@ -158,38 +102,9 @@ func (r *YTDLPResolver) ResolveSearch(ctx context.Context, input string, sourceH
 
 	results := make([]Track, 0, len(items))
 	for _, item := range items {
-		link := item.WebpageURL
-		if link == "" {
-			link = item.URL
-		}
-		if link == "" {
-			continue
+		if track, ok := trackFromYTDLPItem(item, source); ok {
+			results = append(results, track)
 		}
-
-		title := strings.TrimSpace(item.Title)
-		if title == "" {
-			title = "Unknown Title"
-		}
-
-		source := sourceHint
-		if source == TrackSourceUnknown || source == "" {
-			source = detectSourceFromURL(link)
-		}
-
-		duration := time.Duration(item.Duration * float64(time.Second))
-		if duration < 0 {
-			duration = 0
-		}
-
-		results = append(results, Track{
-			ID:          item.ID,
-			Title:       title,
-			URL:         link,
-			Source:      source,
-			Duration:    duration,
-			Thumbnail:   item.Thumbnail,
-			RequestedBy: "",
-		})
 	}
 
 	if len(results) == 0 {
@@ -199,45 +114,100 @@ func (r *YTDLPResolver) ResolveSearch(ctx context.Context, input string, sourceH
 	return results, nil
 }
 
-func (r *YTDLPResolver) ResolveStreamURL(ctx context.Context, input string, sourceHint TrackSource) (string, error) {
-	if strings.TrimSpace(input) == "" {
+func (r *YTDLPResolver) ResolveStreamURL(ctx context.Context, target string) (string, error) {
+	target = strings.TrimSpace(target)
+	if target == "" {
 		return "", fmt.Errorf("%w: empty input", ErrResolveFailed)
 	}
 
-	target := strings.TrimSpace(input)
-	if !looksLikeURL(target) {
-		switch sourceHint {
-		case TrackSourceSoundCloud:
-			target = "scsearch1:" + target
-		default:
-			target = "ytsearch1:" + target
-		}
-	}
-
-	args := []string{
+	output, err := r.run(ctx,
 		"--no-warnings",
 		"-f",
-		"bestaudio",
+		"bestaudio/best",
 		"-g",
 		"--no-playlist",
 		"--paths",
 		"/app/tmp",
 		target,
+	)
+	if err != nil {
+		return "", err
 	}
 
+	streamURL, _, _ := strings.Cut(strings.TrimSpace(string(output)), "\n")
+	streamURL = strings.TrimSpace(streamURL)
+	if streamURL == "" {
+		return "", fmt.Errorf("%w: empty stream url", ErrResolveFailed)
+	}
+
+	return streamURL, nil
+}
+
+func (r *YTDLPResolver) run(ctx context.Context, args ...string) ([]byte, error) {
 	cmd := exec.CommandContext(ctx, r.Binary, args...)
 	cmd.Env = append(os.Environ(), "TMPDIR=/app/tmp", "TEMP=/app/tmp", "TMP=/app/tmp")
-	output, err := cmd.CombinedOutput()
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+
+	stderrText := strings.TrimSpace(stderr.String())
+	if stderrText != "" && slog.Default().Enabled(ctx, slog.LevelDebug) {
+		target := ""
+		if len(args) > 0 {
+			target = args[len(args)-1]
+		}
+		for _, line := range strings.Split(stderrText, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				slog.Debug("yt-dlp", "target", target, "stderr", line)
+			}
+		}
+	}
+
 	if err != nil {
-		return "", fmt.Errorf("%w: yt-dlp failed: %v: %s", ErrResolveFailed, err, strings.TrimSpace(string(output)))
+		return nil, fmt.Errorf("%w: yt-dlp failed: %v: %s", ErrResolveFailed, err, stderrText)
 	}
+	return output, nil
+}
 
-	streamURL := strings.TrimSpace(string(output))
-	if streamURL == "" {
-		return "", fmt.Errorf("%w: empty stream url", ErrResolveFailed)
+func trackFromYTDLPItem(item ytDLPItem, source TrackSource) (Track, bool) {
+	link := item.WebpageURL
+	if link == "" {
+		link = item.URL
+	}
+	if link == "" {
+		return Track{}, false
 	}
 
-	return streamURL, nil
+	title := strings.TrimSpace(item.Title)
+	if title == "" {
+		title = "Unknown Title"
+	}
+
+	if source == "" {
+		source = TrackSourceUnknown
+	}
+
+	duration := time.Duration(item.Duration * float64(time.Second))
+	if duration < 0 {
+		duration = 0
+	}
+
+	isLive := item.IsLive || item.LiveStatus == "is_live"
+	if isLive {
+		duration = 0
+	}
+
+	return Track{
+		ID:          item.ID,
+		Title:       title,
+		URL:         link,
+		Source:      source,
+		Duration:    duration,
+		Thumbnail:   item.Thumbnail,
+		RequestedBy: "",
+		IsLive:      isLive,
+	}, true
 }
 
 type ytDLPItem struct {
@@ -247,6 +217,8 @@ type ytDLPItem struct {
 	URL        string      `json:"url"`
 	Duration   float64     `json:"duration"`
 	Thumbnail  string      `json:"thumbnail"`
+	IsLive     bool        `json:"is_live"`
+	LiveStatus string      `json:"live_status"`
 	Entries    []ytDLPItem `json:"entries"`
 }
 
@@ -302,22 +274,3 @@ func looksLikeURL(value string) bool {
 	u, err := url.Parse(value)
 	return err == nil && u.Scheme != "" && u.Host != ""
 }
-
-func detectSourceFromURL(raw string) TrackSource {
-	u, err := url.Parse(raw)
-	if err != nil {
-		return TrackSourceUnknown
-	}
-
-	host := strings.ToLower(u.Host)
-	switch {
-	case strings.Contains(host, "youtube.com"), strings.Contains(host, "youtu.be"):
-		return TrackSourceYouTube
-	case strings.Contains(host, "soundcloud.com"):
-		return TrackSourceSoundCloud
-	case strings.Contains(host, "spotify.com"):
-		return TrackSourceSpotify
-	default:
-		return TrackSourceUnknown
-	}
-}
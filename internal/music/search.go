@@ -14,6 +14,7 @@ const searchCacheTTL = 5 * time.Minute
 
 type searchCacheEntry struct {
 	results   []Track
+	limit     int
 	expiresAt time.Time
 }
 
@@ -29,53 +30,28 @@ func SearchTracks(
 	query string,
 	sourceHint TrackSource,
 	limit int,
-	resolver *YTDLPResolver,
-	spotify *SpotifyClient,
+	providers *ProviderRegistry,
 ) ([]Track, error) {
 	query = strings.TrimSpace(query)
 	if query == "" {
 		return nil, ErrMissingInput
 	}
-
-	if limit <= 0 {
-		limit = defaultSearchLimit
-	}
-	if limit > maxSearchLimit {
-		limit = maxSearchLimit
+	if providers == nil {
+		return nil, ErrResolverNil
 	}
 
+	limit = normalizeSearchLimit(limit)
 	key := cacheKey(query, sourceHint)
-	if cached, ok := getCachedResults(key); ok {
+	if cached, ok := getCachedResults(key, limit); ok {
 		return cached, nil
 	}
 
-	if isSpotifyInput(query, sourceHint) {
-		if spotify == nil {
-			return nil, ErrSpotifyClientNil
-		}
-		lower := strings.ToLower(query)
-		if strings.Contains(lower, "spotify.com") || strings.HasPrefix(lower, "spotify:track:") {
-			track, err := spotify.ResolveTrack(ctx, query)
-			if err != nil {
-				return nil, err
-			}
-			results := []Track{track}
-			setCachedResults(key, results)
-			return results, nil
-		}
-		results, err := spotify.SearchTracks(ctx, query, limit)
-		if err != nil {
-			return nil, err
-		}
-		setCachedResults(key, results)
-		return results, nil
-	}
-
-	if resolver == nil {
-		return nil, ErrResolverNil
+	provider, err := providers.Lookup(query, sourceHint)
+	if err != nil {
+		return nil, err
 	}
 
-	results, err := resolver.ResolveSearch(ctx, query, sourceHint, limit)
+	results, err := provider.Search(ctx, query, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -83,16 +59,27 @@ func SearchTracks(
 		return nil, fmt.Errorf("%w: no search results", ErrResolveFailed)
 	}
 
-	setCachedResults(key, results)
+	setCachedResults(key, limit, results)
 	return results, nil
 }
 
+func CachedSearchResults(query string, sourceHint TrackSource, limit int) ([]Track, bool) {
+	return getCachedResults(cacheKey(query, sourceHint), normalizeSearchLimit(limit))
+}
+
+func normalizeSearchLimit(limit int) int {
+	if limit <= 0 {
+		return defaultSearchLimit
+	}
+	return min(limit, maxSearchLimit)
+}
+
 func cacheKey(query string, sourceHint TrackSource) string {
 	normalized := strings.ToLower(strings.TrimSpace(query))
 	return string(sourceHint) + ":" + normalized
 }
 
-func getCachedResults(key string) ([]Track, bool) {
+func getCachedResults(key string, limit int) ([]Track, bool) {
 	searchCache.mu.RLock()
 	entry, ok := searchCache.data[key]
 	searchCache.mu.RUnlock()
@@ -105,14 +92,30 @@ func getCachedResults(key string) ([]Track, bool) {
 		searchCache.mu.Unlock()
 		return nil, false
 	}
+	if entry.limit < limit {
+		return nil, false
+	}
+	if limit > 0 && len(entry.results) > limit {
+		return entry.results[:limit], true
+	}
 	return entry.results, true
 }
 
-func setCachedResults(key string, results []Track) {
+func setCachedResults(key string, limit int, results []Track) {
 	searchCache.mu.Lock()
 	searchCache.data[key] = searchCacheEntry{
 		results:   results,
+		limit:     limit,
 		expiresAt: time.Now().Add(searchCacheTTL),
 	}
 	searchCache.mu.Unlock()
 }
+
+func ClearSearchCache() int {
+	searchCache.mu.Lock()
+	defer searchCache.mu.Unlock()
+
+	cleared := len(searchCache.data)
+	searchCache.data = make(map[string]searchCacheEntry)
+	return cleared
+}
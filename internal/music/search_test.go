@@ -0,0 +1,91 @@
+package music
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type countingSearchProvider struct {
+	calls int
+}
+
+func (p *countingSearchProvider) Source() TrackSource { return TrackSourceYouTube }
+
+func (p *countingSearchProvider) Match(input string) bool { return false }
+
+func (p *countingSearchProvider) Search(ctx context.Context, query string, limit int) ([]Track, error) {
+	p.calls++
+	tracks := make([]Track, 0, limit)
+	for idx := range limit {
+		tracks = append(tracks, Track{ID: fmt.Sprint(idx), Title: fmt.Sprintf("%s %d", query, idx)})
+	}
+	return tracks, nil
+}
+
+func (p *countingSearchProvider) Resolve(ctx context.Context, input string) (Track, error) {
+	return Track{}, ErrResolveFailed
+}
+
+func (p *countingSearchProvider) StreamURL(ctx context.Context, track Track) (string, error) {
+	return "", ErrResolveFailed
+}
+
+func TestSearchTracksCacheRespectsLimit(t *testing.T) {
+	ClearSearchCache()
+	t.Cleanup(func() { ClearSearchCache() })
+
+	provider := &countingSearchProvider{}
+	registry := NewProviderRegistry(TrackSourceYouTube)
+	registry.Register(provider)
+
+	query := strings.ToLower(t.Name())
+	single, err := SearchTracks(context.Background(), query, TrackSourceUnknown, 1, registry)
+	if err != nil || len(single) != 1 {
+		t.Fatalf("limit 1: got %d results, err %v", len(single), err)
+	}
+
+	full, err := SearchTracks(context.Background(), query, TrackSourceUnknown, 0, registry)
+	if err != nil {
+		t.Fatalf("default limit: %v", err)
+	}
+	if len(full) != defaultSearchLimit {
+		t.Fatalf("default limit returned %d cached results, want %d", len(full), defaultSearchLimit)
+	}
+	if provider.calls != 2 {
+		t.Fatalf("provider searched %d times, want 2", provider.calls)
+	}
+
+	again, err := SearchTracks(context.Background(), query, TrackSourceUnknown, 2, registry)
+	if err != nil || len(again) != 2 {
+		t.Fatalf("limit 2: got %d results, err %v", len(again), err)
+	}
+	if provider.calls != 2 {
+		t.Errorf("smaller limit was not served from cache, provider searched %d times", provider.calls)
+	}
+}
+
+func TestCachedSearchResultsRequiresLimit(t *testing.T) {
+	ClearSearchCache()
+	t.Cleanup(func() { ClearSearchCache() })
+
+	provider := &countingSearchProvider{}
+	registry := NewProviderRegistry(TrackSourceYouTube)
+	registry.Register(provider)
+
+	query := strings.ToLower(t.Name())
+	if _, err := SearchTracks(context.Background(), query, TrackSourceUnknown, 2, registry); err != nil {
+		t.Fatalf("search: %v", err)
+	}
+
+	if cached, ok := CachedSearchResults(query, TrackSourceUnknown, 2); !ok || len(cached) != 2 {
+		t.Fatalf("limit 2: got %d cached results, ok %v", len(cached), ok)
+	}
+	if cached, ok := CachedSearchResults(query, TrackSourceUnknown, 1); !ok || len(cached) != 1 {
+		t.Fatalf("limit 1: got %d cached results, ok %v", len(cached), ok)
+	}
+	if _, ok := CachedSearchResults(query, TrackSourceUnknown, 3); ok {
+		t.Errorf("limit 3 was served from a cache entry holding 2 results")
+	}
+}
@@ -16,29 +16,26 @@ var (
 )
 
 type Service struct {
-	queue    *QueueStore
-	resolver *YTDLPResolver
-	spotify  *SpotifyClient
+	queue     *QueueStore
+	providers *ProviderRegistry
 }
 
-func NewService(queue *QueueStore, resolver *YTDLPResolver, spotify *SpotifyClient) *Service {
+func NewService(queue *QueueStore, providers *ProviderRegistry) *Service {
 	return &Service{
-		queue:    queue,
-		resolver: resolver,
-		spotify:  spotify,
+		queue:     queue,
+		providers: providers,
 	}
 }
 
 func NewDefaultService() *Service {
 	return &Service{
-		queue:    NewQueueStoreFromDefault(),
-		resolver: NewYTDLPResolver(),
+		queue:     NewQueueStoreFromDefault(),
+		providers: NewDefaultProviderRegistry(nil),
 	}
 }
 
-func (s *Service) WithSpotify(client *SpotifyClient) *Service {
-	s.spotify = client
-	return s
+func (s *Service) Providers() *ProviderRegistry {
+	return s.providers
 }
 
 func (s *Service) ResolveInput(ctx context.Context, input string, sourceHint TrackSource, requestedBy string) (Track, error) {
@@ -46,40 +43,57 @@ func (s *Service) ResolveInput(ctx context.Context, input string, sourceHint Tra
 	if input == "" {
 		return Track{}, ErrMissingInput
 	}
-	if s.resolver == nil {
+	if s.providers == nil {
 		return Track{}, ErrResolverNil
 	}
 
-	if isSpotifyInput(input, sourceHint) {
-		if s.spotify == nil {
-			return Track{}, ErrSpotifyClientNil
-		}
+	provider, err := s.providers.Lookup(input, sourceHint)
+	if err != nil {
+		return Track{}, err
+	}
 
-		spotifyTrack, err := s.spotify.ResolveTrack(ctx, input)
-		if err != nil {
+	track, err := provider.Resolve(ctx, input)
+	if err != nil {
+		if !looksLikeURL(input) || sourceHint != TrackSourceUnknown || s.providers.Detect(input) != TrackSourceUnknown {
 			return Track{}, err
 		}
-
-		playable, err := s.resolver.Resolve(ctx, spotifyTrack.Title, TrackSourceYouTube)
+		prober, ok := s.providers.Probe(ctx, input)
+		if !ok {
+			return Track{}, err
+		}
+		track, err = prober.Resolve(ctx, input)
 		if err != nil {
 			return Track{}, err
 		}
+	}
 
-		playable.Title = spotifyTrack.Title
-		if spotifyTrack.Thumbnail != "" {
-			playable.Thumbnail = spotifyTrack.Thumbnail
-		}
-		playable.RequestedBy = requestedBy
-		return playable, nil
+	track.RequestedBy = requestedBy
+	return track, nil
+}
+
+func (s *Service) ResolveCollection(ctx context.Context, input string, sourceHint TrackSource, requestedBy string) ([]Track, bool, error) {
+	input = strings.TrimSpace(input)
+	if input == "" || s.providers == nil {
+		return nil, false, nil
 	}
 
-	track, err := s.resolver.Resolve(ctx, input, sourceHint)
+	provider, err := s.providers.Lookup(input, sourceHint)
 	if err != nil {
-		return Track{}, err
+		return nil, false, nil
+	}
+	collector, ok := provider.(CollectionProvider)
+	if !ok {
+		return nil, false, nil
 	}
 
-	track.RequestedBy = requestedBy
-	return track, nil
+	tracks, ok, err := collector.ResolveCollection(ctx, input)
+	if !ok || err != nil {
+		return nil, ok, err
+	}
+	for idx := range tracks {
+		tracks[idx].RequestedBy = requestedBy
+	}
+	return tracks, true, nil
 }
 
 func (s *Service) ResolveAndEnqueue(ctx context.Context, guildID string, input string, sourceHint TrackSource, requestedBy string, priority int) (QueueItem, error) {
@@ -105,6 +119,28 @@ func (s *Service) ResolveAndEnqueue(ctx context.Context, guildID string, input s
 	return item, nil
 }
 
+func (s *Service) EnqueueTracks(ctx context.Context, guildID string, tracks []Track, requestedBy string) ([]QueueItem, error) {
+	if s.queue == nil {
+		return nil, ErrQueueStoreNil
+	}
+
+	base := time.Now().UTC()
+	items := make([]QueueItem, 0, len(tracks))
+	for idx, track := range tracks {
+		track.RequestedBy = requestedBy
+		item := QueueItem{
+			Track:      track,
+			EnqueuedAt: base.Add(time.Duration(idx) * time.Millisecond),
+		}
+		if err := s.queue.Enqueue(ctx, guildID, item); err != nil {
+			return items, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
 func (s *Service) Dequeue(ctx context.Context, guildID string) (*QueueItem, error) {
 	if s.queue == nil {
 		return nil, ErrQueueStoreNil
@@ -126,6 +162,13 @@ func (s *Service) List(ctx context.Context, guildID string, limit int64) ([]Queu
 	return s.queue.List(ctx, guildID, limit)
 }
 
+func (s *Service) QueueSize(ctx context.Context, guildID string) (int64, error) {
+	if s.queue == nil {
+		return 0, ErrQueueStoreNil
+	}
+	return s.queue.QueueSize(ctx, guildID)
+}
+
 func (s *Service) Clear(ctx context.Context, guildID string) error {
 	if s.queue == nil {
 		return ErrQueueStoreNil
@@ -133,6 +176,13 @@ func (s *Service) Clear(ctx context.Context, guildID string) error {
 	return s.queue.Clear(ctx, guildID)
 }
 
+func (s *Service) Move(ctx context.Context, guildID string, from int, to int) error {
+	if s.queue == nil {
+		return ErrQueueStoreNil
+	}
+	return s.queue.Move(ctx, guildID, from, to)
+}
+
 func (s *Service) GetSettings(ctx context.Context, guildID string) (QueueSettings, error) {
 	if s.queue == nil {
 		return QueueSettings{}, ErrQueueStoreNil
@@ -147,35 +197,21 @@ func (s *Service) SetSettings(ctx context.Context, guildID string, settings Queu
 	return s.queue.SetSettings(ctx, guildID, settings)
 }
 
-func isSpotifyInput(input string, hint TrackSource) bool {
-	if hint == TrackSourceSpotify {
-		return true
-	}
-	return strings.Contains(strings.ToLower(input), "spotify.com") || strings.HasPrefix(strings.ToLower(input), "spotify:track:")
-}
-
 func (s *Service) ResolveSpotifySearch(ctx context.Context, query string, requestedBy string) (Track, error) {
-	if s.spotify == nil {
-		return Track{}, ErrSpotifyClientNil
-	}
-	if s.resolver == nil {
+	if s.providers == nil {
 		return Track{}, ErrResolverNil
 	}
 
-	spotifyTrack, err := s.spotify.SearchTrack(ctx, query)
-	if err != nil {
-		return Track{}, err
+	provider, ok := s.providers.Get(TrackSourceSpotify)
+	if !ok {
+		return Track{}, ErrSpotifyClientNil
 	}
 
-	playable, err := s.resolver.Resolve(ctx, spotifyTrack.Title, TrackSourceYouTube)
+	playable, err := provider.Resolve(ctx, query)
 	if err != nil {
 		return Track{}, err
 	}
 
-	playable.Title = spotifyTrack.Title
-	if spotifyTrack.Thumbnail != "" {
-		playable.Thumbnail = spotifyTrack.Thumbnail
-	}
 	playable.RequestedBy = requestedBy
 	return playable, nil
 }
@@ -184,7 +220,7 @@ func (s *Service) Validate() error {
 	if s.queue == nil {
 		return ErrQueueStoreNil
 	}
-	if s.resolver == nil {
+	if s.providers == nil {
 		return ErrResolverNil
 	}
 	return nil
@@ -192,8 +228,12 @@ func (s *Service) Validate() error {
 
 func (s *Service) DebugString() string {
 	queueOK := s.queue != nil
-	resolverOK := s.resolver != nil
-	spotifyOK := s.spotify != nil
+	providers := []string{}
+	if s.providers != nil {
+		for _, p := range s.providers.Providers() {
+			providers = append(providers, string(p.Source()))
+		}
+	}
 
-	return fmt.Sprintf("queue=%t resolver=%t spotify=%t", queueOK, resolverOK, spotifyOK)
+	return fmt.Sprintf("queue=%t providers=%s", queueOK, strings.Join(providers, ","))
 }
@@ -0,0 +1,33 @@
+package music
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDefaultProviderRegistryBuildsSpotifyWithClient(t *testing.T) {
+	unconfigured := NewDefaultProviderRegistry(nil)
+	provider, ok := unconfigured.Get(TrackSourceSpotify)
+	if !ok {
+		t.Fatal("registry has no spotify provider")
+	}
+	if _, err := provider.(*SpotifyProvider).getClient(); !errors.Is(err, ErrSpotifyClientNil) {
+		t.Fatalf("unconfigured spotify provider: err = %v, want ErrSpotifyClientNil", err)
+	}
+
+	client := NewSpotifyClient("id", "secret")
+	configured := NewDefaultProviderRegistry(client)
+	provider, ok = configured.Get(TrackSourceSpotify)
+	if !ok {
+		t.Fatal("registry has no spotify provider")
+	}
+	spotify := provider.(*SpotifyProvider)
+	if got, err := spotify.getClient(); err != nil || got != client {
+		t.Fatalf("configured spotify provider: client = %p, err = %v", got, err)
+	}
+
+	youtube, _ := configured.Get(TrackSourceYouTube)
+	if spotify.playback != youtube {
+		t.Error("spotify provider does not play back through the registry's youtube provider")
+	}
+}
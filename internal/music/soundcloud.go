@@ -0,0 +1,10 @@
+package music
+
+func NewSoundCloudProvider(resolver *YTDLPResolver) Provider {
+	return &ytdlpProvider{
+		source:       TrackSourceSoundCloud,
+		hosts:        []string{"soundcloud.com", "snd.sc"},
+		searchPrefix: "scsearch",
+		resolver:     resolver,
+	}
+}
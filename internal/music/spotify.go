@@ -11,6 +11,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/hxnx/tunebot/internal/metrics"
 )
 
 var ErrSpotifyResolveFailed = errors.New("failed to resolve spotify track")
@@ -83,6 +85,7 @@ func (c *SpotifyClient) ResolveTrack(ctx context.Context, input string) (Track,
 		Source:    TrackSourceSpotify,
 		Duration:  duration,
 		Thumbnail: thumb,
+		ISRC:      payload.ExternalIDs.ISRC,
 	}, nil
 }
 
@@ -155,6 +158,7 @@ func (c *SpotifyClient) SearchTracks(ctx context.Context, query string, limit in
 			Source:    TrackSourceSpotify,
 			Duration:  duration,
 			Thumbnail: thumb,
+			ISRC:      item.ExternalIDs.ISRC,
 		})
 	}
 
@@ -184,39 +188,136 @@ func (c *SpotifyClient) getAccessToken(ctx context.Context) (string, error) {
 		return "", fmt.Errorf("%w: missing spotify client credentials", ErrSpotifyResolveFailed)
 	}
 
+	token, expiresIn, err := c.requestAccessToken(ctx)
+	if err != nil {
+		metrics.SpotifyTokenRefreshes.WithLabelValues("failure").Inc()
+		return "", err
+	}
+	metrics.SpotifyTokenRefreshes.WithLabelValues("success").Inc()
+
+	c.accessToken = token
+	c.expiresAt = time.Now().Add(time.Duration(expiresIn-30) * time.Second)
+
+	return c.accessToken, nil
+}
+
+func (c *SpotifyClient) requestAccessToken(ctx context.Context) (string, int, error) {
+
 	form := url.Values{}
 	form.Set("grant_type", "client_credentials")
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://accounts.spotify.com/api/token", strings.NewReader(form.Encode()))
 	if err != nil {
-		return "", err
+		return "", 0, err
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Set("Authorization", "Basic "+basicAuth(c.ClientID, c.ClientSecret))
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return "", err
+		return "", 0, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return "", fmt.Errorf("%w: token status %d", ErrSpotifyResolveFailed, resp.StatusCode)
+		return "", 0, fmt.Errorf("%w: token status %d", ErrSpotifyResolveFailed, resp.StatusCode)
 	}
 
 	var payload spotifyTokenResponse
 	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-		return "", err
+		return "", 0, err
 	}
 
 	if payload.AccessToken == "" {
-		return "", fmt.Errorf("%w: empty access token", ErrSpotifyResolveFailed)
+		return "", 0, fmt.Errorf("%w: empty access token", ErrSpotifyResolveFailed)
 	}
 
-	c.accessToken = payload.AccessToken
-	c.expiresAt = time.Now().Add(time.Duration(payload.ExpiresIn-30) * time.Second)
+	return payload.AccessToken, payload.ExpiresIn, nil
+}
 
-	return c.accessToken, nil
+type SpotifyProvider struct {
+	client   *SpotifyClient
+	playback Provider
+}
+
+func NewSpotifyProvider(client *SpotifyClient, playback Provider) *SpotifyProvider {
+	return &SpotifyProvider{
+		client:   client,
+		playback: playback,
+	}
+}
+
+func (p *SpotifyProvider) getClient() (*SpotifyClient, error) {
+	if p.client == nil {
+		return nil, ErrSpotifyClientNil
+	}
+	return p.client, nil
+}
+
+func (p *SpotifyProvider) Source() TrackSource {
+	return TrackSourceSpotify
+}
+
+func (p *SpotifyProvider) Match(input string) bool {
+	if strings.HasPrefix(strings.ToLower(strings.TrimSpace(input)), "spotify:track:") {
+		return true
+	}
+	return matchURLHost(input, "spotify.com")
+}
+
+func (p *SpotifyProvider) Search(ctx context.Context, query string, limit int) ([]Track, error) {
+	client, err := p.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Match(query) {
+		track, err := client.ResolveTrack(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		return []Track{track}, nil
+	}
+
+	return client.SearchTracks(ctx, query, limit)
+}
+
+func (p *SpotifyProvider) Resolve(ctx context.Context, input string) (Track, error) {
+	client, err := p.getClient()
+	if err != nil {
+		return Track{}, err
+	}
+	if p.playback == nil {
+		return Track{}, ErrResolverNil
+	}
+
+	var spotifyTrack Track
+	if p.Match(input) {
+		spotifyTrack, err = client.ResolveTrack(ctx, input)
+	} else {
+		spotifyTrack, err = client.SearchTrack(ctx, input)
+	}
+	if err != nil {
+		return Track{}, err
+	}
+
+	return resolvePlayable(ctx, p.playback, spotifyTrack)
+}
+
+func (p *SpotifyProvider) StreamURL(ctx context.Context, track Track) (string, error) {
+	if p.playback == nil {
+		return "", ErrResolverNil
+	}
+
+	if p.Match(track.URL) {
+		playable, err := p.Resolve(ctx, track.URL)
+		if err != nil {
+			return "", err
+		}
+		track = playable
+	}
+
+	return p.playback.StreamURL(ctx, track)
 }
 
 func extractSpotifyTrackID(input string) string {
@@ -265,10 +366,13 @@ type spotifySearchResponse struct {
 }
 
 type spotifyTrackResponse struct {
-	ID         string `json:"id"`
-	Name       string `json:"name"`
-	DurationMS int64  `json:"duration_ms"`
-	Artists    []struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DurationMS  int64  `json:"duration_ms"`
+	ExternalIDs struct {
+		ISRC string `json:"isrc"`
+	} `json:"external_ids"`
+	Artists []struct {
 		Name string `json:"name"`
 	} `json:"artists"`
 	Album struct {
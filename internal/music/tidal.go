@@ -0,0 +1,127 @@
+package music
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const tidalBrowseURL = "https://tidal.com/browse"
+
+func NewTidalProvider(playback Provider) Provider {
+	return &linkProvider{
+		source:     TrackSourceTidal,
+		baseURL:    tidalBrowseURL,
+		hosts:      []string{"tidal.com"},
+		fetch:      fetchTidalTracks,
+		album:      isTidalAlbum,
+		playback:   playback,
+		HTTPClient: &http.Client{Timeout: linkMetadataTimeout},
+	}
+}
+
+func isTidalAlbum(input string) bool {
+	kind, _ := parseTidalURL(input)
+	return kind == "album"
+}
+
+func fetchTidalTracks(ctx context.Context, client *http.Client, baseURL string, input string, limit int) ([]Track, error) {
+	kind, id := parseTidalURL(input)
+	switch kind {
+	case "track":
+		track, err := fetchTidalTrack(ctx, client, baseURL, id)
+		if err != nil {
+			return nil, err
+		}
+		return []Track{track}, nil
+	case "album":
+		page, _, err := fetchLinkPage(ctx, client, fmt.Sprintf("%s/album/%s", baseURL, id))
+		if err != nil {
+			return nil, err
+		}
+
+		tracks := make([]Track, 0, limit)
+		for _, song := range parseHTMLMeta(page)["music:song"] {
+			songKind, songID := parseTidalURL(song)
+			if songKind != "track" {
+				continue
+			}
+			track, err := fetchTidalTrack(ctx, client, baseURL, songID)
+			if err != nil {
+				continue
+			}
+			tracks = append(tracks, track)
+			if len(tracks) >= limit {
+				break
+			}
+		}
+		return tracks, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported tidal url", ErrLinkMetadataFailed)
+	}
+}
+
+func fetchTidalTrack(ctx context.Context, client *http.Client, baseURL string, id string) (Track, error) {
+	page, _, err := fetchLinkPage(ctx, client, fmt.Sprintf("%s/track/%s", baseURL, id))
+	if err != nil {
+		return Track{}, err
+	}
+
+	meta := parseHTMLMeta(page)
+	title, artist := splitTidalTitle(firstMeta(meta, "og:title", "twitter:title"))
+	if artist == "" {
+		artist = firstMeta(meta, "music:musician_description", "music:musician")
+	}
+	if title == "" {
+		return Track{}, fmt.Errorf("%w: tidal page has no title", ErrLinkMetadataFailed)
+	}
+
+	var duration time.Duration
+	if seconds, err := strconv.Atoi(firstMeta(meta, "music:duration")); err == nil {
+		duration = time.Duration(seconds) * time.Second
+	}
+
+	return Track{
+		ID:        id,
+		Title:     linkTrackTitle(title, artist),
+		ISRC:      findISRC(page),
+		URL:       fmt.Sprintf("%s/track/%s", tidalBrowseURL, id),
+		Source:    TrackSourceTidal,
+		Duration:  duration,
+		Thumbnail: firstMeta(meta, "og:image", "twitter:image"),
+	}, nil
+}
+
+func splitTidalTitle(value string) (string, string) {
+	value = strings.TrimSpace(value)
+	value = strings.TrimSuffix(value, " on TIDAL")
+	if idx := strings.LastIndex(value, " by "); idx > 0 {
+		return strings.TrimSpace(value[:idx]), strings.TrimSpace(value[idx+len(" by "):])
+	}
+	if title, artist, ok := strings.Cut(value, " - "); ok {
+		return strings.TrimSpace(title), strings.TrimSpace(artist)
+	}
+	return value, ""
+}
+
+func parseTidalURL(input string) (string, string) {
+	u, err := url.Parse(strings.TrimSpace(input))
+	if err != nil || !strings.HasSuffix(strings.ToLower(u.Hostname()), "tidal.com") {
+		return "", ""
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 0; i+1 < len(parts); i++ {
+		if parts[i] != "track" && parts[i] != "album" {
+			continue
+		}
+		if _, err := strconv.ParseInt(parts[i+1], 10, 64); err == nil {
+			return parts[i], parts[i+1]
+		}
+	}
+	return "", ""
+}
@@ -0,0 +1,340 @@
+package music
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	QueueExportSchema        = "tunebot.queue"
+	QueueExportSchemaVersion = 1
+	MaxQueueImportEntries    = 100
+
+	queueImportWorkers        = 4
+	queueImportResolveTimeout = 60 * time.Second
+	m3uSchemaPrefix           = "#TUNEBOT-SCHEMA:"
+	xspfSchemaRel             = "https://github.com/hxnx/tunebot/schema/queue"
+)
+
+var (
+	ErrInvalidQueueImport       = errors.New("invalid queue import")
+	ErrUnsupportedSchemaVersion = errors.New("unsupported queue schema version")
+)
+
+type ExportFormat string
+
+const (
+	ExportFormatJSON ExportFormat = "json"
+	ExportFormatM3U8 ExportFormat = "m3u8"
+	ExportFormatXSPF ExportFormat = "xspf"
+)
+
+type QueueExport struct {
+	Schema     string         `json:"schema"`
+	Version    int            `json:"version"`
+	ExportedAt time.Time      `json:"exported_at"`
+	Settings   *QueueSettings `json:"settings,omitempty"`
+	Tracks     []ExportTrack  `json:"tracks"`
+}
+
+type ExportTrack struct {
+	Title      string      `json:"title"`
+	URL        string      `json:"url"`
+	Source     TrackSource `json:"source,omitempty"`
+	DurationMS int64       `json:"duration_ms,omitempty"`
+	Thumbnail  string      `json:"thumbnail,omitempty"`
+	IsLive     bool        `json:"is_live,omitempty"`
+	ISRC       string      `json:"isrc,omitempty"`
+}
+
+type QueueImportEntry struct {
+	Title  string
+	Input  string
+	Source TrackSource
+}
+
+type QueueImport struct {
+	Format   ExportFormat
+	Version  int
+	Settings *QueueSettings
+	Entries  []QueueImportEntry
+	Skipped  int
+}
+
+func NewQueueExport(items []QueueItem, settings QueueSettings) QueueExport {
+	tracks := make([]ExportTrack, 0, len(items))
+	for _, item := range items {
+		tracks = append(tracks, ExportTrack{
+			Title:      item.Track.Title,
+			URL:        item.Track.URL,
+			Source:     item.Track.Source,
+			DurationMS: item.Track.Duration.Milliseconds(),
+			Thumbnail:  item.Track.Thumbnail,
+			IsLive:     item.Track.IsLive,
+			ISRC:       item.Track.ISRC,
+		})
+	}
+
+	return QueueExport{
+		Schema:     QueueExportSchema,
+		Version:    QueueExportSchemaVersion,
+		ExportedAt: time.Now().UTC(),
+		Settings:   &settings,
+		Tracks:     tracks,
+	}
+}
+
+func (e QueueExport) Encode(format ExportFormat) ([]byte, error) {
+	switch format {
+	case ExportFormatJSON:
+		return json.MarshalIndent(e, "", "  ")
+	case ExportFormatM3U8:
+		return e.encodeM3U8(), nil
+	case ExportFormatXSPF:
+		return e.encodeXSPF()
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+func (e QueueExport) encodeM3U8() []byte {
+	var b strings.Builder
+	b.WriteString("#EXTM3U\n")
+	fmt.Fprintf(&b, "%s%d\n", m3uSchemaPrefix, e.Version)
+	for _, t := range e.Tracks {
+		seconds := -1
+		if !t.IsLive && t.DurationMS > 0 {
+			seconds = int(t.DurationMS / 1000)
+		}
+		fmt.Fprintf(&b, "#EXTINF:%d,%s\n", seconds, strings.ReplaceAll(t.Title, "\n", " "))
+		b.WriteString(t.URL)
+		b.WriteString("\n")
+	}
+	return []byte(b.String())
+}
+
+type xspfPlaylist struct {
+	XMLName xml.Name    `xml:"playlist"`
+	Version string      `xml:"version,attr"`
+	XMLNS   string      `xml:"xmlns,attr,omitempty"`
+	Title   string      `xml:"title,omitempty"`
+	Meta    []xspfMeta  `xml:"meta"`
+	Tracks  []xspfTrack `xml:"trackList>track"`
+}
+
+type xspfTrack struct {
+	Location   string     `xml:"location"`
+	Title      string     `xml:"title,omitempty"`
+	Duration   int64      `xml:"duration,omitempty"`
+	Image      string     `xml:"image,omitempty"`
+	Identifier string     `xml:"identifier,omitempty"`
+	Meta       []xspfMeta `xml:"meta"`
+}
+
+type xspfMeta struct {
+	Rel   string `xml:"rel,attr"`
+	Value string `xml:",chardata"`
+}
+
+func (e QueueExport) encodeXSPF() ([]byte, error) {
+	doc := xspfPlaylist{
+		Version: "1",
+		XMLNS:   "http://xspf.org/ns/0/",
+		Title:   "TuneBot Queue",
+		Meta:    []xspfMeta{{Rel: xspfSchemaRel, Value: strconv.Itoa(e.Version)}},
+	}
+	doc.Tracks = make([]xspfTrack, len(e.Tracks))
+	for idx, t := range e.Tracks {
+		track := &doc.Tracks[idx]
+		track.Location = t.URL
+		track.Title = t.Title
+		track.Duration = t.DurationMS
+		track.Image = t.Thumbnail
+		if t.ISRC != "" {
+			track.Identifier = "isrc:" + t.ISRC
+		}
+		if t.Source != "" {
+			track.Meta = []xspfMeta{{Rel: xspfSchemaRel + "#source", Value: string(t.Source)}}
+		}
+	}
+
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+func ParseQueueImport(data []byte) (QueueImport, error) {
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	if len(trimmed) == 0 {
+		return QueueImport{}, fmt.Errorf("%w: empty input", ErrInvalidQueueImport)
+	}
+
+	var (
+		result QueueImport
+		err    error
+	)
+	switch {
+	case trimmed[0] == '{':
+		result, err = parseQueueJSON(trimmed)
+	case bytes.HasPrefix(trimmed, []byte("<?xml")) || bytes.HasPrefix(trimmed, []byte("<playlist")):
+		result, err = parseQueueXSPF(trimmed)
+	default:
+		result, err = parseQueueM3U(string(trimmed))
+	}
+	if err != nil {
+		return QueueImport{}, err
+	}
+
+	if result.Version > QueueExportSchemaVersion {
+		return QueueImport{}, fmt.Errorf("%w: %d", ErrUnsupportedSchemaVersion, result.Version)
+	}
+
+	entries := make([]QueueImportEntry, 0, len(result.Entries))
+	for _, entry := range result.Entries {
+		entry.Input = strings.TrimSpace(entry.Input)
+		if !validImportInput(entry.Input) || len(entries) >= MaxQueueImportEntries {
+			result.Skipped++
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	result.Entries = entries
+
+	if len(result.Entries) == 0 {
+		return QueueImport{}, fmt.Errorf("%w: no valid entries", ErrInvalidQueueImport)
+	}
+	return result, nil
+}
+
+func parseQueueJSON(data []byte) (QueueImport, error) {
+	var doc QueueExport
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return QueueImport{}, fmt.Errorf("%w: %v", ErrInvalidQueueImport, err)
+	}
+	if doc.Schema != QueueExportSchema {
+		return QueueImport{}, fmt.Errorf("%w: unknown schema %q", ErrInvalidQueueImport, doc.Schema)
+	}
+
+	result := QueueImport{
+		Format:   ExportFormatJSON,
+		Version:  doc.Version,
+		Settings: doc.Settings,
+	}
+	for _, t := range doc.Tracks {
+		result.Entries = append(result.Entries, QueueImportEntry{Title: t.Title, Input: t.URL, Source: t.Source})
+	}
+	return result, nil
+}
+
+func parseQueueXSPF(data []byte) (QueueImport, error) {
+	var doc xspfPlaylist
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return QueueImport{}, fmt.Errorf("%w: %v", ErrInvalidQueueImport, err)
+	}
+
+	result := QueueImport{Format: ExportFormatXSPF}
+	for _, meta := range doc.Meta {
+		if meta.Rel == xspfSchemaRel {
+			result.Version, _ = strconv.Atoi(strings.TrimSpace(meta.Value))
+		}
+	}
+	for _, t := range doc.Tracks {
+		entry := QueueImportEntry{Title: strings.TrimSpace(t.Title), Input: t.Location}
+		for _, meta := range t.Meta {
+			if meta.Rel == xspfSchemaRel+"#source" {
+				entry.Source = TrackSource(strings.TrimSpace(meta.Value))
+			}
+		}
+		result.Entries = append(result.Entries, entry)
+	}
+	return result, nil
+}
+
+func parseQueueM3U(body string) (QueueImport, error) {
+	result := QueueImport{Format: ExportFormatM3U8}
+	title := ""
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, m3uSchemaPrefix):
+			result.Version, _ = strconv.Atoi(strings.TrimPrefix(line, m3uSchemaPrefix))
+		case strings.HasPrefix(line, "#EXTINF:"):
+			if _, name, ok := strings.Cut(line, ","); ok {
+				title = strings.TrimSpace(name)
+			}
+		case strings.HasPrefix(line, "#"):
+			continue
+		default:
+			result.Entries = append(result.Entries, QueueImportEntry{Title: title, Input: line})
+			title = ""
+		}
+	}
+	return result, nil
+}
+
+func validImportInput(input string) bool {
+	if input == "" || len(input) > 2048 {
+		return false
+	}
+	if strings.HasPrefix(input, localURLPrefix) {
+		return true
+	}
+	_, ok := parseHTTPURL(input)
+	return ok
+}
+
+func (s *Service) ResolveImport(ctx context.Context, entries []QueueImportEntry, requestedBy string) ([]Track, int) {
+	resolved := make([]*Track, len(entries))
+
+	var wg sync.WaitGroup
+	jobs := make(chan int)
+	for range queueImportWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				entryCtx, cancel := context.WithTimeout(ctx, queueImportResolveTimeout)
+				track, err := s.resolveImportEntry(entryCtx, entries[idx], requestedBy)
+				cancel()
+				if err == nil {
+					resolved[idx] = &track
+				}
+			}
+		}()
+	}
+	for idx := range entries {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+
+	tracks := make([]Track, 0, len(entries))
+	for _, track := range resolved {
+		if track != nil {
+			tracks = append(tracks, *track)
+		}
+	}
+	return tracks, len(entries) - len(tracks)
+}
+
+func (s *Service) resolveImportEntry(ctx context.Context, entry QueueImportEntry, requestedBy string) (Track, error) {
+	hint := TrackSourceUnknown
+	if entry.Source != "" && s.providers != nil {
+		if _, ok := s.providers.Get(entry.Source); ok && s.providers.Detect(entry.Input) == entry.Source {
+			hint = entry.Source
+		}
+	}
+	return s.ResolveInput(ctx, entry.Input, hint, requestedBy)
+}
@@ -0,0 +1,9 @@
+package music
+
+func NewTwitchProvider(resolver *YTDLPResolver) Provider {
+	return &ytdlpProvider{
+		source:   TrackSourceTwitch,
+		hosts:    []string{"twitch.tv"},
+		resolver: resolver,
+	}
+}
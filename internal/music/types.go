@@ -1,6 +1,9 @@
 package music
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 type TrackSource string
 
@@ -8,6 +11,15 @@ const (
 	TrackSourceYouTube    TrackSource = "youtube"
 	TrackSourceSpotify    TrackSource = "spotify"
 	TrackSourceSoundCloud TrackSource = "soundcloud"
+	TrackSourceBandcamp   TrackSource = "bandcamp"
+	TrackSourceVimeo      TrackSource = "vimeo"
+	TrackSourceTwitch     TrackSource = "twitch"
+	TrackSourceMixcloud   TrackSource = "mixcloud"
+	TrackSourceAppleMusic TrackSource = "applemusic"
+	TrackSourceDeezer     TrackSource = "deezer"
+	TrackSourceTidal      TrackSource = "tidal"
+	TrackSourceHTTP       TrackSource = "http"
+	TrackSourceLocal      TrackSource = "local"
 	TrackSourceUnknown    TrackSource = "unknown"
 )
 
@@ -27,6 +39,16 @@ type Track struct {
 	Duration    time.Duration `json:"duration"`
 	Thumbnail   string        `json:"thumbnail"`
 	RequestedBy string        `json:"requested_by"`
+	IsLive      bool          `json:"is_live,omitempty"`
+	ISRC        string        `json:"isrc,omitempty"`
+}
+
+func (t Track) LinkURL() string {
+	lower := strings.ToLower(t.URL)
+	if strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") {
+		return t.URL
+	}
+	return ""
 }
 
 type QueueItem struct {
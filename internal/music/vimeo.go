@@ -0,0 +1,9 @@
+package music
+
+func NewVimeoProvider(resolver *YTDLPResolver) Provider {
+	return &ytdlpProvider{
+		source:   TrackSourceVimeo,
+		hosts:    []string{"vimeo.com"},
+		resolver: resolver,
+	}
+}
@@ -0,0 +1,10 @@
+package music
+
+func NewYouTubeProvider(resolver *YTDLPResolver) Provider {
+	return &ytdlpProvider{
+		source:       TrackSourceYouTube,
+		hosts:        []string{"youtube.com", "youtu.be", "youtube-nocookie.com"},
+		searchPrefix: "ytsearch",
+		resolver:     resolver,
+	}
+}
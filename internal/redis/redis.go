@@ -2,6 +2,7 @@ package redis
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -14,6 +15,8 @@ var (
 	once   sync.Once
 )
 
+var ErrNotInitialized = errors.New("redis client not initialized")
+
 type Config struct {
 	Host     string
 	Port     int
@@ -57,7 +60,7 @@ func Init(cfg Config) (*redislib.Client, error) {
 	})
 
 	if client == nil && initErr == nil {
-		return nil, fmt.Errorf("redis client not initialized")
+		return nil, ErrNotInitialized
 	}
 
 	return client, initErr
@@ -67,6 +70,13 @@ func Client() *redislib.Client {
 	return client
 }
 
+func Ping(ctx context.Context) error {
+	if client == nil {
+		return ErrNotInitialized
+	}
+	return client.Ping(ctx).Err()
+}
+
 func Close() error {
 	if client == nil {
 		return nil
@@ -0,0 +1,173 @@
+package scrobble
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	lastFMBaseURL = "https://ws.audioscrobbler.com/2.0/"
+	lastFMAuthURL = "https://www.last.fm/api/auth/"
+)
+
+var ErrLastFMTokenPending = errors.New("last.fm token has not been authorized yet")
+
+type LastFM struct {
+	BaseURL   string
+	APIKey    string
+	APISecret string
+	Client    *http.Client
+}
+
+func NewLastFM(apiKey string, apiSecret string) *LastFM {
+	return &LastFM{
+		BaseURL:   lastFMBaseURL,
+		APIKey:    apiKey,
+		APISecret: apiSecret,
+		Client:    &http.Client{Timeout: requestTimeout},
+	}
+}
+
+func (l *LastFM) Configured() bool {
+	return l != nil && l.APIKey != "" && l.APISecret != ""
+}
+
+func (l *LastFM) AuthURL(token string) string {
+	return fmt.Sprintf("%s?api_key=%s&token=%s", lastFMAuthURL, url.QueryEscape(l.APIKey), url.QueryEscape(token))
+}
+
+func (l *LastFM) RequestToken(ctx context.Context) (string, error) {
+	var result struct {
+		Token string `json:"token"`
+	}
+	if err := l.call(ctx, "auth.getToken", url.Values{}, &result); err != nil {
+		return "", err
+	}
+	return result.Token, nil
+}
+
+func (l *LastFM) Session(ctx context.Context, token string) (string, string, error) {
+	var result struct {
+		Session struct {
+			Name string `json:"name"`
+			Key  string `json:"key"`
+		} `json:"session"`
+	}
+	if err := l.call(ctx, "auth.getSession", url.Values{"token": {token}}, &result); err != nil {
+		return "", "", err
+	}
+	return result.Session.Name, result.Session.Key, nil
+}
+
+func (l *LastFM) NowPlaying(ctx context.Context, sessionKey string, listen Listen) error {
+	params := listenParams(listen)
+	params.Set("sk", sessionKey)
+	return l.call(ctx, "track.updateNowPlaying", params, nil)
+}
+
+func (l *LastFM) Scrobble(ctx context.Context, sessionKey string, listen Listen) error {
+	params := listenParams(listen)
+	params.Set("sk", sessionKey)
+	params.Set("timestamp", strconv.FormatInt(listen.ListenedAt.Unix(), 10))
+	params.Set("chosenByUser", "0")
+	return l.call(ctx, "track.scrobble", params, nil)
+}
+
+func listenParams(listen Listen) url.Values {
+	params := url.Values{
+		"artist": {listen.Artist},
+		"track":  {listen.Track},
+	}
+	if listen.Duration > 0 {
+		params.Set("duration", strconv.FormatInt(int64(listen.Duration.Seconds()), 10))
+	}
+	return params
+}
+
+func (l *LastFM) call(ctx context.Context, method string, params url.Values, dst any) error {
+	if !l.Configured() {
+		return &PermanentError{Err: ErrServiceNotConfigured}
+	}
+
+	params.Set("method", method)
+	params.Set("api_key", l.APIKey)
+	params.Set("api_sig", l.sign(params))
+	params.Set("format", "json")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.BaseURL, strings.NewReader(params.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", submissionClient)
+
+	resp, err := l.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 256<<10))
+	if err != nil {
+		return err
+	}
+
+	var apiErr struct {
+		Error   int    `json:"error"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &apiErr) == nil && apiErr.Error != 0 {
+		return lastFMError(apiErr.Error, apiErr.Message)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return statusError(ServiceLastFM, resp, string(body))
+	}
+	if dst == nil {
+		return nil
+	}
+	return json.Unmarshal(body, dst)
+}
+
+func (l *LastFM) sign(params url.Values) string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		if key != "format" && key != "callback" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		b.WriteString(key)
+		b.WriteString(params.Get(key))
+	}
+	b.WriteString(l.APISecret)
+
+	sum := md5.Sum([]byte(b.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+func lastFMError(code int, message string) error {
+	err := fmt.Errorf("last.fm error %d: %s", code, message)
+	switch code {
+	case 8, 11, 16, 29:
+		return err
+	case 14:
+		return &PermanentError{Err: fmt.Errorf("%w: %w", ErrLastFMTokenPending, err)}
+	case 4, 9, 10, 15, 26:
+		return &PermanentError{Err: fmt.Errorf("%w: %w", ErrInvalidCredentials, err)}
+	default:
+		return &PermanentError{Err: err}
+	}
+}
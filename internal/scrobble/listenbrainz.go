@@ -0,0 +1,126 @@
+package scrobble
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const listenBrainzBaseURL = "https://api.listenbrainz.org"
+
+type ListenBrainz struct {
+	BaseURL string
+	Client  *http.Client
+}
+
+func NewListenBrainz() *ListenBrainz {
+	return &ListenBrainz{
+		BaseURL: listenBrainzBaseURL,
+		Client:  &http.Client{Timeout: requestTimeout},
+	}
+}
+
+type listenBrainzSubmission struct {
+	ListenType string               `json:"listen_type"`
+	Payload    []listenBrainzListen `json:"payload"`
+}
+
+type listenBrainzListen struct {
+	ListenedAt    int64                     `json:"listened_at,omitempty"`
+	TrackMetadata listenBrainzTrackMetadata `json:"track_metadata"`
+}
+
+type listenBrainzTrackMetadata struct {
+	ArtistName     string                     `json:"artist_name"`
+	TrackName      string                     `json:"track_name"`
+	AdditionalInfo listenBrainzAdditionalInfo `json:"additional_info"`
+}
+
+type listenBrainzAdditionalInfo struct {
+	DurationMS       int64  `json:"duration_ms,omitempty"`
+	OriginURL        string `json:"origin_url,omitempty"`
+	SubmissionClient string `json:"submission_client"`
+}
+
+func (l *ListenBrainz) ValidateToken(ctx context.Context, token string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, l.BaseURL+"/1/validate-token", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Token "+token)
+
+	resp, err := l.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Valid    bool   `json:"valid"`
+		UserName string `json:"user_name"`
+		Message  string `json:"message"`
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode != http.StatusOK {
+		return "", statusError(ServiceListenBrainz, resp, string(body))
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", err
+	}
+	if !result.Valid {
+		return "", &PermanentError{Err: ErrInvalidCredentials}
+	}
+	return result.UserName, nil
+}
+
+func (l *ListenBrainz) NowPlaying(ctx context.Context, token string, listen Listen) error {
+	return l.submit(ctx, token, "playing_now", listen)
+}
+
+func (l *ListenBrainz) Scrobble(ctx context.Context, token string, listen Listen) error {
+	return l.submit(ctx, token, "single", listen)
+}
+
+func (l *ListenBrainz) submit(ctx context.Context, token string, listenType string, listen Listen) error {
+	entry := listenBrainzListen{
+		TrackMetadata: listenBrainzTrackMetadata{
+			ArtistName: listen.Artist,
+			TrackName:  listen.Track,
+			AdditionalInfo: listenBrainzAdditionalInfo{
+				DurationMS:       listen.Duration.Milliseconds(),
+				OriginURL:        listen.OriginURL,
+				SubmissionClient: submissionClient,
+			},
+		},
+	}
+	if listenType == "single" {
+		entry.ListenedAt = listen.ListenedAt.Unix()
+	}
+
+	payload, err := json.Marshal(listenBrainzSubmission{ListenType: listenType, Payload: []listenBrainzListen{entry}})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.BaseURL+"/1/submit-listens", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Token "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := l.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode != http.StatusOK {
+		return statusError(ServiceListenBrainz, resp, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
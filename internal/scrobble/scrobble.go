@@ -0,0 +1,120 @@
+package scrobble
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hxnx/tunebot/internal/music"
+)
+
+const (
+	ServiceListenBrainz = "listenbrainz"
+	ServiceLastFM       = "lastfm"
+
+	submissionClient = "TuneBot"
+	requestTimeout   = 10 * time.Second
+	minTrackLength   = 30 * time.Second
+	maxListenTime    = 4 * time.Minute
+)
+
+var (
+	ErrUnknownService       = errors.New("unknown scrobbling service")
+	ErrServiceNotConfigured = errors.New("scrobbling service is not configured")
+	ErrInvalidCredentials   = errors.New("scrobbler credentials were rejected")
+)
+
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+func IsPermanent(err error) bool {
+	var permanent *PermanentError
+	return errors.As(err, &permanent)
+}
+
+type Listen struct {
+	Artist     string
+	Track      string
+	Duration   time.Duration
+	ListenedAt time.Time
+	OriginURL  string
+}
+
+type Scrobbler interface {
+	NowPlaying(ctx context.Context, token string, listen Listen) error
+	Scrobble(ctx context.Context, token string, listen Listen) error
+}
+
+func Threshold(duration time.Duration) time.Duration {
+	if duration <= 0 {
+		return maxListenTime
+	}
+	return min(duration/2, maxListenTime)
+}
+
+func Eligible(track music.Track) bool {
+	if track.IsLive {
+		return false
+	}
+	return track.Duration <= 0 || track.Duration > minTrackLength
+}
+
+var titleNoise = regexp.MustCompile(`(?i)\s*[\(\[](official|lyrics?|audio|mv|m/v|hd|4k|visuali[sz]er|music video|video)[^\)\]]*[\)\]]`)
+
+func ParseTitle(title string) (string, string, bool) {
+	title = strings.TrimSpace(title)
+	if name, artist, ok := strings.Cut(title, " — "); ok {
+		name, artist = strings.TrimSpace(name), strings.TrimSpace(artist)
+		return artist, name, artist != "" && name != ""
+	}
+
+	cleaned := strings.TrimSpace(titleNoise.ReplaceAllString(title, ""))
+	for _, sep := range []string{" - ", " – ", " | "} {
+		if artist, name, ok := strings.Cut(cleaned, sep); ok {
+			artist, name = strings.TrimSpace(artist), strings.TrimSpace(name)
+			return artist, name, artist != "" && name != ""
+		}
+	}
+	return "", "", false
+}
+
+func NewListen(track music.Track, listenedAt time.Time) (Listen, bool) {
+	artist, name, ok := ParseTitle(track.Title)
+	if !ok || !Eligible(track) {
+		return Listen{}, false
+	}
+	return Listen{
+		Artist:     artist,
+		Track:      name,
+		Duration:   track.Duration,
+		ListenedAt: listenedAt.UTC(),
+		OriginURL:  track.LinkURL(),
+	}, true
+}
+
+func statusError(service string, resp *http.Response, message string) error {
+	err := fmt.Errorf("%s responded with status %d: %s", service, resp.StatusCode, strings.TrimSpace(message))
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized, resp.StatusCode == http.StatusForbidden:
+		return &PermanentError{Err: fmt.Errorf("%w: %w", ErrInvalidCredentials, err)}
+	case resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode >= 500:
+		return err
+	case resp.StatusCode >= 400:
+		return &PermanentError{Err: err}
+	default:
+		return err
+	}
+}
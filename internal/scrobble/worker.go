@@ -0,0 +1,160 @@
+package scrobble
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/hxnx/tunebot/internal/database"
+	"github.com/hxnx/tunebot/internal/retry"
+)
+
+const (
+	pollInterval   = 15 * time.Second
+	claimBatch     = 20
+	claimLease     = 2 * time.Minute
+	baseBackoff    = 30 * time.Second
+	maxBackoff     = 6 * time.Hour
+	maxListenAge   = 13 * 24 * time.Hour
+	maxErrorLength = 500
+	nowPlayingWait = 5 * time.Second
+)
+
+var clients = struct {
+	mu           sync.RWMutex
+	listenBrainz *ListenBrainz
+	lastFM       *LastFM
+}{
+	listenBrainz: NewListenBrainz(),
+	lastFM:       NewLastFM("", ""),
+}
+
+func ConfigureLastFM(apiKey string, apiSecret string) {
+	clients.mu.Lock()
+	clients.lastFM = NewLastFM(apiKey, apiSecret)
+	clients.mu.Unlock()
+}
+
+func LastFMClient() *LastFM {
+	clients.mu.RLock()
+	defer clients.mu.RUnlock()
+	return clients.lastFM
+}
+
+func ListenBrainzClient() *ListenBrainz {
+	clients.mu.RLock()
+	defer clients.mu.RUnlock()
+	return clients.listenBrainz
+}
+
+func For(service string) (Scrobbler, error) {
+	switch service {
+	case ServiceListenBrainz:
+		return ListenBrainzClient(), nil
+	case ServiceLastFM:
+		client := LastFMClient()
+		if !client.Configured() {
+			return nil, ErrServiceNotConfigured
+		}
+		return client, nil
+	default:
+		return nil, ErrUnknownService
+	}
+}
+
+func SendNowPlaying(link database.ScrobblerLink, listen Listen) {
+	scrobbler, err := For(link.Service)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), nowPlayingWait)
+	defer cancel()
+	if err := scrobbler.NowPlaying(ctx, link.Token, listen); err != nil {
+		slog.Debug("scrobble now playing failed", "user_id", link.UserID, "service", link.Service, "error", err)
+	}
+}
+
+type Worker struct {
+	*retry.Worker
+}
+
+var DefaultWorker = NewWorker()
+
+func NewWorker() *Worker {
+	w := &Worker{}
+	w.Worker = retry.NewWorker(pollInterval, w.drain)
+	return w
+}
+
+func Backoff(attempt int) time.Duration {
+	return retry.Backoff(attempt, baseBackoff, maxBackoff)
+}
+
+func (w *Worker) drain(ctx context.Context) {
+	repo := database.NewScrobbleRepository()
+	if !repo.Available() {
+		return
+	}
+
+	for ctx.Err() == nil {
+		scrobbles, err := repo.ClaimDue(claimBatch, claimLease)
+		if err != nil {
+			slog.Warn("scrobble claim failed", "error", err)
+			return
+		}
+		for _, s := range scrobbles {
+			w.submit(ctx, repo, s)
+		}
+		if len(scrobbles) < claimBatch {
+			return
+		}
+	}
+}
+
+func (w *Worker) submit(ctx context.Context, repo *database.ScrobbleRepository, s database.QueuedScrobble) {
+	logger := slog.Default().With("user_id", s.UserID, "service", s.Service, "scrobble_id", s.ID, "attempt", s.Attempts)
+
+	scrobbler, err := For(s.Service)
+	if err != nil {
+		err = &PermanentError{Err: err}
+	} else {
+		submitCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+		err = scrobbler.Scrobble(submitCtx, s.Token, Listen{
+			Artist:     s.Artist,
+			Track:      s.Track,
+			Duration:   time.Duration(s.DurationMS) * time.Millisecond,
+			ListenedAt: s.ListenedAt,
+			OriginURL:  s.OriginURL,
+		})
+		cancel()
+	}
+
+	if err == nil {
+		if err := repo.Delete(s.ID); err != nil {
+			logger.Error("scrobble bookkeeping failed", "error", err)
+		}
+		return
+	}
+
+	message := err.Error()
+	if len(message) > maxErrorLength {
+		message = message[:maxErrorLength]
+	}
+
+	switch {
+	case ctx.Err() != nil:
+		err = repo.Reschedule(s.ID, time.Now(), message)
+	case IsPermanent(err), time.Since(s.ListenedAt) > maxListenAge:
+		logger.Warn("scrobble dropped", "error", message)
+		err = repo.Delete(s.ID)
+	default:
+		next := time.Now().Add(Backoff(s.Attempts))
+		logger.Info("scrobble failed, retrying", "error", message, "next_attempt_at", next)
+		err = repo.Reschedule(s.ID, next, message)
+	}
+	if err != nil {
+		logger.Error("scrobble bookkeeping failed", "error", err)
+	}
+}
@@ -0,0 +1,323 @@
+package shard
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	identifyInterval = 5 * time.Second
+	initialBackoff   = 2 * time.Second
+	maxBackoff       = 2 * time.Minute
+)
+
+var ErrNoShardsOpened = errors.New("no shards could be opened")
+
+type State string
+
+const (
+	StateIdle         State = "idle"
+	StateConnecting   State = "connecting"
+	StateReady        State = "ready"
+	StateDisconnected State = "disconnected"
+	StateBackoff      State = "backoff"
+	StateClosed       State = "closed"
+)
+
+type Status struct {
+	ID            int           `json:"id"`
+	State         State         `json:"state"`
+	Latency       time.Duration `json:"-"`
+	LatencyMS     int64         `json:"latency_ms"`
+	Guilds        int           `json:"guilds"`
+	LastHeartbeat time.Time     `json:"last_heartbeat,omitempty"`
+	Reconnects    int           `json:"reconnects"`
+	LastError     string        `json:"last_error,omitempty"`
+	NextRetry     *time.Time    `json:"next_retry,omitempty"`
+}
+
+type Shard struct {
+	ID      int
+	Session *discordgo.Session
+
+	mu         sync.Mutex
+	state      State
+	reconnects int
+	lastError  string
+	nextRetry  time.Time
+	backoff    time.Duration
+}
+
+type Manager struct {
+	mu             sync.RWMutex
+	shardCount     int
+	maxConcurrency int
+	shards         []*Shard
+	stop           chan struct{}
+	closed         bool
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultManager *Manager
+)
+
+func SetDefault(m *Manager) {
+	defaultMu.Lock()
+	defaultManager = m
+	defaultMu.Unlock()
+}
+
+func Default() *Manager {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultManager
+}
+
+func NewManager(sessions []*discordgo.Session, shardCount int, maxConcurrency int) *Manager {
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+
+	m := &Manager{
+		shardCount:     shardCount,
+		maxConcurrency: maxConcurrency,
+		stop:           make(chan struct{}),
+	}
+
+	for _, s := range sessions {
+		sh := &Shard{
+			ID:      s.ShardID,
+			Session: s,
+			state:   StateIdle,
+		}
+		sh.attachHandlers()
+		m.shards = append(m.shards, sh)
+	}
+
+	sort.Slice(m.shards, func(i, j int) bool {
+		return m.shards[i].ID < m.shards[j].ID
+	})
+	return m
+}
+
+func (m *Manager) ShardCount() int {
+	return m.shardCount
+}
+
+func (m *Manager) MaxConcurrency() int {
+	return m.maxConcurrency
+}
+
+func (m *Manager) Sessions() []*discordgo.Session {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	sessions := make([]*discordgo.Session, 0, len(m.shards))
+	for _, sh := range m.shards {
+		sessions = append(sessions, sh.Session)
+	}
+	return sessions
+}
+
+func (m *Manager) Session(shardID int) *discordgo.Session {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, sh := range m.shards {
+		if sh.ID == shardID {
+			return sh.Session
+		}
+	}
+	return nil
+}
+
+func (m *Manager) Open() error {
+	if len(m.shards) == 0 {
+		return ErrNoShardsOpened
+	}
+
+	rounds := make(map[int][]*Shard)
+	order := []int{}
+	for _, sh := range m.shards {
+		round := sh.ID / m.maxConcurrency
+		if _, ok := rounds[round]; !ok {
+			order = append(order, round)
+		}
+		rounds[round] = append(rounds[round], sh)
+	}
+
+	opened := 0
+	var lastErr error
+	for idx, round := range order {
+		if idx > 0 {
+			select {
+			case <-m.stop:
+				return nil
+			case <-time.After(identifyInterval):
+			}
+		}
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		for _, sh := range rounds[round] {
+			wg.Add(1)
+			go func(sh *Shard) {
+				defer wg.Done()
+				if err := sh.open(); err != nil {
+					slog.Warn("failed to open shard", "shard", sh.ID, "error", err)
+					mu.Lock()
+					lastErr = err
+					mu.Unlock()
+					go m.retry(sh)
+					return
+				}
+				mu.Lock()
+				opened++
+				mu.Unlock()
+			}(sh)
+		}
+		wg.Wait()
+	}
+
+	if opened == 0 {
+		return fmt.Errorf("%w: %v", ErrNoShardsOpened, lastErr)
+	}
+	return nil
+}
+
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return nil
+	}
+	m.closed = true
+	close(m.stop)
+	m.mu.Unlock()
+
+	var firstErr error
+	for _, sh := range m.shards {
+		sh.setState(StateClosed)
+		if err := sh.Session.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (m *Manager) Statuses() []Status {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	statuses := make([]Status, 0, len(m.shards))
+	for _, sh := range m.shards {
+		statuses = append(statuses, sh.status())
+	}
+	return statuses
+}
+
+func (m *Manager) retry(sh *Shard) {
+	for {
+		sh.mu.Lock()
+		if sh.backoff <= 0 {
+			sh.backoff = initialBackoff
+		} else {
+			sh.backoff = min(sh.backoff*2, maxBackoff)
+		}
+		wait := sh.backoff
+		sh.state = StateBackoff
+		sh.nextRetry = time.Now().Add(wait)
+		sh.mu.Unlock()
+
+		select {
+		case <-m.stop:
+			return
+		case <-time.After(wait):
+		}
+
+		if err := sh.open(); err != nil {
+			slog.Warn("shard reconnect failed", "shard", sh.ID, "retry_in", min(wait*2, maxBackoff), "error", err)
+			continue
+		}
+		slog.Info("shard reconnected", "shard", sh.ID)
+		return
+	}
+}
+
+func (sh *Shard) open() error {
+	sh.setState(StateConnecting)
+	if err := sh.Session.Open(); err != nil {
+		sh.mu.Lock()
+		sh.state = StateDisconnected
+		sh.lastError = err.Error()
+		sh.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (sh *Shard) attachHandlers() {
+	sh.Session.AddHandler(func(s *discordgo.Session, r *discordgo.Ready) {
+		sh.mu.Lock()
+		sh.state = StateReady
+		sh.backoff = 0
+		sh.nextRetry = time.Time{}
+		sh.mu.Unlock()
+	})
+	sh.Session.AddHandler(func(s *discordgo.Session, r *discordgo.Resumed) {
+		sh.mu.Lock()
+		sh.state = StateReady
+		sh.backoff = 0
+		sh.nextRetry = time.Time{}
+		sh.mu.Unlock()
+	})
+	sh.Session.AddHandler(func(s *discordgo.Session, d *discordgo.Disconnect) {
+		sh.mu.Lock()
+		if sh.state != StateClosed {
+			sh.state = StateDisconnected
+			sh.reconnects++
+		}
+		sh.mu.Unlock()
+	})
+}
+
+func (sh *Shard) setState(state State) {
+	sh.mu.Lock()
+	sh.state = state
+	sh.mu.Unlock()
+}
+
+func (sh *Shard) status() Status {
+	sh.mu.Lock()
+	st := Status{
+		ID:         sh.ID,
+		State:      sh.state,
+		Reconnects: sh.reconnects,
+		LastError:  sh.lastError,
+	}
+	if !sh.nextRetry.IsZero() {
+		next := sh.nextRetry
+		st.NextRetry = &next
+	}
+	sh.mu.Unlock()
+
+	s := sh.Session
+	if st.State == StateReady {
+		st.Latency = s.HeartbeatLatency().Round(time.Millisecond)
+		st.LatencyMS = st.Latency.Milliseconds()
+	}
+	st.LastHeartbeat = s.LastHeartbeatAck
+	if s.State != nil {
+		s.State.RLock()
+		st.Guilds = len(s.State.Guilds)
+		s.State.RUnlock()
+	}
+	return st
+}
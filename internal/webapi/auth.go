@@ -0,0 +1,124 @@
+package webapi
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hxnx/tunebot/internal/database"
+)
+
+const (
+	tokenPrefix        = "tb_"
+	overlayTokenPrefix = "tbo_"
+)
+
+type tokenContextKey struct{}
+
+var publicURL = struct {
+	mu    sync.RWMutex
+	value string
+}{}
+
+func SetPublicURL(url string) {
+	publicURL.mu.Lock()
+	publicURL.value = strings.TrimRight(strings.TrimSpace(url), "/")
+	publicURL.mu.Unlock()
+}
+
+func PublicURL() string {
+	publicURL.mu.RLock()
+	defer publicURL.mu.RUnlock()
+	return publicURL.value
+}
+
+func GenerateToken() (string, string, error) {
+	return generateToken(tokenPrefix)
+}
+
+func GenerateOverlayToken() (string, string, error) {
+	return generateToken(overlayTokenPrefix)
+}
+
+func generateToken(prefix string) (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token := prefix + base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashToken(token), nil
+}
+
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func TokenFromContext(ctx context.Context) (database.APIToken, bool) {
+	token, ok := ctx.Value(tokenContextKey{}).(database.APIToken)
+	return token, ok
+}
+
+func bearerToken(r *http.Request) string {
+	header := r.Header.Get("Authorization")
+	if scheme, value, ok := strings.Cut(header, " "); ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(value)
+	}
+	return ""
+}
+
+func bearerOrQueryToken(r *http.Request) string {
+	if token := bearerToken(r); token != "" {
+		return token
+	}
+	return strings.TrimSpace(r.URL.Query().Get("token"))
+}
+
+func requireGuildToken(next http.HandlerFunc) http.HandlerFunc {
+	return guildTokenHandler(next, bearerToken, false)
+}
+
+func requireGuildStreamToken(next http.HandlerFunc) http.HandlerFunc {
+	return guildTokenHandler(next, bearerOrQueryToken, true)
+}
+
+func guildTokenHandler(next http.HandlerFunc, extract func(*http.Request) string, allowReadOnly bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		raw := extract(r)
+		if raw == "" || !(strings.HasPrefix(raw, tokenPrefix) || strings.HasPrefix(raw, overlayTokenPrefix)) {
+			writeError(w, http.StatusUnauthorized, "unauthorized", "missing or malformed token")
+			return
+		}
+
+		token, err := database.NewAPITokenRepository().FindByHash(HashToken(raw))
+		switch {
+		case errors.Is(err, database.ErrAPITokenNotFound):
+			writeError(w, http.StatusUnauthorized, "unauthorized", "invalid or revoked token")
+			return
+		case errors.Is(err, database.ErrAPITokenUnavailable):
+			writeError(w, http.StatusServiceUnavailable, "unavailable", "token storage is not available")
+			return
+		case err != nil:
+			logger(r).Error("api token lookup failed", "error", err)
+			writeError(w, http.StatusInternalServerError, "internal", "token lookup failed")
+			return
+		}
+
+		if token.GuildID != r.PathValue("guild") {
+			writeError(w, http.StatusForbidden, "forbidden", "token is not valid for this guild")
+			return
+		}
+		if token.ReadOnly && !allowReadOnly {
+			writeError(w, http.StatusForbidden, "forbidden", "overlay tokens can only subscribe to the live stream")
+			return
+		}
+
+		next(w, r.WithContext(context.WithValue(r.Context(), tokenContextKey{}, token)))
+	}
+}
@@ -0,0 +1,444 @@
+package webapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/hxnx/tunebot/internal/cluster"
+	"github.com/hxnx/tunebot/internal/music"
+)
+
+const (
+	maxLiveClientsPerGuild = 20
+	liveSendBuffer         = 32
+	livePositionInterval   = time.Second
+	liveResyncInterval     = 15 * time.Second
+	livePingInterval       = 20 * time.Second
+	livePongWait           = 60 * time.Second
+	liveWriteTimeout       = 10 * time.Second
+	liveReadLimit          = 4 << 10
+)
+
+var (
+	errLiveGuildFull = errors.New("too many live connections for this guild")
+	errLiveClosed    = errors.New("live stream hub is shutting down")
+)
+
+var liveUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 4096,
+	CheckOrigin: func(r *http.Request) bool {
+		return true
+	},
+}
+
+type liveRefresh uint8
+
+const (
+	refreshState liveRefresh = 1 << iota
+	refreshQueue
+	refreshSettings
+)
+
+func refreshFor(eventType music.PlayerEventType) liveRefresh {
+	switch eventType {
+	case music.PlayerEventQueueChanged:
+		return refreshQueue | refreshState
+	case music.PlayerEventSettingsChanged:
+		return refreshSettings | refreshState
+	default:
+		return refreshState
+	}
+}
+
+type liveStateMessage struct {
+	Type    string        `json:"type"`
+	GuildID string        `json:"guild_id"`
+	At      time.Time     `json:"at"`
+	State   stateResponse `json:"state"`
+}
+
+type livePositionMessage struct {
+	Type       string    `json:"type"`
+	GuildID    string    `json:"guild_id"`
+	At         time.Time `json:"at"`
+	PositionMS int64     `json:"position_ms"`
+	DurationMS int64     `json:"duration_ms"`
+}
+
+type liveQueueMessage struct {
+	Type    string              `json:"type"`
+	GuildID string              `json:"guild_id"`
+	At      time.Time           `json:"at"`
+	Items   []queueItemResponse `json:"items"`
+	Length  int                 `json:"length"`
+}
+
+type liveSettingsMessage struct {
+	Type     string              `json:"type"`
+	GuildID  string              `json:"guild_id"`
+	At       time.Time           `json:"at"`
+	Settings music.QueueSettings `json:"settings"`
+}
+
+type liveHub struct {
+	api     *API
+	relay   chan relayedEvent
+	mu      sync.Mutex
+	streams map[string]*liveStream
+	closed  bool
+}
+
+func newLiveHub(api *API) *liveHub {
+	return &liveHub{
+		api:     api,
+		relay:   make(chan relayedEvent, liveRelayBuffer),
+		streams: make(map[string]*liveStream),
+	}
+}
+
+func (h *liveHub) handleLive(w http.ResponseWriter, r *http.Request) {
+	client := newLiveClient()
+	stream, err := h.join(r.PathValue("guild"), client)
+	switch {
+	case errors.Is(err, errLiveGuildFull):
+		writeError(w, http.StatusTooManyRequests, "too_many_connections", err.Error())
+		return
+	case err != nil:
+		writeError(w, http.StatusServiceUnavailable, "unavailable", err.Error())
+		return
+	}
+
+	conn, err := liveUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		h.leave(stream, client)
+		logger(r).Warn("live stream upgrade failed", "error", err)
+		return
+	}
+	client.conn = conn
+
+	logger(r).Info("live stream connected", "remote_addr", r.RemoteAddr)
+	go client.writeLoop()
+	client.readLoop()
+	client.close(websocket.CloseNormalClosure, "")
+	h.leave(stream, client)
+	logger(r).Info("live stream disconnected", "remote_addr", r.RemoteAddr)
+}
+
+func (h *liveHub) join(guildID string, client *liveClient) (*liveStream, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, errLiveClosed
+	}
+
+	stream, ok := h.streams[guildID]
+	if !ok {
+		ctx, cancel := context.WithCancel(context.Background())
+		stream = &liveStream{
+			api:     h.api,
+			guildID: guildID,
+			cancel:  cancel,
+			wake:    make(chan struct{}, 1),
+			clients: make(map[*liveClient]struct{}),
+			log:     slog.Default().With("guild_id", guildID),
+		}
+		h.streams[guildID] = stream
+		go stream.run(ctx)
+	}
+
+	if !stream.add(client) {
+		return nil, errLiveGuildFull
+	}
+	stream.request(refreshState | refreshQueue | refreshSettings)
+	return stream, nil
+}
+
+func (h *liveHub) leave(stream *liveStream, client *liveClient) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if stream.remove(client) > 0 {
+		return
+	}
+	stream.cancel()
+	if h.streams[stream.guildID] == stream {
+		delete(h.streams, stream.guildID)
+	}
+}
+
+func (h *liveHub) notify(guildID string, eventType music.PlayerEventType) {
+	h.mu.Lock()
+	stream, ok := h.streams[guildID]
+	h.mu.Unlock()
+
+	if ok {
+		stream.request(refreshFor(eventType))
+	}
+}
+
+func (h *liveHub) shutdown() {
+	h.mu.Lock()
+	h.closed = true
+	streams := make([]*liveStream, 0, len(h.streams))
+	for _, stream := range h.streams {
+		streams = append(streams, stream)
+	}
+	h.mu.Unlock()
+
+	for _, stream := range streams {
+		stream.closeAll(websocket.CloseGoingAway, "server shutting down")
+	}
+}
+
+type liveStream struct {
+	api     *API
+	guildID string
+	cancel  context.CancelFunc
+	wake    chan struct{}
+	log     *slog.Logger
+
+	mu       sync.Mutex
+	clients  map[*liveClient]struct{}
+	pending  liveRefresh
+	last     stateResponse
+	lastAt   time.Time
+	hasState bool
+}
+
+func (st *liveStream) add(client *liveClient) bool {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if len(st.clients) >= maxLiveClientsPerGuild {
+		return false
+	}
+	st.clients[client] = struct{}{}
+	return true
+}
+
+func (st *liveStream) remove(client *liveClient) int {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	delete(st.clients, client)
+	return len(st.clients)
+}
+
+func (st *liveStream) closeAll(code int, text string) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	for client := range st.clients {
+		client.close(code, text)
+	}
+}
+
+func (st *liveStream) request(flags liveRefresh) {
+	st.mu.Lock()
+	st.pending |= flags
+	st.mu.Unlock()
+
+	select {
+	case st.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (st *liveStream) run(ctx context.Context) {
+	if !cluster.DefaultCoordinator.Owns(st.guildID) {
+		go st.followRemote(ctx)
+	}
+
+	position := time.NewTicker(livePositionInterval)
+	defer position.Stop()
+	resync := time.NewTicker(liveResyncInterval)
+	defer resync.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-st.wake:
+			st.mu.Lock()
+			flags := st.pending
+			st.pending = 0
+			st.mu.Unlock()
+			st.refresh(ctx, flags)
+		case <-resync.C:
+			st.refresh(ctx, refreshState)
+		case <-position.C:
+			st.tickPosition()
+		}
+	}
+}
+
+func (st *liveStream) refresh(ctx context.Context, flags liveRefresh) {
+	ctx, cancel := context.WithTimeout(ctx, actionTimeout)
+	defer cancel()
+
+	now := time.Now().UTC()
+
+	if flags&refreshState != 0 {
+		state, err := st.api.snapshot(ctx, st.guildID)
+		if err != nil {
+			st.log.Warn("live state refresh failed", "error", err)
+		} else {
+			st.mu.Lock()
+			st.last, st.lastAt, st.hasState = state, time.Now(), true
+			st.mu.Unlock()
+			st.broadcast(liveStateMessage{Type: "state", GuildID: st.guildID, At: now, State: state}, false)
+		}
+	}
+
+	if flags&refreshQueue != 0 {
+		items, err := st.api.service.List(ctx, st.guildID, 0)
+		if err != nil {
+			st.log.Warn("live queue refresh failed", "error", err)
+		} else {
+			length := len(items)
+			if len(items) > defaultQueueMax {
+				items = items[:defaultQueueMax]
+			}
+			st.broadcast(liveQueueMessage{
+				Type:    "queue",
+				GuildID: st.guildID,
+				At:      now,
+				Items:   newQueueResponse(items),
+				Length:  length,
+			}, false)
+		}
+	}
+
+	if flags&refreshSettings != 0 {
+		settings, err := st.api.service.GetSettings(ctx, st.guildID)
+		if err != nil {
+			st.log.Warn("live settings refresh failed", "error", err)
+		} else {
+			st.broadcast(liveSettingsMessage{Type: "settings", GuildID: st.guildID, At: now, Settings: settings}, false)
+		}
+	}
+}
+
+func (st *liveStream) tickPosition() {
+	st.mu.Lock()
+	if !st.hasState || !st.last.Playing || st.last.Paused || st.last.Track == nil {
+		st.mu.Unlock()
+		return
+	}
+	position := st.last.PositionMS + time.Since(st.lastAt).Milliseconds()
+	duration := st.last.Track.DurationMS
+	st.mu.Unlock()
+
+	if duration > 0 && position > duration {
+		position = duration
+	}
+	st.broadcast(livePositionMessage{
+		Type:       "position",
+		GuildID:    st.guildID,
+		At:         time.Now().UTC(),
+		PositionMS: position,
+		DurationMS: duration,
+	}, true)
+}
+
+func (st *liveStream) broadcast(message any, droppable bool) {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		st.log.Error("live message encode failed", "error", err)
+		return
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	for client := range st.clients {
+		if client.enqueue(payload, droppable) || droppable {
+			continue
+		}
+		st.log.Warn("live client too slow, disconnecting")
+		client.close(websocket.ClosePolicyViolation, "client is not reading fast enough")
+	}
+}
+
+type liveClient struct {
+	conn      *websocket.Conn
+	send      chan []byte
+	done      chan struct{}
+	closeOnce sync.Once
+	closeCode int
+	closeText string
+}
+
+func newLiveClient() *liveClient {
+	return &liveClient{
+		send: make(chan []byte, liveSendBuffer),
+		done: make(chan struct{}),
+	}
+}
+
+func (c *liveClient) enqueue(payload []byte, droppable bool) bool {
+	if droppable && len(c.send) >= cap(c.send)/2 {
+		return false
+	}
+	select {
+	case c.send <- payload:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *liveClient) close(code int, text string) {
+	c.closeOnce.Do(func() {
+		c.closeCode, c.closeText = code, text
+		close(c.done)
+	})
+}
+
+func (c *liveClient) writeLoop() {
+	ping := time.NewTicker(livePingInterval)
+	defer func() {
+		ping.Stop()
+		_ = c.conn.Close()
+	}()
+
+	for {
+		select {
+		case payload := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(liveWriteTimeout))
+			if err := c.conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(liveWriteTimeout)); err != nil {
+				return
+			}
+		case <-c.done:
+			message := websocket.FormatCloseMessage(c.closeCode, c.closeText)
+			_ = c.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(liveWriteTimeout))
+			return
+		}
+	}
+}
+
+func (c *liveClient) readLoop() {
+	c.conn.SetReadLimit(liveReadLimit)
+	_ = c.conn.SetReadDeadline(time.Now().Add(livePongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(livePongWait))
+	})
+
+	for {
+		if _, _, err := c.conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
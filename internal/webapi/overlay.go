@@ -0,0 +1,18 @@
+package webapi
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+//go:embed overlay.html
+var overlayPage []byte
+
+func handleOverlay(w http.ResponseWriter, r *http.Request) {
+	header := w.Header()
+	header.Set("Content-Type", "text/html; charset=utf-8")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Referrer-Policy", "no-referrer")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(overlayPage)
+}
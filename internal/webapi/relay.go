@@ -0,0 +1,98 @@
+package webapi
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/hxnx/tunebot/internal/cluster"
+	"github.com/hxnx/tunebot/internal/music"
+	internalredis "github.com/hxnx/tunebot/internal/redis"
+)
+
+const (
+	liveChannelPrefix = "tunebot:live:guild:"
+	liveRelayBuffer   = 256
+	liveRelayTimeout  = 2 * time.Second
+)
+
+type relayedEvent struct {
+	Type    music.PlayerEventType `json:"type"`
+	GuildID string                `json:"guild_id"`
+	Origin  string                `json:"origin"`
+}
+
+func liveChannel(guildID string) string {
+	return liveChannelPrefix + guildID
+}
+
+func (h *liveHub) handlePlayerEvent(event music.PlayerEvent) {
+	if event.GuildID == "" {
+		return
+	}
+
+	h.notify(event.GuildID, event.Type)
+
+	select {
+	case h.relay <- relayedEvent{Type: event.Type, GuildID: event.GuildID, Origin: cluster.DefaultCoordinator.InstanceID()}:
+	default:
+		slog.Warn("live relay event dropped: buffer full", "guild_id", event.GuildID, "type", event.Type)
+	}
+}
+
+func (h *liveHub) relayLoop() {
+	for event := range h.relay {
+		client := internalredis.Client()
+		if client == nil {
+			continue
+		}
+
+		payload, err := json.Marshal(event)
+		if err != nil {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), liveRelayTimeout)
+		if err := client.Publish(ctx, liveChannel(event.GuildID), payload).Err(); err != nil {
+			slog.Warn("live relay publish failed", "guild_id", event.GuildID, "error", err)
+		}
+		cancel()
+	}
+}
+
+func (st *liveStream) followRemote(ctx context.Context) {
+	client := internalredis.Client()
+	if client == nil {
+		return
+	}
+
+	sub := client.Subscribe(ctx, liveChannel(st.guildID))
+	defer sub.Close()
+	if _, err := sub.Receive(ctx); err != nil {
+		if ctx.Err() == nil {
+			st.log.Warn("live relay subscribe failed", "error", err)
+		}
+		return
+	}
+
+	instanceID := cluster.DefaultCoordinator.InstanceID()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-sub.Channel():
+			if !ok {
+				return
+			}
+			var event relayedEvent
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				continue
+			}
+			if event.Origin == instanceID {
+				continue
+			}
+			st.request(refreshFor(event.Type))
+		}
+	}
+}
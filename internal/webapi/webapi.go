@@ -0,0 +1,574 @@
+package webapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hxnx/tunebot/internal/cluster"
+	"github.com/hxnx/tunebot/internal/httpserver"
+	"github.com/hxnx/tunebot/internal/music"
+)
+
+const (
+	apiPrefix       = "/api/v1/guilds/{guild}"
+	maxBodySize     = 64 << 10
+	actionTimeout   = 10 * time.Second
+	enqueueTimeout  = 60 * time.Second
+	defaultQueueMax = 100
+)
+
+type API struct {
+	service *music.Service
+}
+
+func Register(server *httpserver.Server) {
+	api := &API{service: music.DefaultPlayerManager.Service()}
+
+	routes := []struct {
+		pattern string
+		handler http.HandlerFunc
+	}{
+		{"GET " + apiPrefix + "/state", api.handleState},
+		{"GET " + apiPrefix + "/queue", api.handleQueue},
+		{"POST " + apiPrefix + "/queue", api.handleEnqueue},
+		{"POST " + apiPrefix + "/queue/move", api.handleMove},
+		{"GET " + apiPrefix + "/search", api.handleSearch},
+		{"POST " + apiPrefix + "/skip", api.handleSkip},
+		{"POST " + apiPrefix + "/pause", api.handlePause},
+		{"POST " + apiPrefix + "/stop", api.handleStop},
+		{"POST " + apiPrefix + "/seek", api.handleSeek},
+		{"POST " + apiPrefix + "/volume", api.handleVolume},
+		{"GET " + apiPrefix + "/settings", api.handleGetSettings},
+		{"PATCH " + apiPrefix + "/settings", api.handleUpdateSettings},
+	}
+	for _, route := range routes {
+		server.Handle(route.pattern, withCORS(requireGuildToken(route.handler)))
+	}
+	server.Handle("OPTIONS /api/", withCORS(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	hub := newLiveHub(api)
+	music.DefaultPlayerManager.Subscribe(hub.handlePlayerEvent)
+	go hub.relayLoop()
+	server.Handle("GET "+apiPrefix+"/ws", requireGuildStreamToken(hub.handleLive))
+	server.Handle("GET /overlay/{guild}", http.HandlerFunc(handleOverlay))
+	server.OnShutdown(hub.shutdown)
+}
+
+type trackResponse struct {
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	Source      string `json:"source"`
+	DurationMS  int64  `json:"duration_ms"`
+	Thumbnail   string `json:"thumbnail,omitempty"`
+	RequestedBy string `json:"requested_by,omitempty"`
+	IsLive      bool   `json:"is_live"`
+}
+
+type queueItemResponse struct {
+	Index      int           `json:"index"`
+	Track      trackResponse `json:"track"`
+	EnqueuedAt time.Time     `json:"enqueued_at"`
+}
+
+type stateResponse struct {
+	GuildID     string              `json:"guild_id"`
+	Connected   bool                `json:"connected"`
+	Playing     bool                `json:"playing"`
+	Paused      bool                `json:"paused"`
+	Track       *trackResponse      `json:"track"`
+	PositionMS  int64               `json:"position_ms"`
+	Volume      int                 `json:"volume"`
+	Settings    music.QueueSettings `json:"settings"`
+	QueueLength int                 `json:"queue_length"`
+}
+
+type errorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+type okResponse struct {
+	OK bool `json:"ok"`
+}
+
+func newTrackResponse(track music.Track) trackResponse {
+	return trackResponse{
+		Title:       track.Title,
+		URL:         track.LinkURL(),
+		Source:      string(track.Source),
+		DurationMS:  track.Duration.Milliseconds(),
+		Thumbnail:   track.Thumbnail,
+		RequestedBy: track.RequestedBy,
+		IsLive:      track.IsLive,
+	}
+}
+
+func (a *API) handleState(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), actionTimeout)
+	defer cancel()
+
+	resp, err := a.snapshot(ctx, r.PathValue("guild"))
+	if err != nil {
+		writeActionError(w, r, err)
+		return
+	}
+	httpserver.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (a *API) snapshot(ctx context.Context, guildID string) (stateResponse, error) {
+	status, err := cluster.State(ctx, guildID)
+	if err != nil {
+		return stateResponse{}, err
+	}
+	settings, err := a.service.GetSettings(ctx, guildID)
+	if err != nil {
+		return stateResponse{}, err
+	}
+	queueLength, err := a.service.QueueSize(ctx, guildID)
+	if err != nil {
+		return stateResponse{}, err
+	}
+
+	resp := stateResponse{
+		GuildID:     guildID,
+		Connected:   status.Connected,
+		Playing:     status.State.IsPlaying,
+		Paused:      status.State.PausedAt != nil,
+		PositionMS:  status.State.Position.Milliseconds(),
+		Volume:      status.State.Volume,
+		Settings:    settings,
+		QueueLength: int(queueLength),
+	}
+	if status.State.Track != nil {
+		track := newTrackResponse(*status.State.Track)
+		resp.Track = &track
+	}
+	return resp, nil
+}
+
+func (a *API) handleQueue(w http.ResponseWriter, r *http.Request) {
+	limit := defaultQueueMax
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			writeError(w, http.StatusBadRequest, "invalid_request", "limit must be a positive integer")
+			return
+		}
+		limit = parsed
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), actionTimeout)
+	defer cancel()
+
+	items, err := a.service.List(ctx, r.PathValue("guild"), int64(limit))
+	if err != nil {
+		writeActionError(w, r, err)
+		return
+	}
+
+	httpserver.WriteJSON(w, http.StatusOK, newQueueResponse(items))
+}
+
+func newQueueResponse(items []music.QueueItem) []queueItemResponse {
+	resp := make([]queueItemResponse, 0, len(items))
+	for idx, item := range items {
+		resp = append(resp, queueItemResponse{
+			Index:      idx,
+			Track:      newTrackResponse(item.Track),
+			EnqueuedAt: item.EnqueuedAt,
+		})
+	}
+	return resp
+}
+
+type enqueueRequest struct {
+	URL    string `json:"url"`
+	Query  string `json:"query"`
+	Source string `json:"source"`
+}
+
+func (a *API) handleEnqueue(w http.ResponseWriter, r *http.Request) {
+	var req enqueueRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	req.URL = strings.TrimSpace(req.URL)
+	req.Query = strings.TrimSpace(req.Query)
+	if (req.URL == "") == (req.Query == "") {
+		writeError(w, http.StatusBadRequest, "invalid_request", "exactly one of url or query is required")
+		return
+	}
+
+	source, ok := parseSource(req.Source)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "invalid_request", "unsupported source")
+		return
+	}
+
+	token, _ := TokenFromContext(r.Context())
+	guildID := r.PathValue("guild")
+
+	ctx, cancel := context.WithTimeout(r.Context(), enqueueTimeout)
+	defer cancel()
+
+	input := req.URL
+	if req.Query != "" {
+		if source == music.TrackSourceUnknown {
+			source = music.DefaultPlayerManager.Providers().Detect(req.Query)
+		}
+		results, err := music.SearchTracks(ctx, req.Query, source, 1, music.DefaultPlayerManager.Providers())
+		if err != nil {
+			writeActionError(w, r, err)
+			return
+		}
+		input, source = results[0].URL, results[0].Source
+	} else if source == music.TrackSourceUnknown {
+		source = music.DefaultPlayerManager.Providers().Detect(input)
+	}
+
+	item, err := cluster.Enqueue(ctx, guildID, token.CreatedBy, input, source)
+	if err != nil {
+		writeActionError(w, r, err)
+		return
+	}
+
+	logger(r).Info("api enqueue", "track_id", item.Track.ID, "source", item.Track.Source)
+	httpserver.WriteJSON(w, http.StatusCreated, enqueueResponse{
+		Track:      newTrackResponse(item.Track),
+		EnqueuedAt: item.EnqueuedAt,
+	})
+}
+
+type enqueueResponse struct {
+	Track      trackResponse `json:"track"`
+	EnqueuedAt time.Time     `json:"enqueued_at"`
+}
+
+type moveRequest struct {
+	From *int `json:"from"`
+	To   *int `json:"to"`
+}
+
+func (a *API) handleMove(w http.ResponseWriter, r *http.Request) {
+	var req moveRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.From == nil || req.To == nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "from and to are required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), actionTimeout)
+	defer cancel()
+
+	if err := cluster.MoveQueueItem(ctx, r.PathValue("guild"), *req.From, *req.To); err != nil {
+		writeActionError(w, r, err)
+		return
+	}
+	httpserver.WriteJSON(w, http.StatusOK, okResponse{OK: true})
+}
+
+func (a *API) handleSearch(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request", "q is required")
+		return
+	}
+	source, ok := parseSource(r.URL.Query().Get("source"))
+	if !ok {
+		writeError(w, http.StatusBadRequest, "invalid_request", "unsupported source")
+		return
+	}
+	if source == music.TrackSourceUnknown {
+		source = music.DefaultPlayerManager.Providers().Detect(query)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), enqueueTimeout)
+	defer cancel()
+
+	results, err := music.SearchTracks(ctx, query, source, 0, music.DefaultPlayerManager.Providers())
+	if err != nil {
+		writeActionError(w, r, err)
+		return
+	}
+
+	resp := make([]trackResponse, 0, len(results))
+	for _, track := range results {
+		resp = append(resp, newTrackResponse(track))
+	}
+	httpserver.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (a *API) handleSkip(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), actionTimeout)
+	defer cancel()
+
+	guildID := r.PathValue("guild")
+	status, err := cluster.State(ctx, guildID)
+	if err != nil {
+		writeActionError(w, r, err)
+		return
+	}
+	if !status.State.IsPlaying {
+		writeActionError(w, r, music.ErrNotPlaying)
+		return
+	}
+
+	if err := cluster.Skip(ctx, guildID); err != nil {
+		writeActionError(w, r, err)
+		return
+	}
+	logger(r).Info("api skip")
+	httpserver.WriteJSON(w, http.StatusOK, okResponse{OK: true})
+}
+
+type pauseRequest struct {
+	Paused *bool `json:"paused"`
+}
+
+func (a *API) handlePause(w http.ResponseWriter, r *http.Request) {
+	var req pauseRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), actionTimeout)
+	defer cancel()
+
+	guildID := r.PathValue("guild")
+	status, err := cluster.State(ctx, guildID)
+	if err != nil {
+		writeActionError(w, r, err)
+		return
+	}
+	if !status.State.IsPlaying {
+		writeActionError(w, r, music.ErrNotPlaying)
+		return
+	}
+
+	paused := status.State.PausedAt != nil
+	if req.Paused == nil || *req.Paused != paused {
+		if err := cluster.TogglePause(ctx, guildID); err != nil {
+			writeActionError(w, r, err)
+			return
+		}
+		paused = !paused
+	}
+	httpserver.WriteJSON(w, http.StatusOK, struct {
+		Paused bool `json:"paused"`
+	}{Paused: paused})
+}
+
+type stopRequest struct {
+	ClearQueue bool `json:"clear_queue"`
+}
+
+func (a *API) handleStop(w http.ResponseWriter, r *http.Request) {
+	var req stopRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), actionTimeout)
+	defer cancel()
+
+	if err := cluster.Stop(ctx, r.PathValue("guild"), req.ClearQueue); err != nil {
+		writeActionError(w, r, err)
+		return
+	}
+	logger(r).Info("api stop", "clear_queue", req.ClearQueue)
+	httpserver.WriteJSON(w, http.StatusOK, okResponse{OK: true})
+}
+
+type seekRequest struct {
+	PositionMS *int64 `json:"position_ms"`
+}
+
+func (a *API) handleSeek(w http.ResponseWriter, r *http.Request) {
+	var req seekRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.PositionMS == nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "position_ms is required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), actionTimeout)
+	defer cancel()
+
+	position := time.Duration(*req.PositionMS) * time.Millisecond
+	if err := cluster.Seek(ctx, r.PathValue("guild"), position); err != nil {
+		writeActionError(w, r, err)
+		return
+	}
+	httpserver.WriteJSON(w, http.StatusOK, okResponse{OK: true})
+}
+
+type volumeRequest struct {
+	Volume *int `json:"volume"`
+}
+
+func (a *API) handleVolume(w http.ResponseWriter, r *http.Request) {
+	var req volumeRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.Volume == nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "volume is required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), actionTimeout)
+	defer cancel()
+
+	if err := cluster.SetVolume(ctx, r.PathValue("guild"), *req.Volume); err != nil {
+		writeActionError(w, r, err)
+		return
+	}
+	httpserver.WriteJSON(w, http.StatusOK, okResponse{OK: true})
+}
+
+func (a *API) handleGetSettings(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), actionTimeout)
+	defer cancel()
+
+	settings, err := a.service.GetSettings(ctx, r.PathValue("guild"))
+	if err != nil {
+		writeActionError(w, r, err)
+		return
+	}
+	httpserver.WriteJSON(w, http.StatusOK, settings)
+}
+
+type settingsRequest struct {
+	RepeatMode *music.RepeatMode `json:"repeat_mode"`
+	Shuffle    *bool             `json:"shuffle"`
+}
+
+func (a *API) handleUpdateSettings(w http.ResponseWriter, r *http.Request) {
+	var req settingsRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), actionTimeout)
+	defer cancel()
+
+	guildID := r.PathValue("guild")
+	settings, err := a.service.GetSettings(ctx, guildID)
+	if err != nil {
+		writeActionError(w, r, err)
+		return
+	}
+
+	if req.RepeatMode != nil {
+		switch *req.RepeatMode {
+		case music.RepeatModeNone, music.RepeatModeTrack, music.RepeatModeQueue:
+			settings.RepeatMode = *req.RepeatMode
+		default:
+			writeError(w, http.StatusBadRequest, "invalid_request", "repeat_mode must be none, track or queue")
+			return
+		}
+	}
+	if req.Shuffle != nil {
+		settings.Shuffle = *req.Shuffle
+	}
+
+	if err := cluster.UpdateSettings(ctx, guildID, settings); err != nil {
+		writeActionError(w, r, err)
+		return
+	}
+	httpserver.WriteJSON(w, http.StatusOK, settings)
+}
+
+func parseSource(raw string) (music.TrackSource, bool) {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+	if raw == "" || raw == "auto" {
+		return music.TrackSourceUnknown, true
+	}
+	source := music.TrackSource(raw)
+	if _, ok := music.DefaultPlayerManager.Providers().Get(source); !ok {
+		return "", false
+	}
+	return source, true
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, dst any) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dst); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid_request", "invalid JSON body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func writeError(w http.ResponseWriter, status int, code string, message string) {
+	httpserver.WriteJSON(w, status, errorResponse{Error: code, Message: message})
+}
+
+func writeActionError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, music.ErrQueueFull):
+		writeError(w, http.StatusConflict, "queue_full", "the queue is full")
+	case errors.Is(err, music.ErrNoVoiceChannel):
+		writeError(w, http.StatusConflict, "no_voice_channel", "the token owner must be in a voice channel to start playback")
+	case errors.Is(err, music.ErrNotPlaying), errors.Is(err, music.ErrPlaybackStopped):
+		writeError(w, http.StatusConflict, "not_playing", "nothing is playing")
+	case errors.Is(err, music.ErrSeekUnsupported):
+		writeError(w, http.StatusUnprocessableEntity, "seek_unsupported", "the current track cannot be seeked")
+	case errors.Is(err, music.ErrSeekOutOfRange):
+		writeError(w, http.StatusUnprocessableEntity, "seek_out_of_range", "position is outside the current track")
+	case errors.Is(err, music.ErrInvalidVolume):
+		writeError(w, http.StatusUnprocessableEntity, "invalid_volume", fmt.Sprintf("volume must be between 0 and %d", music.MaxVolume))
+	case errors.Is(err, music.ErrQueueIndexOutOfRange):
+		writeError(w, http.StatusUnprocessableEntity, "queue_index_out_of_range", "queue index is out of range")
+	case errors.Is(err, music.ErrMissingInput):
+		writeError(w, http.StatusBadRequest, "invalid_request", "input is required")
+	case errors.Is(err, music.ErrSpotifyClientNil):
+		writeError(w, http.StatusUnprocessableEntity, "spotify_not_configured", "Spotify is not configured on this bot")
+	case errors.Is(err, music.ErrSearchUnsupported):
+		writeError(w, http.StatusUnprocessableEntity, "search_unsupported", "this source does not support search")
+	case errors.Is(err, music.ErrResolveFailed):
+		writeError(w, http.StatusUnprocessableEntity, "resolve_failed", "the input could not be resolved")
+	case errors.Is(err, cluster.ErrShardUnavailable),
+		errors.Is(err, cluster.ErrDispatchTimeout),
+		errors.Is(err, cluster.ErrRedisUnavailable),
+		errors.Is(err, cluster.ErrSessionUnavailable),
+		errors.Is(err, music.ErrGuildNotOwned):
+		writeError(w, http.StatusServiceUnavailable, "unavailable", "the instance serving this guild is not reachable")
+	default:
+		logger(r).Error("api request failed", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal", "request failed")
+	}
+}
+
+func logger(r *http.Request) *slog.Logger {
+	l := slog.Default().With("guild_id", r.PathValue("guild"), "method", r.Method, "path", r.URL.Path)
+	if token, ok := TokenFromContext(r.Context()); ok {
+		l = l.With("token_id", token.ID, "user_id", token.CreatedBy)
+	}
+	return l
+}
+
+func withCORS(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		header := w.Header()
+		header.Set("Access-Control-Allow-Origin", "*")
+		header.Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+		header.Set("Access-Control-Allow-Methods", "GET, POST, PATCH, OPTIONS")
+		next(w, r)
+	}
+}
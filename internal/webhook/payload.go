@@ -0,0 +1,105 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hxnx/tunebot/internal/music"
+)
+
+const (
+	EventTrackStarted    = "track.started"
+	EventTrackEnded      = "track.ended"
+	EventQueueAdded      = "queue.added"
+	EventPlaybackStopped = "playback.stopped"
+	EventPing            = "ping"
+)
+
+type Envelope struct {
+	Event      string    `json:"event"`
+	GuildID    string    `json:"guild_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       any       `json:"data"`
+}
+
+type Track struct {
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	Source      string `json:"source"`
+	DurationMS  int64  `json:"duration_ms"`
+	Thumbnail   string `json:"thumbnail,omitempty"`
+	RequestedBy string `json:"requested_by,omitempty"`
+	IsLive      bool   `json:"is_live"`
+}
+
+type TrackData struct {
+	Track Track `json:"track"`
+}
+
+type TrackEndedData struct {
+	Track  Track  `json:"track"`
+	Reason string `json:"reason"`
+}
+
+type QueueAddedData struct {
+	Tracks      []Track `json:"tracks"`
+	RequestedBy string  `json:"requested_by,omitempty"`
+}
+
+type PlaybackStoppedData struct {
+	QueueCleared bool `json:"queue_cleared"`
+}
+
+type PingData struct {
+	WebhookID   int64  `json:"webhook_id"`
+	RequestedBy string `json:"requested_by"`
+}
+
+func NewTrack(track music.Track) Track {
+	return Track{
+		Title:       track.Title,
+		URL:         track.LinkURL(),
+		Source:      string(track.Source),
+		DurationMS:  track.Duration.Milliseconds(),
+		Thumbnail:   track.Thumbnail,
+		RequestedBy: track.RequestedBy,
+		IsLive:      track.IsLive,
+	}
+}
+
+func Encode(event string, guildID string, at time.Time, data any) ([]byte, error) {
+	return json.Marshal(Envelope{
+		Event:      event,
+		GuildID:    guildID,
+		OccurredAt: at.UTC(),
+		Data:       data,
+	})
+}
+
+func FromPlayerEvent(event music.PlayerEvent) (string, []byte, bool, error) {
+	var name string
+	var data any
+
+	switch event.Type {
+	case music.PlayerEventTrackStarted:
+		name, data = EventTrackStarted, TrackData{Track: NewTrack(event.Item.Track)}
+	case music.PlayerEventTrackEnded:
+		name, data = EventTrackEnded, TrackEndedData{Track: NewTrack(event.Item.Track), Reason: string(event.Reason)}
+	case music.PlayerEventTrackQueued:
+		tracks := make([]Track, 0, len(event.Items))
+		for _, item := range event.Items {
+			tracks = append(tracks, NewTrack(item.Track))
+		}
+		if len(tracks) == 0 {
+			return "", nil, false, nil
+		}
+		name, data = EventQueueAdded, QueueAddedData{Tracks: tracks, RequestedBy: event.Items[0].Track.RequestedBy}
+	case music.PlayerEventStopped:
+		name, data = EventPlaybackStopped, PlaybackStoppedData{QueueCleared: event.Cleared}
+	default:
+		return "", nil, false, nil
+	}
+
+	body, err := Encode(name, event.GuildID, event.At, data)
+	return name, body, true, err
+}
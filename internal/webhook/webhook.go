@@ -0,0 +1,150 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/hxnx/tunebot/internal/netguard"
+)
+
+const (
+	SignatureHeader = "X-TuneBot-Signature"
+	TimestampHeader = "X-TuneBot-Timestamp"
+	EventHeader     = "X-TuneBot-Event"
+	DeliveryHeader  = "X-TuneBot-Delivery"
+
+	secretPrefix    = "whsec_"
+	signaturePrefix = "sha256="
+	maxURLLength    = 2048
+	requestTimeout  = 10 * time.Second
+	maxResponseRead = 64 << 10
+	userAgent       = "TuneBot-Webhook/1.0"
+)
+
+var (
+	ErrInvalidURL     = errors.New("webhook url must be an absolute http or https url")
+	ErrPrivateAddress = netguard.ErrPrivateAddress
+)
+
+type StatusError struct {
+	Code int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("webhook receiver responded with status %d", e.Code)
+}
+
+type Request struct {
+	DeliveryID int64
+	URL        string
+	Secret     string
+	Event      string
+	Payload    []byte
+}
+
+var allowPrivate atomic.Bool
+
+func SetAllowPrivate(value bool) {
+	allowPrivate.Store(value)
+}
+
+func GenerateSecret() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return secretPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func Verify(secret string, signature string, timestamp int64, body []byte) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+func ValidateURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || len(raw) > maxURLLength {
+		return "", ErrInvalidURL
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" || parsed.User != nil {
+		return "", ErrInvalidURL
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", ErrInvalidURL
+	}
+	if !allowPrivate.Load() && netguard.IsPrivateLiteral(parsed.Hostname()) {
+		return "", ErrPrivateAddress
+	}
+	return parsed.String(), nil
+}
+
+func NewClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: netguard.Control(allowPrivate.Load),
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   requestTimeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func Send(ctx context.Context, client *http.Client, req Request) (int, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	header := httpReq.Header
+	header.Set("Content-Type", "application/json")
+	header.Set("User-Agent", userAgent)
+	header.Set(EventHeader, req.Event)
+	header.Set(DeliveryHeader, strconv.FormatInt(req.DeliveryID, 10))
+	header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	header.Set(SignatureHeader, Sign(req.Secret, timestamp, req.Payload))
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseRead))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, &StatusError{Code: resp.StatusCode}
+	}
+	return resp.StatusCode, nil
+}
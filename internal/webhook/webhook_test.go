@@ -0,0 +1,182 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hxnx/tunebot/internal/database"
+)
+
+type recordingStore struct {
+	mu          sync.Mutex
+	delivered   []int64
+	rescheduled map[int64]time.Time
+	deadLetters map[int64]string
+}
+
+func newRecordingStore() *recordingStore {
+	return &recordingStore{
+		rescheduled: make(map[int64]time.Time),
+		deadLetters: make(map[int64]string),
+	}
+}
+
+func (s *recordingStore) MarkDelivered(d database.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.delivered = append(s.delivered, d.ID)
+	return nil
+}
+
+func (s *recordingStore) Reschedule(d database.WebhookDelivery, next time.Time, lastError string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rescheduled[d.ID] = next
+	return nil
+}
+
+func (s *recordingStore) DeadLetter(d database.WebhookDelivery, lastError string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deadLetters[d.ID] = lastError
+	return nil
+}
+
+type receiver struct {
+	t      *testing.T
+	secret string
+	status int
+
+	mu       sync.Mutex
+	verified int
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		rc.t.Errorf("read body: %v", err)
+	}
+	timestamp, err := strconv.ParseInt(r.Header.Get(TimestampHeader), 10, 64)
+	if err != nil {
+		rc.t.Errorf("invalid %s header: %v", TimestampHeader, err)
+	}
+	if !Verify(rc.secret, r.Header.Get(SignatureHeader), timestamp, body) {
+		rc.t.Errorf("signature %q did not verify", r.Header.Get(SignatureHeader))
+	} else {
+		rc.mu.Lock()
+		rc.verified++
+		rc.mu.Unlock()
+	}
+	if got := r.Header.Get(EventHeader); got != "track.started" {
+		rc.t.Errorf("%s = %q, want track.started", EventHeader, got)
+	}
+	w.WriteHeader(rc.status)
+}
+
+func newTestWorker(t *testing.T, rc *receiver) (*Worker, string) {
+	t.Helper()
+	server := httptest.NewServer(rc)
+	t.Cleanup(server.Close)
+
+	SetAllowPrivate(true)
+	t.Cleanup(func() { SetAllowPrivate(false) })
+	return NewWorker(NewClient()), server.URL
+}
+
+func testDelivery(id int64, url string, secret string, attempts int) database.WebhookDelivery {
+	return database.WebhookDelivery{
+		ID:       id,
+		URL:      url,
+		Secret:   secret,
+		Event:    "track.started",
+		Payload:  `{"event":"track.started"}`,
+		Attempts: attempts,
+	}
+}
+
+func TestDeliverSignsRequest(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret: %v", err)
+	}
+	rc := &receiver{t: t, secret: secret, status: http.StatusNoContent}
+	worker, url := newTestWorker(t, rc)
+	store := newRecordingStore()
+
+	worker.deliver(context.Background(), store, testDelivery(1, url, secret, 1))
+
+	if rc.verified != 1 {
+		t.Fatalf("verified %d requests, want 1", rc.verified)
+	}
+	if len(store.delivered) != 1 || store.delivered[0] != 1 {
+		t.Errorf("delivered = %v, want [1]", store.delivered)
+	}
+	if len(store.rescheduled) != 0 || len(store.deadLetters) != 0 {
+		t.Errorf("unexpected retry bookkeeping: rescheduled=%v dead=%v", store.rescheduled, store.deadLetters)
+	}
+}
+
+func TestDeliverReschedulesServerErrors(t *testing.T) {
+	rc := &receiver{t: t, secret: "whsec_test", status: http.StatusServiceUnavailable}
+	worker, url := newTestWorker(t, rc)
+	store := newRecordingStore()
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		id := int64(attempt)
+		before := time.Now()
+		worker.deliver(context.Background(), store, testDelivery(id, url, rc.secret, attempt))
+		after := time.Now()
+
+		next, ok := store.rescheduled[id]
+		if !ok {
+			t.Fatalf("attempt %d was not rescheduled", attempt)
+		}
+		base := baseBackoff << (attempt - 1)
+		if next.Before(before.Add(base)) || next.After(after.Add(base+base/5+1)) {
+			t.Errorf("attempt %d rescheduled %s out, want within [%s, %s]", attempt, next.Sub(before), base, base+base/5)
+		}
+	}
+	if len(store.delivered) != 0 || len(store.deadLetters) != 0 {
+		t.Errorf("unexpected bookkeeping: delivered=%v dead=%v", store.delivered, store.deadLetters)
+	}
+}
+
+func TestDeliverDeadLettersAfterMaxAttempts(t *testing.T) {
+	rc := &receiver{t: t, secret: "whsec_test", status: http.StatusInternalServerError}
+	worker, url := newTestWorker(t, rc)
+	store := newRecordingStore()
+
+	worker.deliver(context.Background(), store, testDelivery(7, url, rc.secret, MaxAttempts))
+
+	message, ok := store.deadLetters[7]
+	if !ok {
+		t.Fatal("delivery was not dead-lettered")
+	}
+	if want := (&StatusError{Code: http.StatusInternalServerError}).Error(); message != want {
+		t.Errorf("dead letter error = %q, want %q", message, want)
+	}
+	if len(store.rescheduled) != 0 {
+		t.Errorf("exhausted delivery was rescheduled: %v", store.rescheduled)
+	}
+}
+
+func TestBackoffBounds(t *testing.T) {
+	for attempt := 1; attempt <= 20; attempt++ {
+		base := maxBackoff
+		if attempt <= 16 {
+			base = min(baseBackoff<<(attempt-1), maxBackoff)
+		}
+		for range 50 {
+			delay := Backoff(attempt)
+			if delay < base || delay > base+base/5 {
+				t.Fatalf("Backoff(%d) = %s, want within [%s, %s]", attempt, delay, base, base+base/5)
+			}
+		}
+	}
+}
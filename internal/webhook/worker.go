@@ -0,0 +1,120 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hxnx/tunebot/internal/database"
+	"github.com/hxnx/tunebot/internal/retry"
+)
+
+const (
+	MaxAttempts     = 8
+	pollInterval    = 5 * time.Second
+	claimBatch      = 10
+	claimLease      = time.Minute
+	baseBackoff     = 10 * time.Second
+	maxBackoff      = time.Hour
+	maxErrorLength  = 500
+	deliveryTimeout = requestTimeout + 5*time.Second
+)
+
+type deliveryStore interface {
+	MarkDelivered(d database.WebhookDelivery) error
+	Reschedule(d database.WebhookDelivery, next time.Time, lastError string) error
+	DeadLetter(d database.WebhookDelivery, lastError string) error
+}
+
+type Worker struct {
+	*retry.Worker
+	client *http.Client
+}
+
+var DefaultWorker = NewWorker(NewClient())
+
+func NewWorker(client *http.Client) *Worker {
+	if client == nil {
+		client = NewClient()
+	}
+	w := &Worker{client: client}
+	w.Worker = retry.NewWorker(pollInterval, w.drain)
+	return w
+}
+
+func Backoff(attempt int) time.Duration {
+	return retry.Backoff(attempt, baseBackoff, maxBackoff)
+}
+
+func (w *Worker) drain(ctx context.Context) {
+	repo := database.NewWebhookRepository()
+	if !repo.Available() {
+		return
+	}
+
+	for ctx.Err() == nil {
+		deliveries, err := repo.ClaimDue(claimBatch, claimLease)
+		if err != nil {
+			slog.Warn("webhook delivery claim failed", "error", err)
+			return
+		}
+
+		var wg sync.WaitGroup
+		for _, d := range deliveries {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				w.deliver(ctx, repo, d)
+			}()
+		}
+		wg.Wait()
+
+		if len(deliveries) < claimBatch {
+			return
+		}
+	}
+}
+
+func (w *Worker) deliver(ctx context.Context, repo deliveryStore, d database.WebhookDelivery) {
+	logger := slog.Default().With("guild_id", d.GuildID, "webhook_id", d.WebhookID, "delivery_id", d.ID, "event", d.Event, "attempt", d.Attempts)
+
+	sendCtx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	_, err := Send(sendCtx, w.client, Request{
+		DeliveryID: d.ID,
+		URL:        d.URL,
+		Secret:     d.Secret,
+		Event:      d.Event,
+		Payload:    []byte(d.Payload),
+	})
+	cancel()
+
+	if err == nil {
+		if err := repo.MarkDelivered(d); err != nil {
+			logger.Error("webhook delivery bookkeeping failed", "error", err)
+		}
+		return
+	}
+
+	message := err.Error()
+	if len(message) > maxErrorLength {
+		message = message[:maxErrorLength]
+	}
+
+	switch {
+	case ctx.Err() != nil:
+		err = repo.Reschedule(d, time.Now(), message)
+	case d.Attempts >= MaxAttempts || errors.Is(err, ErrPrivateAddress):
+		logger.Warn("webhook delivery moved to dead letters", "error", message)
+		err = repo.DeadLetter(d, message)
+	default:
+		next := time.Now().Add(Backoff(d.Attempts))
+		logger.Info("webhook delivery failed, retrying", "error", message, "next_attempt_at", next)
+		err = repo.Reschedule(d, next, message)
+	}
+	if err != nil {
+		logger.Error("webhook delivery bookkeeping failed", "error", err)
+	}
+}